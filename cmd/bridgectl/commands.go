@@ -0,0 +1,294 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/contracts"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/processor"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/signer"
+)
+
+// ReceiptTimeout is how long a sent transaction is waited for.
+const ReceiptTimeout = 5 * time.Minute
+
+// Backend is the node connection commands run against. An ethclient.Client
+// and the go-ethereum simulated backend satisfy it.
+type Backend interface {
+	bind.ContractBackend
+	bind.DeployBackend
+	ChainID(ctx context.Context) (*big.Int, error)
+}
+
+// Options are how a command runs.
+type Options struct {
+	// Bridge is the BatchBridge the command is for. Deploy ignores it.
+	Bridge common.Address
+	// DryRun prints the calldata of the transaction instead of sending it.
+	DryRun bool
+	// Unsigned prints the transaction From would send, with its nonce, gas
+	// and fees filled in, for signing offline.
+	Unsigned bool
+	From     common.Address
+	// Signer signs and sends the transaction otherwise.
+	Signer signer.Signer
+}
+
+// call is a BatchBridge transaction: a method and its arguments, or the
+// deployment of the contract when method is empty.
+type call struct {
+	method string
+	params []interface{}
+}
+
+// Call is what a dry run prints: the transaction's recipient, left out for
+// a deployment, and its calldata.
+type Call struct {
+	To   *common.Address `json:"to,omitempty"`
+	Data hexutil.Bytes   `json:"data"`
+}
+
+// parseCall turns a transaction command and its arguments into a call.
+func parseCall(name string, args []string) (*call, error) {
+	want := map[string]int{
+		"deploy":               1,
+		"transferAdmin":        1,
+		"addSupportedToken":    1,
+		"removeSupportedToken": 1,
+		"addOperator":          1,
+		"removeOperator":       1,
+		"addAttester":          1,
+		"removeAttester":       1,
+		"setAttesterThreshold": 1,
+		"pause":                0,
+		"unpause":              0,
+		"emergencyWithdraw":    3,
+	}
+	n, ok := want[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown command %q", name)
+	}
+	if len(args) != n {
+		return nil, fmt.Errorf("%s takes %d arguments, got %d", name, n, len(args))
+	}
+
+	switch name {
+	case "deploy":
+		admin, err := parseAddress(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return &call{params: []interface{}{admin}}, nil
+	case "addSupportedToken", "removeSupportedToken":
+		token, err := parseToken(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return &call{method: name, params: []interface{}{token}}, nil
+	case "addOperator", "removeOperator", "addAttester", "removeAttester", "transferAdmin":
+		account, err := parseAddress(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return &call{method: name, params: []interface{}{account}}, nil
+	case "setAttesterThreshold":
+		threshold, ok := new(big.Int).SetString(args[0], 10)
+		if !ok || threshold.Sign() <= 0 {
+			return nil, fmt.Errorf("invalid threshold %q: must be a positive number of attesters", args[0])
+		}
+		return &call{method: name, params: []interface{}{threshold}}, nil
+	case "emergencyWithdraw":
+		token, err := parseToken(args[0])
+		if err != nil {
+			return nil, err
+		}
+		recipient, err := parseAddress(args[1])
+		if err != nil {
+			return nil, err
+		}
+		amount, ok := new(big.Int).SetString(args[2], 10)
+		if !ok || amount.Sign() <= 0 {
+			return nil, fmt.Errorf("invalid amount %q: must be a positive number of the token's base units", args[2])
+		}
+		return &call{method: name, params: []interface{}{token, recipient, amount}}, nil
+	default:
+		return &call{method: name}, nil
+	}
+}
+
+// parseToken parses a token address, or "native" for the native coin.
+func parseToken(s string) (common.Address, error) {
+	if s == "native" {
+		return processor.NativeToken, nil
+	}
+	return parseAddress(s)
+}
+
+func parseAddress(s string) (common.Address, error) {
+	if !common.IsHexAddress(s) {
+		return common.Address{}, fmt.Errorf("invalid address %q", s)
+	}
+	return common.HexToAddress(s), nil
+}
+
+// Run runs the command name with args against backend, writing what it
+// prints to out.
+func Run(ctx context.Context, backend Backend, opts *Options, name string, args []string, out io.Writer) error {
+	if name == "completedBatches" {
+		if opts.DryRun || opts.Unsigned {
+			return fmt.Errorf("completedBatches is a query: -dry-run and -unsigned only apply to transactions")
+		}
+		return completedBatches(ctx, backend, opts, args, out)
+	}
+
+	c, err := parseCall(name, args)
+	if err != nil {
+		return err
+	}
+	bridgeABI, err := contracts.BatchBridgeMetaData.GetAbi()
+	if err != nil {
+		return fmt.Errorf("error parsing bridge ABI: %v", err)
+	}
+
+	if opts.DryRun {
+		data, err := calldata(bridgeABI, c)
+		if err != nil {
+			return err
+		}
+		result := &Call{Data: data}
+		if c.method != "" {
+			result.To = &opts.Bridge
+		}
+		return printJSON(out, result)
+	}
+
+	chainID, err := backend.ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting chain ID: %v", err)
+	}
+	tx, err := transact(ctx, backend, bridgeABI, transactOpts(ctx, opts, chainID), opts.Bridge, c)
+	if err != nil {
+		return err
+	}
+	if opts.Unsigned {
+		return printJSON(out, signer.NewTransactionArgs(opts.From, tx, chainID))
+	}
+
+	fmt.Fprintf(out, "sent %s\n", tx.Hash().Hex())
+	waitCtx, cancel := context.WithTimeout(ctx, ReceiptTimeout)
+	defer cancel()
+	receipt, err := bind.WaitMined(waitCtx, backend, tx)
+	if err != nil {
+		return fmt.Errorf("error waiting for transaction %s: %v", tx.Hash().Hex(), err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return fmt.Errorf("transaction %s reverted", tx.Hash().Hex())
+	}
+	if c.method == "" {
+		fmt.Fprintf(out, "deployed BatchBridge at %s in block %d\n", receipt.ContractAddress.Hex(), receipt.BlockNumber)
+	} else {
+		fmt.Fprintf(out, "%s mined in block %d\n", c.method, receipt.BlockNumber)
+	}
+	return nil
+}
+
+// calldata encodes c: the method call, or the contract's creation code
+// followed by the constructor arguments.
+func calldata(bridgeABI *abi.ABI, c *call) ([]byte, error) {
+	if c.method == "" {
+		args, err := bridgeABI.Pack("", c.params...)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding constructor arguments: %v", err)
+		}
+		return append(common.FromHex(contracts.BatchBridgeMetaData.Bin), args...), nil
+	}
+	data, err := bridgeABI.Pack(c.method, c.params...)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding %s: %v", c.method, err)
+	}
+	return data, nil
+}
+
+// transactOpts signs with opts.Signer, or for unsigned transactions leaves
+// them unsigned and unsent once their nonce, gas and fees are filled in.
+func transactOpts(ctx context.Context, opts *Options, chainID *big.Int) *bind.TransactOpts {
+	if opts.Unsigned {
+		return &bind.TransactOpts{
+			From: opts.From,
+			Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+				return tx, nil
+			},
+			NoSend:  true,
+			Context: ctx,
+		}
+	}
+	return &bind.TransactOpts{
+		From: opts.Signer.Address(),
+		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != opts.Signer.Address() {
+				return nil, bind.ErrNotAuthorized
+			}
+			return opts.Signer.SignTx(ctx, tx, chainID)
+		},
+		Context: ctx,
+	}
+}
+
+func transact(ctx context.Context, backend Backend, bridgeABI *abi.ABI, txOpts *bind.TransactOpts, bridge common.Address, c *call) (*types.Transaction, error) {
+	if c.method == "" {
+		_, tx, _, err := bind.DeployContract(txOpts, *bridgeABI, common.FromHex(contracts.BatchBridgeMetaData.Bin), backend, c.params...)
+		if err != nil {
+			return nil, fmt.Errorf("error deploying bridge: %v", err)
+		}
+		return tx, nil
+	}
+
+	bound := bind.NewBoundContract(bridge, *bridgeABI, backend, backend, backend)
+	tx, err := bound.Transact(txOpts, c.method, c.params...)
+	if err != nil {
+		return nil, fmt.Errorf("error sending %s: %v", c.method, err)
+	}
+	return tx, nil
+}
+
+// completedBatches prints whether the bridge has completed the batch from
+// the source chain with the on-chain id in args.
+func completedBatches(ctx context.Context, backend Backend, opts *Options, args []string, out io.Writer) error {
+	if len(args) != 2 {
+		return fmt.Errorf("completedBatches takes 2 arguments, got %d", len(args))
+	}
+	sourceChainID, ok := new(big.Int).SetString(args[0], 10)
+	if !ok || sourceChainID.Sign() <= 0 {
+		return fmt.Errorf("invalid source chain id %q", args[0])
+	}
+	id, err := hexutil.Decode(args[1])
+	if err != nil || len(id) != common.HashLength {
+		return fmt.Errorf("invalid batch id %q: must be 32 bytes of hex", args[1])
+	}
+
+	bridge, err := contracts.NewBatchBridgeCaller(opts.Bridge, backend)
+	if err != nil {
+		return fmt.Errorf("error binding bridge contract: %v", err)
+	}
+	completed, err := bridge.CompletedBatches(&bind.CallOpts{Context: ctx}, sourceChainID, common.BytesToHash(id))
+	if err != nil {
+		return fmt.Errorf("error reading completedBatches: %v", err)
+	}
+	fmt.Fprintln(out, completed)
+	return nil
+}
+
+func printJSON(out io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "    ")
+	return encoder.Encode(v)
+}
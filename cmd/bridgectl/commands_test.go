@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/contracts"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/signer"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// autoMine mines every transaction as it is sent, so commands waiting for
+// receipts return.
+type autoMine struct {
+	simulated.Client
+	backend *simulated.Backend
+}
+
+func (a *autoMine) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if err := a.Client.SendTransaction(ctx, tx); err != nil {
+		return err
+	}
+	a.backend.Commit()
+	return nil
+}
+
+func runCommand(t *testing.T, backend Backend, opts *Options, name string, args ...string) string {
+	var out bytes.Buffer
+	require.NoError(t, Run(context.Background(), backend, opts, name, args, &out))
+	return out.String()
+}
+
+func TestCommands(t *testing.T) {
+	ctx := context.Background()
+	chain := testutil.NewChain(t)
+	backend := &autoMine{Client: chain.Client, backend: chain.Backend}
+	owner := &Options{Signer: signer.NewLocal(chain.Owner)}
+	admin := &Options{Signer: signer.NewLocal(chain.Admin)}
+
+	out := runCommand(t, backend, owner, "deploy", chain.AdminAddr.Hex())
+	require.Contains(t, out, "deployed BatchBridge at ")
+	address := common.HexToAddress(strings.Fields(out[strings.Index(out, "deployed"):])[3])
+	bridge, err := contracts.NewBatchBridge(address, chain.Client)
+	require.NoError(t, err)
+	owner.Bridge, admin.Bridge = address, address
+	adminAddr, err := bridge.Admin(&bind.CallOpts{})
+	require.NoError(t, err)
+	require.Equal(t, chain.AdminAddr, adminAddr)
+
+	runCommand(t, backend, owner, "addSupportedToken", chain.TokenAddr.Hex())
+	supported, err := bridge.SupportedTokens(&bind.CallOpts{}, chain.TokenAddr)
+	require.NoError(t, err)
+	require.True(t, supported)
+
+	operator := common.HexToAddress("0x0b")
+	var discard bytes.Buffer
+	require.ErrorContains(t, Run(ctx, backend, owner, "addOperator", []string{operator.Hex()}, &discard), "reverted")
+	runCommand(t, backend, admin, "addOperator", operator.Hex())
+	isOperator, err := bridge.Operators(&bind.CallOpts{}, operator)
+	require.NoError(t, err)
+	require.True(t, isOperator)
+
+	attester := common.HexToAddress("0x0c")
+	runCommand(t, backend, admin, "addAttester", attester.Hex())
+	runCommand(t, backend, admin, "setAttesterThreshold", "1")
+	isAttester, err := bridge.Attesters(&bind.CallOpts{}, attester)
+	require.NoError(t, err)
+	require.True(t, isAttester)
+	threshold, err := bridge.AttesterThreshold(&bind.CallOpts{})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), threshold.Int64())
+	// The last attester cannot be removed while the threshold needs it
+	require.ErrorContains(t, Run(ctx, backend, admin, "removeAttester", []string{attester.Hex()}, &discard), "removeAttester")
+
+	runCommand(t, backend, owner, "pause")
+	paused, err := bridge.Paused(&bind.CallOpts{})
+	require.NoError(t, err)
+	require.True(t, paused)
+
+	require.Equal(t, "false\n", runCommand(t, backend, owner, "completedBatches", "56", common.HexToHash("0xb1").Hex()))
+
+	// A dry run only encodes the call
+	bridgeABI, err := contracts.BatchBridgeMetaData.GetAbi()
+	require.NoError(t, err)
+	recipient := common.HexToAddress("0xbeef")
+	data, err := bridgeABI.Pack("emergencyWithdraw", chain.TokenAddr, recipient, big.NewInt(5))
+	require.NoError(t, err)
+	var dryRun Call
+	out = runCommand(t, nil, &Options{Bridge: address, DryRun: true}, "emergencyWithdraw", chain.TokenAddr.Hex(), recipient.Hex(), "5")
+	require.NoError(t, json.Unmarshal([]byte(out), &dryRun))
+	require.Equal(t, &address, dryRun.To)
+	require.Equal(t, data, []byte(dryRun.Data))
+	data, err = bridgeABI.Pack("setAttesterThreshold", big.NewInt(2))
+	require.NoError(t, err)
+	out = runCommand(t, nil, &Options{Bridge: address, DryRun: true}, "setAttesterThreshold", "2")
+	require.NoError(t, json.Unmarshal([]byte(out), &dryRun))
+	require.Equal(t, data, []byte(dryRun.Data))
+	data, err = bridgeABI.Pack("emergencyWithdraw", chain.TokenAddr, recipient, big.NewInt(5))
+	require.NoError(t, err)
+
+	// An unsigned transaction is filled in but not sent
+	tx, err := chain.Token.Transfer(chain.Transactor(t, chain.Owner), address, big.NewInt(5))
+	require.NoError(t, err)
+	chain.Mine(t, tx)
+	nonce, err := chain.Client.PendingNonceAt(ctx, chain.AdminAddr)
+	require.NoError(t, err)
+	var args signer.TransactionArgs
+	out = runCommand(t, backend, &Options{Bridge: address, Unsigned: true, From: chain.AdminAddr}, "emergencyWithdraw", chain.TokenAddr.Hex(), recipient.Hex(), "5")
+	require.NoError(t, json.Unmarshal([]byte(out), &args))
+	require.Equal(t, chain.AdminAddr, args.From)
+	require.Equal(t, &address, args.To)
+	require.Equal(t, nonce, uint64(args.Nonce))
+	require.NotZero(t, args.Gas)
+	require.Equal(t, data, []byte(args.Data))
+	require.Equal(t, chain.ID, args.ChainID.ToInt().Int64())
+	after, err := chain.Client.PendingNonceAt(ctx, chain.AdminAddr)
+	require.NoError(t, err)
+	require.Equal(t, nonce, after)
+
+	require.ErrorContains(t, Run(ctx, backend, owner, "transferOwnership", nil, &discard), "unknown command")
+	require.ErrorContains(t, Run(ctx, backend, owner, "emergencyWithdraw", []string{"native", recipient.Hex(), "-1"}, &discard), "invalid amount")
+	require.ErrorContains(t, Run(ctx, backend, admin, "setAttesterThreshold", []string{"0"}, &discard), "invalid threshold")
+	require.ErrorContains(t, Run(ctx, backend, owner, "completedBatches", []string{"56", "0xb1"}, &discard), "invalid batch id")
+}
@@ -0,0 +1,127 @@
+// Command bridgectl manages BatchBridge deployments with the same contract
+// bindings as the bridge service.
+//
+// Usage:
+//
+//	bridgectl [flags] deploy <admin>
+//	bridgectl [flags] transferAdmin <admin>
+//	bridgectl [flags] addSupportedToken <token>
+//	bridgectl [flags] removeSupportedToken <token>
+//	bridgectl [flags] addOperator <wallet>
+//	bridgectl [flags] removeOperator <wallet>
+//	bridgectl [flags] addAttester <attester>
+//	bridgectl [flags] removeAttester <attester>
+//	bridgectl [flags] setAttesterThreshold <threshold>
+//	bridgectl [flags] pause
+//	bridgectl [flags] unpause
+//	bridgectl [flags] emergencyWithdraw <token> <recipient> <amount>
+//	bridgectl [flags] completedBatches <sourceChainId> <batchId>
+//
+// A token is an address, or "native" for the chain's native coin; amounts
+// are in the token's base units. The admin manages operators and attesters
+// and makes emergency withdrawals; it must not be a hot wallet. The
+// attester threshold is the number of attester signatures a batch needs
+// and cannot exceed the number of attesters. Transactions are signed with
+// the key in the -keystore file and waited for. With -dry-run the calldata
+// is printed instead, without connecting to a node. With -unsigned the
+// transaction -from would send is printed as eth_signTransaction
+// arguments, with its nonce, gas and fees filled in, for signing offline.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/signer"
+)
+
+func main() {
+	err := run(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "bridgectl: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	flags := flag.NewFlagSet("bridgectl", flag.ContinueOnError)
+	rpcURL := flags.String("rpc", os.Getenv("BRIDGE_RPC_URL"), "RPC endpoint of the chain")
+	bridge := flags.String("bridge", os.Getenv("BRIDGE_ADDRESS"), "BatchBridge address")
+	keystoreFile := flags.String("keystore", os.Getenv("BRIDGECTL_KEYSTORE"), "V3 keystore file of the key that signs")
+	passwordFile := flags.String("password-file", os.Getenv("KEYSTORE_PASSWORD_FILE"), "file holding the keystore passphrase; prompted for when unset")
+	dryRun := flags.Bool("dry-run", false, "print the calldata instead of sending the transaction")
+	unsigned := flags.Bool("unsigned", false, "print the unsigned transaction for offline signing")
+	from := flags.String("from", "", "sender of the unsigned transaction")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: bridgectl [flags] deploy <admin> | transferAdmin <admin> | addSupportedToken <token> | removeSupportedToken <token> | addOperator <wallet> | removeOperator <wallet> | addAttester <attester> | removeAttester <attester> | setAttesterThreshold <threshold> | pause | unpause | emergencyWithdraw <token> <recipient> <amount> | completedBatches <sourceChainId> <batchId>\n\nFlags:\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return flag.ErrHelp
+	}
+	name, cmdArgs := flags.Arg(0), flags.Args()[1:]
+
+	opts := &Options{DryRun: *dryRun, Unsigned: *unsigned}
+	if name != "deploy" {
+		address, err := parseAddress(*bridge)
+		if err != nil {
+			return fmt.Errorf("-bridge: %v", err)
+		}
+		opts.Bridge = address
+	}
+	if opts.DryRun {
+		return Run(context.Background(), nil, opts, name, cmdArgs, os.Stdout)
+	}
+	// Check the command before asking for a passphrase
+	if name != "completedBatches" {
+		if _, err := parseCall(name, cmdArgs); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case name == "completedBatches":
+	case opts.Unsigned:
+		address, err := parseAddress(*from)
+		if err != nil {
+			return fmt.Errorf("-from: %v", err)
+		}
+		opts.From = address
+	default:
+		if *keystoreFile == "" {
+			return fmt.Errorf("-keystore is required to send transactions; use -dry-run or -unsigned without a key")
+		}
+		passphrase, err := signer.Passphrase(*passwordFile)
+		if err != nil {
+			return err
+		}
+		local, err := signer.LoadKeyFile(*keystoreFile, passphrase)
+		if err != nil {
+			return err
+		}
+		defer local.Zero()
+		opts.Signer = local
+	}
+
+	if *rpcURL == "" {
+		return fmt.Errorf("-rpc is required")
+	}
+	client, err := ethclient.Dial(*rpcURL)
+	if err != nil {
+		return fmt.Errorf("error connecting to %s: %v", *rpcURL, err)
+	}
+	defer client.Close()
+
+	return Run(context.Background(), client, opts, name, cmdArgs, os.Stdout)
+}
@@ -1,13 +1,22 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"strconv"
 	"strings"
+	"sync"
+	"syscall"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/namdq2/go-cross-chain-bridge-swap/internal/api"
 	"github.com/namdq2/go-cross-chain-bridge-swap/internal/models"
 	"github.com/namdq2/go-cross-chain-bridge-swap/internal/service"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/signer"
 )
 
 func main() {
@@ -17,21 +26,152 @@ func main() {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
 
+	// Chains, their RPC endpoints and bridges come from chain_configs
+	config := service.Config{
+		RPCQuorum: int(envUint("RPC_QUORUM")),
+	}
+
+	// Every keystore shares one passphrase, read once when first needed
+	passphrase := sync.OnceValues(func() (string, error) {
+		return signer.Passphrase(os.Getenv("KEYSTORE_PASSWORD_FILE"))
+	})
+	if err := loadAttester(&config, passphrase); err != nil {
+		log.Fatalf("Failed to load attester key: %v", err)
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Validator mode only attests confirmed batches
+	if os.Getenv("MODE") == "validator" {
+		validator, err := service.NewValidator(config, db)
+		if err != nil {
+			log.Fatalf("Failed to initialize validator: %v", err)
+		}
+		log.Printf("Running validator %s", validator.Address().Hex())
+		validator.Start(ctx)
+		<-ctx.Done()
+		log.Printf("Shutting down")
+		validator.Close()
+		return
+	}
+
 	// Initialize bridge service
-	privateKeys := strings.Split(os.Getenv("HOT_WALLET_PRIVATE_KEYS"), ",")
-	bridgeService, err := service.NewBridgeService(service.Config{
-		Chain1RPC:   os.Getenv("CHAIN1_RPC"),
-		Chain2RPC:   os.Getenv("CHAIN2_RPC"),
-		Chain1ID:    1,  // Ethereum Mainnet
-		Chain2ID:    56, // BSC Mainnet
-		BridgeAddr1: os.Getenv("BRIDGE_ADDR1"),
-		BridgeAddr2: os.Getenv("BRIDGE_ADDR2"),
-	}, privateKeys, db)
+	signers, err := loadSigners(&config, passphrase)
+	if err != nil {
+		log.Fatalf("Failed to load wallets: %v", err)
+	}
+	bridgeService, err := service.NewBridgeService(config, signers, db)
 	if err != nil {
 		log.Fatalf("Failed to initialize bridge service: %v", err)
 	}
+	bridgeService.Start(ctx)
 
 	// Start API server
-	server := api.NewServer(bridgeService)
-	log.Fatal(server.Start(":8080"))
+	server := api.NewServer(bridgeService, os.Getenv("ADMIN_API_TOKEN"))
+	errs := make(chan error, 1)
+	go func() {
+		errs <- server.Start(":8080")
+	}()
+
+	// Stop on a signal or a server failure, zeroing the wallet keys
+	select {
+	case err = <-errs:
+	case <-ctx.Done():
+		log.Printf("Shutting down")
+	}
+	stop()
+	bridgeService.Close()
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// loadAttester sets up config to attest with the key decrypted from the
+// keystore file in ATTESTER_KEYSTORE, when it is set. Attester keys always
+// sign in the process, even with REMOTE_SIGNER_URL set.
+func loadAttester(config *service.Config, passphrase func() (string, error)) error {
+	path := os.Getenv("ATTESTER_KEYSTORE")
+	if path == "" {
+		return nil
+	}
+	pass, err := passphrase()
+	if err != nil {
+		return err
+	}
+	local, err := signer.LoadKeyFile(path, pass)
+	if err != nil {
+		return err
+	}
+	config.Attester = local
+	return nil
+}
+
+// loadSigners returns the signers of the hot wallets and sets up config
+// to sign for the treasury, when one is configured, and for wallets added
+// at runtime. The keys are decrypted from the keystore files in
+// KEYSTORE_DIR and TREASURY_KEYSTORE. With REMOTE_SIGNER_URL set, the keys
+// stay in the remote signer and only their addresses are configured.
+func loadSigners(config *service.Config, passphrase func() (string, error)) ([]signer.Signer, error) {
+	if url := os.Getenv("REMOTE_SIGNER_URL"); url != "" {
+		client, err := rpc.Dial(url)
+		if err != nil {
+			return nil, fmt.Errorf("error connecting to remote signer: %v", err)
+		}
+		var signers []signer.Signer
+		for _, address := range strings.Split(os.Getenv("HOT_WALLET_ADDRESSES"), ",") {
+			if !common.IsHexAddress(address) {
+				return nil, fmt.Errorf("invalid hot wallet address %q", address)
+			}
+			signers = append(signers, signer.NewRemote(client, common.HexToAddress(address)))
+		}
+		if address := os.Getenv("TREASURY_ADDRESS"); address != "" {
+			if !common.IsHexAddress(address) {
+				return nil, fmt.Errorf("invalid treasury address %q", address)
+			}
+			config.Treasury = signer.NewRemote(client, common.HexToAddress(address))
+		}
+		config.LoadSigner = func(address common.Address) (signer.Signer, error) {
+			return signer.NewRemote(client, address), nil
+		}
+		return signers, nil
+	}
+
+	pass, err := passphrase()
+	if err != nil {
+		return nil, err
+	}
+	dir := os.Getenv("KEYSTORE_DIR")
+	locals, err := signer.LoadKeystore(dir, pass)
+	if err != nil {
+		return nil, fmt.Errorf("error loading hot wallet keys: %v", err)
+	}
+	signers := make([]signer.Signer, len(locals))
+	for i, local := range locals {
+		signers[i] = local
+	}
+	if path := os.Getenv("TREASURY_KEYSTORE"); path != "" {
+		local, err := signer.LoadKeyFile(path, pass)
+		if err != nil {
+			return nil, fmt.Errorf("error loading treasury key: %v", err)
+		}
+		config.Treasury = local
+	}
+	// Wallets added at runtime are read from the same keystore, so the
+	// passphrase is kept for them
+	config.LoadSigner = func(address common.Address) (signer.Signer, error) {
+		return signer.LoadKeystoreAccount(dir, address, pass)
+	}
+	return signers, nil
+}
+
+func envUint(key string) uint64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return 0
+	}
+	n, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		log.Fatalf("Invalid %s: %v", key, err)
+	}
+	return n
 }
@@ -2,9 +2,12 @@ pragma solidity ^0.8.0;
 
 import "@openzeppelin/contracts/token/ERC20/IERC20.sol";
 import "@openzeppelin/contracts/access/Ownable.sol";
+import "@openzeppelin/contracts/security/Pausable.sol";
 import "@openzeppelin/contracts/security/ReentrancyGuard.sol";
+import "@openzeppelin/contracts/utils/cryptography/ECDSA.sol";
+import "@openzeppelin/contracts/utils/cryptography/EIP712.sol";
 
-contract BatchBridge is Ownable, ReentrancyGuard {
+contract BatchBridge is Ownable, Pausable, ReentrancyGuard, EIP712 {
     struct SwapRequest {
         address token;
         uint256 amount;
@@ -12,8 +15,36 @@ contract BatchBridge is Ownable, ReentrancyGuard {
         uint256 targetChainId;
     }
 
-    mapping(bytes32 => bool) public processedBatches;
+    bytes32 public constant SWAP_REQUEST_TYPEHASH = keccak256(
+        "SwapRequest(address token,uint256 amount,address recipient,uint256 targetChainId)"
+    );
+    bytes32 public constant BATCH_ATTESTATION_TYPEHASH = keccak256(
+        "BatchAttestation(bytes32 batchId,uint256 sourceChainId,uint256 targetChainId,SwapRequest[] requests)"
+        "SwapRequest(address token,uint256 amount,address recipient,uint256 targetChainId)"
+    );
+
+    // Stands in for the chain's native coin (ETH, BNB) as a request token.
+    address public constant NATIVE_TOKEN = 0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE;
+
+    // Manages the attesters and operators and makes emergency withdrawals.
+    // Kept apart from the owner, and never an operator, so that a hot
+    // wallet key cannot change who signs off on payouts or drain the bridge.
+    address public admin;
+
+    // Number of batches initiated here, which makes every batch id unique.
+    uint256 public batchCount;
+    // Batches completed here, by source chain and batch id.
+    mapping(uint256 => mapping(bytes32 => bool)) public completedBatches;
     mapping(address => bool) public supportedTokens;
+    // ERC20 paid out here for the native coin of a source chain that is not
+    // this chain's native coin, e.g. WBNB on Ethereum for BNB from BSC.
+    // Unset means the native coin is paid out as is.
+    mapping(uint256 => address) public wrappedNativeTokens;
+    mapping(address => bool) public attesters;
+    // Hot wallets allowed to initiate and complete batches.
+    mapping(address => bool) public operators;
+    uint256 public attesterCount;
+    uint256 public attesterThreshold = 1;
     
     event BatchSwapInitiated(
         bytes32 indexed batchId,
@@ -23,12 +54,85 @@ contract BatchBridge is Ownable, ReentrancyGuard {
 
     event BatchSwapCompleted(
         bytes32 indexed batchId,
+        uint256 indexed sourceChainId,
         uint256 targetChainId,
         uint256 timestamp
     );
 
-    constructor() {
-        // Initialize contract
+    event AttesterAdded(address indexed attester);
+    event AttesterRemoved(address indexed attester);
+    event AttesterThresholdChanged(uint256 threshold);
+    event WrappedNativeTokenSet(uint256 indexed sourceChainId, address token);
+    event AdminTransferred(address indexed previousAdmin, address indexed newAdmin);
+    event OperatorAdded(address indexed operator);
+    event OperatorRemoved(address indexed operator);
+
+    modifier onlyAdmin() {
+        require(msg.sender == admin, "Caller is not the admin");
+        _;
+    }
+
+    modifier onlyOperator() {
+        require(operators[msg.sender], "Caller is not an operator");
+        _;
+    }
+
+    constructor(address admin_) EIP712("BatchBridge", "1") {
+        require(admin_ != address(0), "Invalid admin");
+        admin = admin_;
+        emit AdminTransferred(address(0), admin_);
+    }
+
+    function transferAdmin(address newAdmin) external onlyAdmin {
+        require(newAdmin != address(0), "Invalid admin");
+        require(!operators[newAdmin], "Admin cannot be an operator");
+        emit AdminTransferred(admin, newAdmin);
+        admin = newAdmin;
+    }
+
+    function addOperator(address operator) external onlyAdmin {
+        require(operator != address(0), "Invalid operator");
+        require(operator != admin, "Admin cannot be an operator");
+        require(!operators[operator], "Already an operator");
+        operators[operator] = true;
+        emit OperatorAdded(operator);
+    }
+
+    function removeOperator(address operator) external onlyAdmin {
+        require(operators[operator], "Not an operator");
+        operators[operator] = false;
+        emit OperatorRemoved(operator);
+    }
+
+    function addAttester(address attester) external onlyAdmin {
+        require(attester != address(0), "Invalid attester");
+        require(!attesters[attester], "Already an attester");
+        attesters[attester] = true;
+        attesterCount++;
+        emit AttesterAdded(attester);
+    }
+
+    function removeAttester(address attester) external onlyAdmin {
+        require(attesters[attester], "Not an attester");
+        require(attesterCount > attesterThreshold, "Threshold exceeds attesters");
+        attesters[attester] = false;
+        attesterCount--;
+        emit AttesterRemoved(attester);
+    }
+
+    function setAttesterThreshold(uint256 threshold) external onlyAdmin {
+        require(threshold > 0 && threshold <= attesterCount, "Invalid threshold");
+        attesterThreshold = threshold;
+        emit AttesterThresholdChanged(threshold);
+    }
+
+    // Funds native payouts.
+    receive() external payable {}
+
+    function setWrappedNativeToken(uint256 sourceChainId, address token) external onlyOwner {
+        require(token != NATIVE_TOKEN, "Invalid token");
+        wrappedNativeTokens[sourceChainId] = token;
+        emit WrappedNativeTokenSet(sourceChainId, token);
     }
 
     function addSupportedToken(address token) external onlyOwner {
@@ -41,65 +145,141 @@ contract BatchBridge is Ownable, ReentrancyGuard {
 
     function batchInitiateSwap(
         SwapRequest[] calldata requests
-    ) external nonReentrant onlyOwner {
+    ) external payable nonReentrant whenNotPaused onlyOperator {
         require(requests.length > 0, "Empty batch");
         
         bytes32 batchId = keccak256(
-            abi.encodePacked(
-                block.timestamp,
-                msg.sender,
-                requests.length
+            abi.encode(
+                block.chainid,
+                address(this),
+                batchCount
             )
         );
-        
-        require(!processedBatches[batchId], "Batch already processed");
+        batchCount++;
         
         // Process each swap request
+        uint256 nativeAmount = 0;
         for(uint i = 0; i < requests.length; i++) {
             SwapRequest memory req = requests[i];
             require(supportedTokens[req.token], "Unsupported token");
             require(req.amount > 0, "Invalid amount");
             require(req.recipient != address(0), "Invalid recipient");
             
-            IERC20(req.token).transferFrom(msg.sender, address(this), req.amount);
+            if (req.token == NATIVE_TOKEN) {
+                nativeAmount += req.amount;
+            } else {
+                IERC20(req.token).transferFrom(msg.sender, address(this), req.amount);
+            }
         }
+        require(msg.value == nativeAmount, "Incorrect native amount");
         
-        processedBatches[batchId] = true;
         emit BatchSwapInitiated(batchId, requests, block.timestamp);
     }
 
     function batchCompleteSwap(
         bytes32 batchId,
+        uint256 sourceChainId,
         SwapRequest[] calldata requests,
         bytes memory signature
-    ) external nonReentrant onlyOwner {
-        require(!processedBatches[batchId], "Batch already processed");
+    ) external nonReentrant whenNotPaused onlyOperator {
+        require(!completedBatches[sourceChainId][batchId], "Batch already completed");
         require(requests.length > 0, "Empty batch");
         
         // Verify signature
-        require(verifyBatchSignature(batchId, requests, signature), "Invalid signature");
+        require(verifyBatchSignature(batchId, sourceChainId, requests, signature), "Invalid signature");
         
         // Process completions
         for(uint i = 0; i < requests.length; i++) {
             SwapRequest memory req = requests[i];
+            require(req.targetChainId == block.chainid, "Wrong target chain");
             require(supportedTokens[req.token], "Unsupported token");
             require(req.amount > 0, "Invalid amount");
             require(req.recipient != address(0), "Invalid recipient");
             
-            IERC20(req.token).transfer(req.recipient, req.amount);
+            if (req.token != NATIVE_TOKEN) {
+                IERC20(req.token).transfer(req.recipient, req.amount);
+            } else if (wrappedNativeTokens[sourceChainId] != address(0)) {
+                IERC20(wrappedNativeTokens[sourceChainId]).transfer(req.recipient, req.amount);
+            } else {
+                (bool sent, ) = req.recipient.call{value: req.amount}("");
+                require(sent, "Native transfer failed");
+            }
         }
         
-        processedBatches[batchId] = true;
-        emit BatchSwapCompleted(batchId, requests[0].targetChainId, block.timestamp);
+        completedBatches[sourceChainId][batchId] = true;
+        emit BatchSwapCompleted(batchId, sourceChainId, requests[0].targetChainId, block.timestamp);
     }
 
+    // Checks that at least attesterThreshold distinct attesters signed the
+    // EIP-712 BatchAttestation for this chain and bridge. signatures holds
+    // 65-byte r||s||v signatures concatenated in ascending signer order.
     function verifyBatchSignature(
         bytes32 batchId,
+        uint256 sourceChainId,
         SwapRequest[] calldata requests,
-        bytes memory signature
-    ) internal pure returns (bool) {
-        // Implement signature verification
-        return true; // Placeholder
+        bytes memory signatures
+    ) internal view returns (bool) {
+        if (signatures.length == 0 || signatures.length % 65 != 0) {
+            return false;
+        }
+        uint256 count = signatures.length / 65;
+        if (count < attesterThreshold) {
+            return false;
+        }
+
+        bytes32 digest = hashBatchAttestation(batchId, sourceChainId, requests);
+        address last = address(0);
+        for(uint i = 0; i < count; i++) {
+            bytes32 r;
+            bytes32 s;
+            uint8 v;
+            assembly {
+                let offset := add(add(signatures, 0x20), mul(i, 65))
+                r := mload(offset)
+                s := mload(add(offset, 0x20))
+                v := byte(0, mload(add(offset, 0x40)))
+            }
+
+            (address signer, ECDSA.RecoverError error) = ECDSA.tryRecover(digest, v, r, s);
+            if (error != ECDSA.RecoverError.NoError || !attesters[signer] || signer <= last) {
+                return false;
+            }
+            last = signer;
+        }
+        return true;
+    }
+
+    // Returns the EIP-712 digest attesters sign for a batch completed on
+    // this chain.
+    function hashBatchAttestation(
+        bytes32 batchId,
+        uint256 sourceChainId,
+        SwapRequest[] calldata requests
+    ) public view returns (bytes32) {
+        bytes32[] memory requestHashes = new bytes32[](requests.length);
+        for(uint i = 0; i < requests.length; i++) {
+            requestHashes[i] = keccak256(
+                abi.encode(
+                    SWAP_REQUEST_TYPEHASH,
+                    requests[i].token,
+                    requests[i].amount,
+                    requests[i].recipient,
+                    requests[i].targetChainId
+                )
+            );
+        }
+
+        return _hashTypedDataV4(
+            keccak256(
+                abi.encode(
+                    BATCH_ATTESTATION_TYPEHASH,
+                    batchId,
+                    sourceChainId,
+                    block.chainid,
+                    keccak256(abi.encodePacked(requestHashes))
+                )
+            )
+        );
     }
 
     // Emergency functions
@@ -107,8 +287,13 @@ contract BatchBridge is Ownable, ReentrancyGuard {
         address token,
         address recipient,
         uint256 amount
-    ) external onlyOwner {
-        IERC20(token).transfer(recipient, amount);
+    ) external onlyAdmin {
+        if (token == NATIVE_TOKEN) {
+            (bool sent, ) = recipient.call{value: amount}("");
+            require(sent, "Native transfer failed");
+        } else {
+            IERC20(token).transfer(recipient, amount);
+        }
     }
 
     function pause() external onlyOwner {
@@ -0,0 +1,22 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+/// @notice OP-stack GasPriceOracle predeploy at
+/// 0x420000000000000000000000000000000000000F.
+interface IGasPriceOracle {
+    /// @notice L1 data fee, in wei, of a transaction with the given
+    /// RLP-encoded bytes.
+    function getL1Fee(bytes memory data) external view returns (uint256);
+}
+
+/// @notice Arbitrum NodeInterface, a virtual contract at
+/// 0x00000000000000000000000000000000000000C8 that is only reachable through
+/// eth_call and eth_estimateGas.
+interface INodeInterface {
+    /// @notice L2 gas a transaction spends to post its calldata to L1, and
+    /// the L2 base fee that gas is priced at.
+    function gasEstimateL1Component(address to, bool contractCreation, bytes calldata data)
+        external
+        payable
+        returns (uint64 gasEstimateForL1, uint256 baseFee, uint256 l1BaseFeeEstimate);
+}
@@ -1,5 +1,6 @@
 const BatchBridge = artifacts.require("BatchBridge");
 
+// BRIDGE_ADMIN manages attesters and operators; it must not be a hot wallet.
 module.exports = function(deployer) {
-    deployer.deploy(BatchBridge);
+    deployer.deploy(BatchBridge, process.env.BRIDGE_ADMIN);
 };
\ No newline at end of file
@@ -0,0 +1,28 @@
+ALTER TABLE batches ADD COLUMN onchain_batch_id VARCHAR(66);
+ALTER TABLE batch_swaps ADD COLUMN request_index INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE bridge_events (
+    id SERIAL PRIMARY KEY,
+    chain_id BIGINT NOT NULL,
+    event_name VARCHAR(50) NOT NULL,
+    onchain_batch_id VARCHAR(66) NOT NULL,
+    block_number BIGINT NOT NULL,
+    block_hash VARCHAR(66) NOT NULL,
+    tx_hash VARCHAR(66) NOT NULL,
+    log_index INTEGER NOT NULL,
+    payload JSONB,
+    batch_id INTEGER REFERENCES batches(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE(chain_id, tx_hash, log_index)
+);
+
+CREATE TABLE chain_checkpoints (
+    chain_id BIGINT PRIMARY KEY,
+    last_block BIGINT NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_batches_source_tx_hash ON batches(source_tx_hash);
+CREATE INDEX idx_batches_onchain_batch_id ON batches(onchain_batch_id);
+CREATE INDEX idx_bridge_events_batch ON bridge_events(onchain_batch_id);
+CREATE INDEX idx_bridge_events_block ON bridge_events(chain_id, block_number);
@@ -0,0 +1,5 @@
+ALTER TYPE swap_status ADD VALUE IF NOT EXISTS 'confirmed' AFTER 'processing';
+
+ALTER TABLE chain_configs
+    ADD COLUMN finality_mode VARCHAR(16) NOT NULL DEFAULT 'confirmations'
+        CHECK (finality_mode IN ('confirmations', 'safe', 'finalized'));
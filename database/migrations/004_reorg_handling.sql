@@ -0,0 +1,3 @@
+ALTER TYPE swap_status ADD VALUE IF NOT EXISTS 'reorged';
+
+ALTER TABLE batches ADD COLUMN block_hash VARCHAR(66);
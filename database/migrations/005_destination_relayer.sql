@@ -0,0 +1,10 @@
+-- Every batch now carries swaps for a single destination chain.
+ALTER TABLE batches ADD COLUMN target_chain_id BIGINT NOT NULL DEFAULT 0;
+
+UPDATE batches b
+SET target_chain_id = s.to_chain_id
+FROM batch_swaps bs
+JOIN swaps s ON s.id = bs.swap_id
+WHERE bs.batch_id = b.id AND bs.request_index = 0;
+
+ALTER TABLE batches ALTER COLUMN target_chain_id DROP DEFAULT;
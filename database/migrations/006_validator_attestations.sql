@@ -0,0 +1,11 @@
+CREATE TABLE batch_attestations (
+    id SERIAL PRIMARY KEY,
+    batch_id INTEGER NOT NULL REFERENCES batches(id) ON DELETE CASCADE,
+    digest VARCHAR(66) NOT NULL,
+    attester VARCHAR(42) NOT NULL,
+    signature BYTEA NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE(digest, attester)
+);
+
+CREATE INDEX idx_batch_attestations_batch ON batch_attestations(batch_id);
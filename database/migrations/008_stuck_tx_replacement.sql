@@ -0,0 +1,15 @@
+ALTER TABLE chain_configs ADD COLUMN stuck_tx_timeout_seconds INTEGER NOT NULL DEFAULT 300;
+
+CREATE TABLE batch_transactions (
+    id SERIAL PRIMARY KEY,
+    batch_id INTEGER NOT NULL REFERENCES batches(id) ON DELETE CASCADE,
+    wallet_address VARCHAR(42) NOT NULL,
+    tx_hash VARCHAR(66) UNIQUE NOT NULL,
+    nonce BIGINT NOT NULL,
+    gas_price NUMERIC(78) NOT NULL,
+    gas_tip_cap NUMERIC(78),
+    gas_fee_cap NUMERIC(78),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_batch_transactions_batch ON batch_transactions(batch_id);
@@ -0,0 +1,3 @@
+-- Nonces are tracked per wallet and chain, so a wallet has one row per chain.
+ALTER TABLE hot_wallets DROP CONSTRAINT hot_wallets_address_key;
+ALTER TABLE hot_wallets ADD CONSTRAINT hot_wallets_address_chain_id_key UNIQUE (address, chain_id);
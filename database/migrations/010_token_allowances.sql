@@ -0,0 +1 @@
+ALTER TABLE chain_configs ADD COLUMN auto_approve BOOLEAN NOT NULL DEFAULT true;
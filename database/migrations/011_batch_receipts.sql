@@ -0,0 +1,18 @@
+-- Reverted batches count as failed, batches only count for the wallet on
+-- the chain they were sent on, and gas is no longer counted once per swap.
+CREATE OR REPLACE VIEW wallet_performance AS
+SELECT
+    w.address,
+    w.chain_id,
+    count(b.id) as total_batches,
+    coalesce(sum(bs.swaps), 0) as total_swaps,
+    avg(b.gas_price) as avg_gas_price,
+    sum(b.gas_used) as total_gas_used,
+    count(CASE WHEN b.status = 'completed' THEN 1 END) as successful_batches,
+    count(CASE WHEN b.status IN ('failed', 'reverted') THEN 1 END) as failed_batches
+FROM hot_wallets w
+LEFT JOIN batches b ON w.address = b.wallet_address AND w.chain_id = b.chain_id
+LEFT JOIN (
+    SELECT batch_id, count(*) as swaps FROM batch_swaps GROUP BY batch_id
+) bs ON b.id = bs.batch_id
+GROUP BY w.address, w.chain_id;
@@ -0,0 +1 @@
+ALTER TABLE chain_configs ADD COLUMN start_block BIGINT;
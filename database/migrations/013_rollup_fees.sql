@@ -0,0 +1,5 @@
+ALTER TYPE chain_type ADD VALUE 'optimism';
+ALTER TYPE chain_type ADD VALUE 'arbitrum';
+
+ALTER TABLE batches ADD COLUMN execution_fee NUMERIC(78);
+ALTER TABLE batches ADD COLUMN l1_data_fee NUMERIC(78);
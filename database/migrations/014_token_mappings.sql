@@ -0,0 +1,34 @@
+CREATE TABLE token_mappings (
+    id SERIAL PRIMARY KEY,
+    source_chain_id BIGINT NOT NULL,
+    source_token VARCHAR(42) NOT NULL,
+    target_chain_id BIGINT NOT NULL,
+    target_token VARCHAR(42) NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE(source_chain_id, source_token, target_chain_id)
+);
+
+CREATE TRIGGER update_token_mappings_updated_at
+    BEFORE UPDATE ON token_mappings
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Existing swaps paid out the token and amount they carried.
+ALTER TABLE swaps ADD COLUMN target_token_address VARCHAR(42);
+ALTER TABLE swaps ADD COLUMN target_amount NUMERIC(78);
+ALTER TABLE swaps ADD COLUMN dust NUMERIC(78) NOT NULL DEFAULT 0;
+UPDATE swaps SET target_token_address = token_address, target_amount = amount;
+ALTER TABLE swaps ALTER COLUMN target_token_address SET NOT NULL;
+ALTER TABLE swaps ALTER COLUMN target_amount SET NOT NULL;
+
+CREATE OR REPLACE VIEW token_dust AS
+SELECT
+    from_chain_id as chain_id,
+    token_address,
+    sum(dust) as total_dust,
+    count(*) as swaps
+FROM swaps
+WHERE dust > 0 AND status NOT IN ('failed', 'reverted')
+GROUP BY from_chain_id, token_address;
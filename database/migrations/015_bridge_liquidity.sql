@@ -0,0 +1,11 @@
+CREATE TABLE bridge_liquidity (
+    chain_id BIGINT NOT NULL,
+    token_address VARCHAR(42) NOT NULL,
+    balance NUMERIC(78) NOT NULL,
+    obligations NUMERIC(78) NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (chain_id, token_address)
+);
+
+CREATE INDEX idx_swaps_obligations ON swaps(to_chain_id, target_token_address)
+    WHERE status NOT IN ('completed', 'failed', 'reverted');
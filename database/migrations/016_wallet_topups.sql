@@ -0,0 +1,26 @@
+ALTER TABLE hot_wallets ADD COLUMN native_balance NUMERIC(78);
+ALTER TABLE hot_wallets ADD COLUMN balance_updated_at TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE chain_configs ADD COLUMN min_wallet_balance NUMERIC(78);
+ALTER TABLE chain_configs ADD COLUMN target_wallet_balance NUMERIC(78);
+
+CREATE TABLE wallet_topups (
+    id SERIAL PRIMARY KEY,
+    chain_id BIGINT NOT NULL,
+    wallet_address VARCHAR(42) NOT NULL,
+    treasury_address VARCHAR(42) NOT NULL,
+    amount NUMERIC(78) NOT NULL,
+    tx_hash VARCHAR(66) UNIQUE NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'confirmed', 'failed')),
+    error_message TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_wallet_topups_pending ON wallet_topups(chain_id) WHERE status = 'pending';
+
+CREATE TRIGGER update_wallet_topups_updated_at
+    BEFORE UPDATE ON wallet_topups
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
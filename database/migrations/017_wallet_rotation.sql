@@ -0,0 +1,2 @@
+ALTER TABLE hot_wallets ADD COLUMN drain_to VARCHAR(42);
+ALTER TABLE hot_wallets ADD COLUMN drained_at TIMESTAMP WITH TIME ZONE;
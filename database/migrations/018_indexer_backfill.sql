@@ -0,0 +1,3 @@
+-- first_block is where the indexed range of a chain begins, so start_block
+-- is only backfilled once. Existing checkpoints are backfilled one more time.
+ALTER TABLE chain_checkpoints ADD COLUMN first_block BIGINT;
@@ -0,0 +1,3 @@
+-- target_sent_at is when target_tx_hash was sent, so the relayer can
+-- replace a destination transaction that stays unmined.
+ALTER TABLE batches ADD COLUMN target_sent_at TIMESTAMP WITH TIME ZONE;
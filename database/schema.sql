@@ -8,14 +8,19 @@ CREATE TYPE swap_status AS ENUM (
     'pending',
     'queued',
     'processing',
+    'confirmed',
     'completed',
     'failed',
-    'reverted'
+    'reverted',
+    'reorged',
+    'held'
 );
 
 CREATE TYPE chain_type AS ENUM (
     'ethereum',
-    'bsc'
+    'bsc',
+    'optimism',
+    'arbitrum'
 );
 
 -- Create swaps table
@@ -26,6 +31,9 @@ CREATE TABLE swaps (
     to_chain_id BIGINT NOT NULL,
     token_address VARCHAR(42) NOT NULL,
     amount NUMERIC(78) NOT NULL, -- To handle large token amounts
+    target_token_address VARCHAR(42) NOT NULL,
+    target_amount NUMERIC(78) NOT NULL,
+    dust NUMERIC(78) NOT NULL DEFAULT 0, -- Source units the target token cannot represent
     recipient VARCHAR(42) NOT NULL,
     status swap_status NOT NULL DEFAULT 'pending',
     error_message TEXT,
@@ -39,12 +47,18 @@ CREATE TABLE batches (
     batch_id UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
     wallet_address VARCHAR(42) NOT NULL,
     chain_id BIGINT NOT NULL,
+    target_chain_id BIGINT NOT NULL,
+    onchain_batch_id VARCHAR(66),
     source_tx_hash VARCHAR(66),
     target_tx_hash VARCHAR(66),
+    target_sent_at TIMESTAMP WITH TIME ZONE,
     status swap_status NOT NULL DEFAULT 'pending',
     gas_price NUMERIC(78),
     gas_used BIGINT,
+    execution_fee NUMERIC(78),
+    l1_data_fee NUMERIC(78),
     block_number BIGINT,
+    block_hash VARCHAR(66),
     error_message TEXT,
     created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
     updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
@@ -54,21 +68,27 @@ CREATE TABLE batches (
 CREATE TABLE batch_swaps (
     batch_id INTEGER REFERENCES batches(id) ON DELETE CASCADE,
     swap_id INTEGER REFERENCES swaps(id) ON DELETE CASCADE,
+    request_index INTEGER NOT NULL DEFAULT 0,
     PRIMARY KEY (batch_id, swap_id)
 );
 
 -- Create hot wallets table
 CREATE TABLE hot_wallets (
     id SERIAL PRIMARY KEY,
-    address VARCHAR(42) UNIQUE NOT NULL,
+    address VARCHAR(42) NOT NULL,
     chain_id BIGINT NOT NULL,
     nonce BIGINT NOT NULL DEFAULT 0,
+    native_balance NUMERIC(78),
+    balance_updated_at TIMESTAMP WITH TIME ZONE,
     last_used_at TIMESTAMP WITH TIME ZONE,
     is_active BOOLEAN NOT NULL DEFAULT true,
+    drain_to VARCHAR(42),
+    drained_at TIMESTAMP WITH TIME ZONE,
     total_processed_batches INTEGER NOT NULL DEFAULT 0,
     total_processed_volume NUMERIC(78) NOT NULL DEFAULT 0,
     created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
-    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE(address, chain_id)
 );
 
 -- Create supported tokens table
@@ -86,6 +106,19 @@ CREATE TABLE supported_tokens (
     UNIQUE(chain_id, token_address)
 );
 
+-- Create token_mappings table
+CREATE TABLE token_mappings (
+    id SERIAL PRIMARY KEY,
+    source_chain_id BIGINT NOT NULL,
+    source_token VARCHAR(42) NOT NULL,
+    target_chain_id BIGINT NOT NULL,
+    target_token VARCHAR(42) NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE(source_chain_id, source_token, target_chain_id)
+);
+
 -- Create chain_configs table
 CREATE TABLE chain_configs (
     id SERIAL PRIMARY KEY,
@@ -94,12 +127,92 @@ CREATE TABLE chain_configs (
     rpc_url TEXT NOT NULL,
     bridge_address VARCHAR(42) NOT NULL,
     required_confirmations INTEGER NOT NULL,
+    finality_mode VARCHAR(16) NOT NULL DEFAULT 'confirmations'
+        CHECK (finality_mode IN ('confirmations', 'safe', 'finalized')),
     max_gas_price NUMERIC(78),
+    stuck_tx_timeout_seconds INTEGER NOT NULL DEFAULT 300,
+    auto_approve BOOLEAN NOT NULL DEFAULT true,
+    start_block BIGINT,
+    min_wallet_balance NUMERIC(78), -- Hot wallets below it get no work
+    target_wallet_balance NUMERIC(78), -- What the treasury tops them up to
     is_active BOOLEAN NOT NULL DEFAULT true,
     created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
     updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
 );
 
+-- Create bridge_events table
+CREATE TABLE bridge_events (
+    id SERIAL PRIMARY KEY,
+    chain_id BIGINT NOT NULL,
+    event_name VARCHAR(50) NOT NULL,
+    onchain_batch_id VARCHAR(66) NOT NULL,
+    block_number BIGINT NOT NULL,
+    block_hash VARCHAR(66) NOT NULL,
+    tx_hash VARCHAR(66) NOT NULL,
+    log_index INTEGER NOT NULL,
+    payload JSONB,
+    batch_id INTEGER REFERENCES batches(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE(chain_id, tx_hash, log_index)
+);
+
+-- Create chain_checkpoints table
+CREATE TABLE chain_checkpoints (
+    chain_id BIGINT PRIMARY KEY,
+    first_block BIGINT,
+    last_block BIGINT NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create wallet_topups table
+CREATE TABLE wallet_topups (
+    id SERIAL PRIMARY KEY,
+    chain_id BIGINT NOT NULL,
+    wallet_address VARCHAR(42) NOT NULL,
+    treasury_address VARCHAR(42) NOT NULL,
+    amount NUMERIC(78) NOT NULL,
+    tx_hash VARCHAR(66) UNIQUE NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'confirmed', 'failed')),
+    error_message TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create bridge_liquidity table
+CREATE TABLE bridge_liquidity (
+    chain_id BIGINT NOT NULL,
+    token_address VARCHAR(42) NOT NULL,
+    balance NUMERIC(78) NOT NULL,
+    obligations NUMERIC(78) NOT NULL, -- Target amounts of unfinished swaps to the chain
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (chain_id, token_address)
+);
+
+-- Create batch_attestations table
+CREATE TABLE batch_attestations (
+    id SERIAL PRIMARY KEY,
+    batch_id INTEGER NOT NULL REFERENCES batches(id) ON DELETE CASCADE,
+    digest VARCHAR(66) NOT NULL,
+    attester VARCHAR(42) NOT NULL,
+    signature BYTEA NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE(digest, attester)
+);
+
+-- Create batch_transactions table
+CREATE TABLE batch_transactions (
+    id SERIAL PRIMARY KEY,
+    batch_id INTEGER NOT NULL REFERENCES batches(id) ON DELETE CASCADE,
+    wallet_address VARCHAR(42) NOT NULL,
+    tx_hash VARCHAR(66) UNIQUE NOT NULL,
+    nonce BIGINT NOT NULL,
+    gas_price NUMERIC(78) NOT NULL,
+    gas_tip_cap NUMERIC(78),
+    gas_fee_cap NUMERIC(78),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
 -- Create audit_logs table
 CREATE TABLE audit_logs (
     id SERIAL PRIMARY KEY,
@@ -119,17 +232,29 @@ CREATE INDEX idx_swaps_to_chain ON swaps(to_chain_id);
 CREATE INDEX idx_swaps_token ON swaps(token_address);
 CREATE INDEX idx_swaps_recipient ON swaps(recipient);
 CREATE INDEX idx_swaps_created_at ON swaps(created_at);
+CREATE INDEX idx_swaps_obligations ON swaps(to_chain_id, target_token_address)
+    WHERE status NOT IN ('completed', 'failed', 'reverted');
 
 CREATE INDEX idx_batches_status ON batches(status);
 CREATE INDEX idx_batches_wallet ON batches(wallet_address);
 CREATE INDEX idx_batches_chain ON batches(chain_id);
 CREATE INDEX idx_batches_created_at ON batches(created_at);
 CREATE INDEX idx_batches_block_number ON batches(block_number);
+CREATE INDEX idx_batches_source_tx_hash ON batches(source_tx_hash);
+CREATE INDEX idx_batches_onchain_batch_id ON batches(onchain_batch_id);
+
+CREATE INDEX idx_bridge_events_batch ON bridge_events(onchain_batch_id);
+CREATE INDEX idx_bridge_events_block ON bridge_events(chain_id, block_number);
+
+CREATE INDEX idx_batch_attestations_batch ON batch_attestations(batch_id);
+CREATE INDEX idx_batch_transactions_batch ON batch_transactions(batch_id);
 
 CREATE INDEX idx_hot_wallets_chain ON hot_wallets(chain_id);
 CREATE INDEX idx_hot_wallets_active ON hot_wallets(is_active);
 CREATE INDEX idx_hot_wallets_last_used ON hot_wallets(last_used_at);
 
+CREATE INDEX idx_wallet_topups_pending ON wallet_topups(chain_id) WHERE status = 'pending';
+
 CREATE INDEX idx_supported_tokens_chain ON supported_tokens(chain_id);
 CREATE INDEX idx_supported_tokens_active ON supported_tokens(is_active);
 
@@ -167,6 +292,16 @@ CREATE TRIGGER update_chain_configs_updated_at
     FOR EACH ROW
     EXECUTE FUNCTION update_updated_at_column();
 
+CREATE TRIGGER update_token_mappings_updated_at
+    BEFORE UPDATE ON token_mappings
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_wallet_topups_updated_at
+    BEFORE UPDATE ON wallet_topups
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
 -- Audit logging trigger
 CREATE OR REPLACE FUNCTION audit_trigger_func()
 RETURNS TRIGGER AS $$
@@ -245,17 +380,31 @@ CREATE OR REPLACE VIEW wallet_performance AS
 SELECT
     w.address,
     w.chain_id,
-    count(DISTINCT b.id) as total_batches,
-    count(DISTINCT bs.swap_id) as total_swaps,
+    count(b.id) as total_batches,
+    coalesce(sum(bs.swaps), 0) as total_swaps,
     avg(b.gas_price) as avg_gas_price,
     sum(b.gas_used) as total_gas_used,
     count(CASE WHEN b.status = 'completed' THEN 1 END) as successful_batches,
-    count(CASE WHEN b.status = 'failed' THEN 1 END) as failed_batches
+    count(CASE WHEN b.status IN ('failed', 'reverted') THEN 1 END) as failed_batches
 FROM hot_wallets w
-LEFT JOIN batches b ON w.address = b.wallet_address
-LEFT JOIN batch_swaps bs ON b.id = bs.batch_id
+LEFT JOIN batches b ON w.address = b.wallet_address AND w.chain_id = b.chain_id
+LEFT JOIN (
+    SELECT batch_id, count(*) as swaps FROM batch_swaps GROUP BY batch_id
+) bs ON b.id = bs.batch_id
 GROUP BY w.address, w.chain_id;
 
+-- Rounding dust left in each source bridge by swaps to tokens with fewer
+-- decimals, in source token units.
+CREATE OR REPLACE VIEW token_dust AS
+SELECT
+    from_chain_id as chain_id,
+    token_address,
+    sum(dust) as total_dust,
+    count(*) as swaps
+FROM swaps
+WHERE dust > 0 AND status NOT IN ('failed', 'reverted')
+GROUP BY from_chain_id, token_address;
+
 -- Initial data
 INSERT INTO chain_configs (chain_id, chain_type, rpc_url, bridge_address, required_confirmations, max_gas_price) VALUES
 (1, 'ethereum', 'https://mainnet.infura.io/v3/YOUR-PROJECT-ID', '0x...', 12, 500000000000),
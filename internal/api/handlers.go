@@ -1,22 +1,33 @@
 package api
 
 import (
+	"crypto/subtle"
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
+	"strings"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/gorilla/mux"
-	"github.com/yourusername/bridge-swap/internal/service"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/models"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/service"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/wallets"
 )
 
 type Server struct {
-	bridge *service.BridgeService
-	router *mux.Router
+	bridge     *service.BridgeService
+	router     *mux.Router
+	adminToken string
 }
 
-func NewServer(bridge *service.BridgeService) *Server {
+// NewServer serves the API of bridge. The admin endpoints require
+// adminToken as a bearer token and are disabled when it is empty.
+func NewServer(bridge *service.BridgeService, adminToken string) *Server {
 	s := &Server{
-		bridge: bridge,
-		router: mux.NewRouter(),
+		bridge:     bridge,
+		router:     mux.NewRouter(),
+		adminToken: adminToken,
 	}
 	s.setupRoutes()
 	return s
@@ -26,6 +37,28 @@ func (s *Server) setupRoutes() {
 	s.router.HandleFunc("/api/swap", s.handleInitiateSwap).Methods("POST")
 	s.router.HandleFunc("/api/swap/{requestId}", s.handleGetSwapStatus).Methods("GET")
 	s.router.HandleFunc("/api/queue/status", s.handleGetQueueStatus).Methods("GET")
+	s.router.HandleFunc("/api/liquidity", s.handleGetLiquidity).Methods("GET")
+	s.router.HandleFunc("/api/wallets/topups", s.handleGetWalletTopUps).Methods("GET")
+	s.router.HandleFunc("/api/wallets", s.handleGetWallets).Methods("GET")
+	s.router.HandleFunc("/api/wallets", s.admin(s.handleAddWallet)).Methods("POST")
+	s.router.HandleFunc("/api/wallets/{address}/drain", s.admin(s.handleDrainWallet)).Methods("POST")
+	s.router.HandleFunc("/api/wallets/{address}/retire", s.admin(s.handleRetireWallet)).Methods("POST")
+}
+
+// admin lets only requests carrying the admin token through to next.
+func (s *Server) admin(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.adminToken == "" {
+			http.Error(w, "Admin API disabled", http.StatusForbidden)
+			return
+		}
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.adminToken)) != 1 {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next(w, r)
+	}
 }
 
 func (s *Server) Start(addr string) error {
@@ -33,13 +66,20 @@ func (s *Server) Start(addr string) error {
 }
 
 func (s *Server) handleInitiateSwap(w http.ResponseWriter, r *http.Request) {
-	var req service.SwapRequest
+	var req models.SwapRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		http.Error(w, "Invalid request body", http.StatusBadRequest)
 		return
 	}
 
 	response, err := s.bridge.InitiateSwap(r.Context(), &req)
+	var invalid *models.ValidationError
+	if errors.As(err, &invalid) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(invalid)
+		return
+	}
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -69,3 +109,97 @@ func (s *Server) handleGetQueueStatus(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(status)
 }
+
+func (s *Server) handleGetLiquidity(w http.ResponseWriter, r *http.Request) {
+	routes := s.bridge.GetLiquidity(r.Context())
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(routes)
+}
+
+func (s *Server) handleGetWalletTopUps(w http.ResponseWriter, r *http.Request) {
+	limit := 100
+	if value := r.URL.Query().Get("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		limit = n
+	}
+
+	topUps, err := s.bridge.GetWalletTopUps(r.Context(), limit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if topUps == nil {
+		topUps = []*models.WalletTopUp{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(topUps)
+}
+
+func (s *Server) handleGetWallets(w http.ResponseWriter, r *http.Request) {
+	wallets, err := s.bridge.GetWallets(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if wallets == nil {
+		wallets = []*models.HotWalletState{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(wallets)
+}
+
+func (s *Server) handleAddWallet(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Address string `json:"address"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || !common.IsHexAddress(req.Address) {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	s.walletOperation(w, r, s.bridge.AddWallet(r.Context(), common.HexToAddress(req.Address)))
+}
+
+func (s *Server) handleDrainWallet(w http.ResponseWriter, r *http.Request) {
+	address := mux.Vars(r)["address"]
+	var req struct {
+		To string `json:"to"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || !common.IsHexAddress(req.To) || !common.IsHexAddress(address) {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	s.walletOperation(w, r, s.bridge.DrainWallet(r.Context(), common.HexToAddress(address), common.HexToAddress(req.To)))
+}
+
+func (s *Server) handleRetireWallet(w http.ResponseWriter, r *http.Request) {
+	address := mux.Vars(r)["address"]
+	if !common.IsHexAddress(address) {
+		http.Error(w, "Invalid address", http.StatusBadRequest)
+		return
+	}
+
+	s.walletOperation(w, r, s.bridge.RetireWallet(r.Context(), common.HexToAddress(address)))
+}
+
+// walletOperation responds with the outcome of a wallet operation: 400 for
+// one the wallet's state does not allow, and the wallets' states otherwise.
+func (s *Server) walletOperation(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, wallets.ErrInvalidOperation) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	s.handleGetWallets(w, r)
+}
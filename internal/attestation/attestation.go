@@ -0,0 +1,188 @@
+// Package attestation produces and checks the EIP-712 signatures that
+// BatchBridge.batchCompleteSwap requires before releasing a batch on its
+// destination chain.
+package attestation
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/contracts"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/signer"
+)
+
+// ErrNotEnoughSignatures is returned while fewer attesters than the bridge's
+// threshold have signed a batch.
+var ErrNotEnoughSignatures = errors.New("not enough attestations")
+
+// Domain name and version BatchBridge passes to its EIP712 constructor.
+const (
+	DomainName    = "BatchBridge"
+	DomainVersion = "1"
+)
+
+var typedDataTypes = apitypes.Types{
+	"EIP712Domain": {
+		{Name: "name", Type: "string"},
+		{Name: "version", Type: "string"},
+		{Name: "chainId", Type: "uint256"},
+		{Name: "verifyingContract", Type: "address"},
+	},
+	"BatchAttestation": {
+		{Name: "batchId", Type: "bytes32"},
+		{Name: "sourceChainId", Type: "uint256"},
+		{Name: "targetChainId", Type: "uint256"},
+		{Name: "requests", Type: "SwapRequest[]"},
+	},
+	"SwapRequest": {
+		{Name: "token", Type: "address"},
+		{Name: "amount", Type: "uint256"},
+		{Name: "recipient", Type: "address"},
+		{Name: "targetChainId", Type: "uint256"},
+	},
+}
+
+// Batch is what an attestation covers: a source batch and the requests to
+// release for it on the destination chain.
+type Batch struct {
+	BatchID       common.Hash
+	SourceChainID int64
+	TargetChainID int64
+	Requests      []contracts.BatchBridgeSwapRequest
+}
+
+// TypedData returns the EIP-712 BatchAttestation message for batch, bound to
+// the destination bridge at bridge.
+func TypedData(bridge common.Address, batch *Batch) apitypes.TypedData {
+	requests := make([]interface{}, len(batch.Requests))
+	for i, req := range batch.Requests {
+		requests[i] = map[string]interface{}{
+			"token":         req.Token.Hex(),
+			"amount":        req.Amount,
+			"recipient":     req.Recipient.Hex(),
+			"targetChainId": req.TargetChainId,
+		}
+	}
+
+	return apitypes.TypedData{
+		Types:       typedDataTypes,
+		PrimaryType: "BatchAttestation",
+		Domain: apitypes.TypedDataDomain{
+			Name:              DomainName,
+			Version:           DomainVersion,
+			ChainId:           (*math.HexOrDecimal256)(big.NewInt(batch.TargetChainID)),
+			VerifyingContract: bridge.Hex(),
+		},
+		Message: apitypes.TypedDataMessage{
+			"batchId":       batch.BatchID.Hex(),
+			"sourceChainId": big.NewInt(batch.SourceChainID),
+			"targetChainId": big.NewInt(batch.TargetChainID),
+			"requests":      requests,
+		},
+	}
+}
+
+// Digest returns the EIP-712 hash of the attestation for batch.
+func Digest(bridge common.Address, batch *Batch) (common.Hash, error) {
+	hash, _, err := apitypes.TypedDataAndHash(TypedData(bridge, batch))
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error hashing batch attestation: %v", err)
+	}
+	return common.BytesToHash(hash), nil
+}
+
+// Recover returns the address that produced signature over the attestation
+// for batch.
+func Recover(bridge common.Address, batch *Batch, signature []byte) (common.Address, error) {
+	if len(signature) != crypto.SignatureLength {
+		return common.Address{}, fmt.Errorf("invalid signature length %d", len(signature))
+	}
+	digest, err := Digest(bridge, batch)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	// Undo the Ethereum-style recovery id Sign produces.
+	sig := make([]byte, crypto.SignatureLength)
+	copy(sig, signature)
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+
+	pub, err := crypto.SigToPub(digest.Bytes(), sig)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("error recovering signer: %v", err)
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
+
+// Combine encodes the signatures of the first threshold signers, in ascending
+// address order, as the concatenated signature batchCompleteSwap expects.
+func Combine(signatures map[common.Address][]byte, threshold int) ([]byte, error) {
+	if len(signatures) < threshold {
+		return nil, fmt.Errorf("%w: have %d of %d", ErrNotEnoughSignatures, len(signatures), threshold)
+	}
+
+	signers := make([]common.Address, 0, len(signatures))
+	for signer := range signatures {
+		signers = append(signers, signer)
+	}
+	sort.Slice(signers, func(i, j int) bool {
+		return bytes.Compare(signers[i].Bytes(), signers[j].Bytes()) < 0
+	})
+
+	combined := make([]byte, 0, threshold*crypto.SignatureLength)
+	for _, signer := range signers[:threshold] {
+		combined = append(combined, signatures[signer]...)
+	}
+	return combined, nil
+}
+
+// Signer signs batch attestations with a single attester key.
+type Signer struct {
+	local   *signer.Local
+	Address common.Address
+}
+
+func NewSigner(key *ecdsa.PrivateKey) *Signer {
+	return FromLocal(signer.NewLocal(key))
+}
+
+// FromLocal signs attestations with the key local holds, such as one
+// decrypted with signer.LoadKeyFile. Once local is zeroed, Sign fails.
+func FromLocal(local *signer.Local) *Signer {
+	return &Signer{
+		local:   local,
+		Address: local.Address(),
+	}
+}
+
+// Sign returns the 65-byte r||s||v signature over the attestation for batch,
+// with v set to 27 or 28 as the contract's ECDSA.recover expects.
+func (s *Signer) Sign(bridge common.Address, batch *Batch) ([]byte, error) {
+	digest, err := Digest(bridge, batch)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := s.local.SignHash(digest.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("error signing batch attestation: %v", err)
+	}
+	signature[crypto.RecoveryIDOffset] += 27
+	return signature, nil
+}
+
+// Attest implements relayer.Attester with this signer's key alone.
+func (s *Signer) Attest(ctx context.Context, bridge common.Address, batch *Batch) ([]byte, error) {
+	return s.Sign(bridge, batch)
+}
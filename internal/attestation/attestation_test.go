@@ -0,0 +1,177 @@
+package attestation
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/contracts"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/signer"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// newBridge returns a simulated chain whose bridge holds tokens to release
+// and trusts the returned signer.
+func newBridge(t *testing.T) (*testutil.Chain, *Signer) {
+	chain := testutil.NewChain(t)
+
+	tx, err := chain.Token.Transfer(chain.Transactor(t, chain.Owner), chain.BridgeAddr, testutil.Ether(10))
+	require.NoError(t, err)
+	chain.Mine(t, tx)
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer := NewSigner(key)
+	tx, err = chain.Bridge.AddAttester(chain.Transactor(t, chain.Admin), signer.Address)
+	require.NoError(t, err)
+	chain.Mine(t, tx)
+
+	return chain, signer
+}
+
+func newBatch(chain *testutil.Chain) *Batch {
+	return &Batch{
+		BatchID:       common.HexToHash("0xb1"),
+		SourceChainID: 56,
+		TargetChainID: testutil.SimulatedChainID,
+		Requests: []contracts.BatchBridgeSwapRequest{
+			{Token: chain.TokenAddr, Amount: testutil.Ether(1), Recipient: common.HexToAddress("0x01"), TargetChainId: big.NewInt(testutil.SimulatedChainID)},
+			{Token: chain.TokenAddr, Amount: testutil.Ether(2), Recipient: common.HexToAddress("0x02"), TargetChainId: big.NewInt(testutil.SimulatedChainID)},
+		},
+	}
+}
+
+func complete(t *testing.T, chain *testutil.Chain, batch *Batch, signature []byte) error {
+	tx, err := chain.Bridge.BatchCompleteSwap(chain.Transactor(t, chain.Owner), batch.BatchID, big.NewInt(batch.SourceChainID), batch.Requests, signature)
+	if err != nil {
+		return err
+	}
+	chain.Mine(t, tx)
+	return nil
+}
+
+func TestDigestMatchesContract(t *testing.T) {
+	chain, _ := newBridge(t)
+	batch := newBatch(chain)
+
+	digest, err := Digest(chain.BridgeAddr, batch)
+	require.NoError(t, err)
+
+	onchain, err := chain.Bridge.HashBatchAttestation(&bind.CallOpts{}, batch.BatchID, big.NewInt(batch.SourceChainID), batch.Requests)
+	require.NoError(t, err)
+	require.Equal(t, onchain, [32]byte(digest))
+}
+
+func TestSignatureAcceptedOnChain(t *testing.T) {
+	chain, signer := newBridge(t)
+	batch := newBatch(chain)
+
+	signature, err := signer.Sign(chain.BridgeAddr, batch)
+	require.NoError(t, err)
+
+	recovered, err := Recover(chain.BridgeAddr, batch, signature)
+	require.NoError(t, err)
+	require.Equal(t, signer.Address, recovered)
+
+	require.NoError(t, complete(t, chain, batch, signature))
+
+	balance, err := chain.Token.BalanceOf(&bind.CallOpts{}, common.HexToAddress("0x02"))
+	require.NoError(t, err)
+	require.Equal(t, testutil.Ether(2), balance)
+}
+
+func TestZeroedAttesterKeyDoesNotSign(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	local := signer.NewLocal(key)
+	attester := FromLocal(local)
+	require.Equal(t, local.Address(), attester.Address)
+
+	batch := newBatch(testutil.NewChain(t))
+	bridge := common.HexToAddress("0xb1d9")
+	signature, err := attester.Sign(bridge, batch)
+	require.NoError(t, err)
+	recovered, err := Recover(bridge, batch, signature)
+	require.NoError(t, err)
+	require.Equal(t, attester.Address, recovered)
+
+	local.Zero()
+	_, err = attester.Sign(bridge, batch)
+	require.ErrorContains(t, err, "has been zeroed")
+}
+
+func TestTamperedAttestationRejected(t *testing.T) {
+	chain, signer := newBridge(t)
+
+	outsiderKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	outsider := NewSigner(outsiderKey)
+
+	tests := []struct {
+		name   string
+		signer *Signer
+		bridge common.Address
+		tamper func(*Batch)
+	}{
+		{
+			name:   "amount",
+			tamper: func(b *Batch) { b.Requests[0].Amount = testutil.Ether(5) },
+		},
+		{
+			name:   "recipient",
+			tamper: func(b *Batch) { b.Requests[1].Recipient = common.HexToAddress("0xbad") },
+		},
+		{
+			name:   "dropped request",
+			tamper: func(b *Batch) { b.Requests = b.Requests[:1] },
+		},
+		{
+			name:   "batch id",
+			tamper: func(b *Batch) { b.BatchID = common.HexToHash("0xb2") },
+		},
+		{
+			name:   "source chain",
+			tamper: func(b *Batch) { b.SourceChainID = 1 },
+		},
+		{
+			name:   "other bridge",
+			bridge: common.HexToAddress("0xb41d9e"),
+		},
+		{
+			name:   "unknown attester",
+			signer: outsider,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, bridge := signer, chain.BridgeAddr
+			if tt.signer != nil {
+				s = tt.signer
+			}
+			if tt.bridge != (common.Address{}) {
+				bridge = tt.bridge
+			}
+
+			batch := newBatch(chain)
+			signature, err := s.Sign(bridge, batch)
+			require.NoError(t, err)
+			if tt.tamper != nil {
+				tt.tamper(batch)
+			}
+
+			require.ErrorContains(t, complete(t, chain, batch, signature), "Invalid signature")
+		})
+	}
+
+	t.Run("malformed signature", func(t *testing.T) {
+		batch := newBatch(chain)
+		signature, err := signer.Sign(chain.BridgeAddr, batch)
+		require.NoError(t, err)
+
+		require.ErrorContains(t, complete(t, chain, batch, signature[:64]), "Invalid signature")
+	})
+}
@@ -0,0 +1,2966 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// BatchBridgeSwapRequest is an auto generated low-level Go binding around an user-defined struct.
+type BatchBridgeSwapRequest struct {
+	Token         common.Address
+	Amount        *big.Int
+	Recipient     common.Address
+	TargetChainId *big.Int
+}
+
+// BatchBridgeMetaData contains all meta data concerning the BatchBridge contract.
+var BatchBridgeMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"address\",\"name\":\"admin_\",\"type\":\"address\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"previousAdmin\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"newAdmin\",\"type\":\"address\"}],\"name\":\"AdminTransferred\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"attester\",\"type\":\"address\"}],\"name\":\"AttesterAdded\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"attester\",\"type\":\"address\"}],\"name\":\"AttesterRemoved\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"threshold\",\"type\":\"uint256\"}],\"name\":\"AttesterThresholdChanged\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"batchId\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"sourceChainId\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"targetChainId\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"timestamp\",\"type\":\"uint256\"}],\"name\":\"BatchSwapCompleted\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"batchId\",\"type\":\"bytes32\"},{\"components\":[{\"internalType\":\"address\",\"name\":\"token\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"recipient\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"targetChainId\",\"type\":\"uint256\"}],\"indexed\":false,\"internalType\":\"structBatchBridge.SwapRequest[]\",\"name\":\"requests\",\"type\":\"tuple[]\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"timestamp\",\"type\":\"uint256\"}],\"name\":\"BatchSwapInitiated\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[],\"name\":\"EIP712DomainChanged\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"}],\"name\":\"OperatorAdded\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"}],\"name\":\"OperatorRemoved\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"previousOwner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"OwnershipTransferred\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"Paused\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"Unpaused\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"sourceChainId\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"token\",\"type\":\"address\"}],\"name\":\"WrappedNativeTokenSet\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"BATCH_ATTESTATION_TYPEHASH\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"NATIVE_TOKEN\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"SWAP_REQUEST_TYPEHASH\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"attester\",\"type\":\"address\"}],\"name\":\"addAttester\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"}],\"name\":\"addOperator\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"token\",\"type\":\"address\"}],\"name\":\"addSupportedToken\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"admin\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"attesterCount\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"attesterThreshold\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"attesters\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"batchId\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"sourceChainId\",\"type\":\"uint256\"},{\"components\":[{\"internalType\":\"address\",\"name\":\"token\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"recipient\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"targetChainId\",\"type\":\"uint256\"}],\"internalType\":\"structBatchBridge.SwapRequest[]\",\"name\":\"requests\",\"type\":\"tuple[]\"},{\"internalType\":\"bytes\",\"name\":\"signature\",\"type\":\"bytes\"}],\"name\":\"batchCompleteSwap\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"batchCount\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"address\",\"name\":\"token\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"recipient\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"targetChainId\",\"type\":\"uint256\"}],\"internalType\":\"structBatchBridge.SwapRequest[]\",\"name\":\"requests\",\"type\":\"tuple[]\"}],\"name\":\"batchInitiateSwap\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"},{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"name\":\"completedBatches\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"eip712Domain\",\"outputs\":[{\"internalType\":\"bytes1\",\"name\":\"fields\",\"type\":\"bytes1\"},{\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"version\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"chainId\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"verifyingContract\",\"type\":\"address\"},{\"internalType\":\"bytes32\",\"name\":\"salt\",\"type\":\"bytes32\"},{\"internalType\":\"uint256[]\",\"name\":\"extensions\",\"type\":\"uint256[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"token\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"recipient\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"emergencyWithdraw\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"batchId\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"sourceChainId\",\"type\":\"uint256\"},{\"components\":[{\"internalType\":\"address\",\"name\":\"token\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"recipient\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"targetChainId\",\"type\":\"uint256\"}],\"internalType\":\"structBatchBridge.SwapRequest[]\",\"name\":\"requests\",\"type\":\"tuple[]\"}],\"name\":\"hashBatchAttestation\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"operators\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"owner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"pause\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"paused\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"attester\",\"type\":\"address\"}],\"name\":\"removeAttester\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"}],\"name\":\"removeOperator\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"token\",\"type\":\"address\"}],\"name\":\"removeSupportedToken\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"renounceOwnership\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"threshold\",\"type\":\"uint256\"}],\"name\":\"setAttesterThreshold\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"sourceChainId\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"token\",\"type\":\"address\"}],\"name\":\"setWrappedNativeToken\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"supportedTokens\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"newAdmin\",\"type\":\"address\"}],\"name\":\"transferAdmin\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"transferOwnership\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"unpause\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"wrappedNativeTokens\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"stateMutability\":\"payable\",\"type\":\"receive\"}]",
+	Bin: "0x6101206040526001600c553480156200001757600080fd5b5060405162002d1638038062002d168339810160408190526200003a9162000243565b6040518060400160405280600b81526020016a426174636842726964676560a81b815250604051806040016040528060018152602001603160f81b815250620000926200008c620001ef60201b60201c565b620001f3565b6000805460ff60a01b19169055600180556002620000b183826200031a565b506003620000c082826200031a565b50815160208084019190912060e052815190820120610100524660a0526200014b60e05161010051604080517f8b73c3c69bb8fe3d512ecc4cf759cc79239f7b179b0ffacaa9a75d522b39400f60208201529081019290925260608201524660808201523060a082015260009060c00160405160208183030381529060405280519060200120905090565b60805250503060c0526001600160a01b0381166200019f5760405162461bcd60e51b815260206004820152600d60248201526c24b73b30b634b21030b236b4b760991b604482015260640160405180910390fd5b600480546001600160a01b0319166001600160a01b0383169081179091556040516000907ff8ccb027dfcd135e000e9d45e6cc2d662578a8825d4c45b5e32e0adf67e79ec6908290a350620003e6565b3390565b600080546001600160a01b038381166001600160a01b0319831681178455604051919092169283917f8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e09190a35050565b6000602082840312156200025657600080fd5b81516001600160a01b03811681146200026e57600080fd5b9392505050565b634e487b7160e01b600052604160045260246000fd5b600181811c90821680620002a057607f821691505b602082108103620002c157634e487b7160e01b600052602260045260246000fd5b50919050565b601f8211156200031557600081815260208120601f850160051c81016020861015620002f05750805b601f850160051c820191505b818110156200031157828155600101620002fc565b5050505b505050565b81516001600160401b0381111562000336576200033662000275565b6200034e816200034784546200028b565b84620002c7565b602080601f8311600181146200038657600084156200036d5750858301515b600019600386901b1c1916600185901b17855562000311565b600085815260208120601f198616915b82811015620003b75788860151825594840194600190910190840162000396565b5085821015620003d65787850151600019600388901b60f8161c191681555b5050505050600190811b01905550565b60805160a05160c05160e051610100516128eb6200042b60003960006121660152600061213e01526000612099015260006120c3015260006120ed01526128eb6000f3fe6080604052600436106101e75760003560e01c806384b0196e11610102578063c6ef5d0411610095578063e40e421411610064578063e40e4214146105e6578063e63ea4081461061a578063f2fde38b1461063a578063f851a4401461065a57600080fd5b8063c6ef5d0414610521578063cc78fef114610557578063d9d5001514610577578063dd349ff4146105b257600080fd5b8063a4cf845d116100d1578063a4cf845d146104a1578063ac8a584a146104c1578063ae18ba2f146104e1578063b19e77281461050157600080fd5b806384b0196e146104255780638da5cb5b1461044d5780639870d7fe1461046b5780639cb20b731461048b57600080fd5b80635c975abb1161017a57806375829def1161014957806375829def146103b057806376319190146103d0578063821dce33146103f05780638456cb591461041057600080fd5b80635c975abb1461032c57806368c4ac261461034b5780636d69fcaf1461037b578063715018a61461039b57600080fd5b806313e7c9d8116101b657806313e7c9d8146102915780631481103f146102c157806331f7d964146102d75780633f4ba83a1461031757600080fd5b806302bef847146101f357806306f13056146102155780630ee88e6c1461023e578063131a91ad1461025157600080fd5b366101ee57005b600080fd5b3480156101ff57600080fd5b5061021361020e36600461229c565b61067a565b005b34801561022157600080fd5b5061022b60055481565b6040519081526020015b60405180910390f35b61021361024c36600461230a565b6107c2565b34801561025d57600080fd5b5061028161026c36600461229c565b60096020526000908152604090205460ff1681565b6040519015158152602001610235565b34801561029d57600080fd5b506102816102ac36600461229c565b600a6020526000908152604090205460ff1681565b3480156102cd57600080fd5b5061022b600b5481565b3480156102e357600080fd5b506102ff73eeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeee81565b6040516001600160a01b039091168152602001610235565b34801561032357600080fd5b50610213610b8e565b34801561033857600080fd5b50600054600160a01b900460ff16610281565b34801561035757600080fd5b5061028161036636600461229c565b60076020526000908152604090205460ff1681565b34801561038757600080fd5b5061021361039636600461229c565b610ba0565b3480156103a757600080fd5b50610213610bcc565b3480156103bc57600080fd5b506102136103cb36600461229c565b610bde565b3480156103dc57600080fd5b506102136103eb36600461229c565b610d13565b3480156103fc57600080fd5b5061022b61040b36600461234c565b610d3c565b34801561041c57600080fd5b50610213610f57565b34801561043157600080fd5b5061043a610f67565b60405161023597969594939291906123e5565b34801561045957600080fd5b506000546001600160a01b03166102ff565b34801561047757600080fd5b5061021361048636600461229c565b6110dc565b34801561049757600080fd5b5061022b600c5481565b3480156104ad57600080fd5b506102136104bc3660046124c2565b611258565b3480156104cd57600080fd5b506102136104dc36600461229c565b6117d6565b3480156104ed57600080fd5b506102136104fc366004612595565b6118a3565b34801561050d57600080fd5b5061021361051c3660046125ae565b61195a565b34801561052d57600080fd5b506102ff61053c366004612595565b6008602052600090815260409020546001600160a01b031681565b34801561056357600080fd5b5061021361057236600461229c565b611a23565b34801561058357600080fd5b506102816105923660046125da565b600660209081526000928352604080842090915290825290205460ff1681565b3480156105be57600080fd5b5061022b7fb85305c5b351154968d97773be804eb8f6e185afb99bc0890addfc81758a42dd81565b3480156105f257600080fd5b5061022b7f0bd2322168b28734c1912ad23a73b6fd6e2742ab3692358e6f2f5c8ed4e174a381565b34801561062657600080fd5b506102136106353660046125fc565b611b64565b34801561064657600080fd5b5061021361065536600461229c565b611cc8565b34801561066657600080fd5b506004546102ff906001600160a01b031681565b6004546001600160a01b031633146106ad5760405162461bcd60e51b81526004016106a490612638565b60405180910390fd5b6001600160a01b0381166106f65760405162461bcd60e51b815260206004820152601060248201526f24b73b30b634b21030ba3a32b9ba32b960811b60448201526064016106a4565b6001600160a01b03811660009081526009602052604090205460ff16156107555760405162461bcd60e51b815260206004820152601360248201527220b63932b0b23c9030b71030ba3a32b9ba32b960691b60448201526064016106a4565b6001600160a01b0381166000908152600960205260408120805460ff19166001179055600b80549161078683612685565b90915550506040516001600160a01b038216907f6ba7a3f25b8b53ecc6d1367a381fde6e7bd6ae793acb6b3f4726c425fc0caec790600090a250565b6002600154036108145760405162461bcd60e51b815260206004820152601f60248201527f5265656e7472616e637947756172643a207265656e7472616e742063616c6c0060448201526064016106a4565b6002600155610821611d41565b336000908152600a602052604090205460ff1661087c5760405162461bcd60e51b815260206004820152601960248201527821b0b63632b91034b9903737ba1030b71037b832b930ba37b960391b60448201526064016106a4565b806108b75760405162461bcd60e51b815260206004820152600b60248201526a08adae0e8f240c4c2e8c6d60ab1b60448201526064016106a4565b6005805460408051466020808301919091523082840152606080830185905283518084039091018152608090920190925280519101209160006108f983612685565b91905055506000805b83811015610af857600085858381811061091e5761091e61269e565b90506080020180360381019061093491906126b4565b80516001600160a01b031660009081526007602052604090205490915060ff166109945760405162461bcd60e51b81526020600482015260116024820152702ab739bab83837b93a32b2103a37b5b2b760791b60448201526064016106a4565b60008160200151116109d95760405162461bcd60e51b815260206004820152600e60248201526d125b9d985b1a5908185b5bdd5b9d60921b60448201526064016106a4565b60408101516001600160a01b0316610a275760405162461bcd60e51b8152602060048201526011602482015270125b9d985b1a59081c9958da5c1a595b9d607a1b60448201526064016106a4565b80516001600160a01b031673eeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeed1901610a63576020810151610a5c9084612726565b9250610ae5565b805160208201516040516323b872dd60e01b815233600482015230602482015260448101919091526001600160a01b03909116906323b872dd906064016020604051808303816000875af1158015610abf573d6000803e3d6000fd5b505050506040513d601f19601f82011682018060405250810190610ae39190612739565b505b5080610af081612685565b915050610902565b50803414610b485760405162461bcd60e51b815260206004820152601760248201527f496e636f7272656374206e617469766520616d6f756e7400000000000000000060448201526064016106a4565b817fc7c8c8ef2a7ef6e758132a316f95d2a22400e408a2898568490fc579e93d220d858542604051610b7c9392919061275b565b60405180910390a25050600180555050565b610b96611d8e565b610b9e611de8565b565b610ba8611d8e565b6001600160a01b03166000908152600760205260409020805460ff19166001179055565b610bd4611d8e565b610b9e6000611e3d565b6004546001600160a01b03163314610c085760405162461bcd60e51b81526004016106a490612638565b6001600160a01b038116610c4e5760405162461bcd60e51b815260206004820152600d60248201526c24b73b30b634b21030b236b4b760991b60448201526064016106a4565b6001600160a01b0381166000908152600a602052604090205460ff1615610cb75760405162461bcd60e51b815260206004820152601b60248201527f41646d696e2063616e6e6f7420626520616e206f70657261746f72000000000060448201526064016106a4565b6004546040516001600160a01b038084169216907ff8ccb027dfcd135e000e9d45e6cc2d662578a8825d4c45b5e32e0adf67e79ec690600090a3600480546001600160a01b0319166001600160a01b0392909216919091179055565b610d1b611d8e565b6001600160a01b03166000908152600760205260409020805460ff19169055565b6000808267ffffffffffffffff811115610d5857610d5861247b565b604051908082528060200260200182016040528015610d81578160200160208202803683370190505b50905060005b83811015610ebc577fb85305c5b351154968d97773be804eb8f6e185afb99bc0890addfc81758a42dd858583818110610dc257610dc261269e565b610dd8926020608090920201908101915061229c565b868684818110610dea57610dea61269e565b90506080020160200135878785818110610e0657610e0661269e565b9050608002016040016020810190610e1e919061229c565b888886818110610e3057610e3061269e565b90506080020160600135604051602001610e779594939291909485526001600160a01b03938416602086015260408501929092529091166060830152608082015260a00190565b60405160208183030381529060405280519060200120828281518110610e9f57610e9f61269e565b602090810291909101015280610eb481612685565b915050610d87565b50610f4d7f0bd2322168b28734c1912ad23a73b6fd6e2742ab3692358e6f2f5c8ed4e174a387874685604051602001610ef591906127da565b60408051601f198184030181528282528051602091820120908301969096528101939093526060830191909152608082015260a081019190915260c00160405160208183030381529060405280519060200120611e8d565b9695505050505050565b610f5f611d8e565b610b9e611ec0565b6000606080828080836002600346308580604051908082528060200260200182016040528015610fa1578160200160208202803683370190505b50600f60f81b959493929190858054610fb990612810565b80601f0160208091040260200160405190810160405280929190818152602001828054610fe590612810565b80156110325780601f1061100757610100808354040283529160200191611032565b820191906000526020600020905b81548152906001019060200180831161101557829003601f168201915b5050505050955084805461104590612810565b80601f016020809104026020016040519081016040528092919081815260200182805461107190612810565b80156110be5780601f10611093576101008083540402835291602001916110be565b820191906000526020600020905b8154815290600101906020018083116110a157829003601f168201915b50505050509450965096509650965096509650965090919293949596565b6004546001600160a01b031633146111065760405162461bcd60e51b81526004016106a490612638565b6001600160a01b03811661114f5760405162461bcd60e51b815260206004820152601060248201526f24b73b30b634b21037b832b930ba37b960811b60448201526064016106a4565b6004546001600160a01b03908116908216036111ad5760405162461bcd60e51b815260206004820152601b60248201527f41646d696e2063616e6e6f7420626520616e206f70657261746f72000000000060448201526064016106a4565b6001600160a01b0381166000908152600a602052604090205460ff161561120c5760405162461bcd60e51b815260206004820152601360248201527220b63932b0b23c9030b71037b832b930ba37b960691b60448201526064016106a4565b6001600160a01b0381166000818152600a6020526040808220805460ff19166001179055517fac6fa858e9350a46cec16539926e0fde25b7629f84b5a72bffaae4df888ae86d9190a250565b6002600154036112aa5760405162461bcd60e51b815260206004820152601f60248201527f5265656e7472616e637947756172643a207265656e7472616e742063616c6c0060448201526064016106a4565b60026001556112b7611d41565b336000908152600a602052604090205460ff166113125760405162461bcd60e51b815260206004820152601960248201527821b0b63632b91034b9903737ba1030b71037b832b930ba37b960391b60448201526064016106a4565b600084815260066020908152604080832088845290915290205460ff161561137c5760405162461bcd60e51b815260206004820152601760248201527f426174636820616c726561647920636f6d706c6574656400000000000000000060448201526064016106a4565b816113b75760405162461bcd60e51b815260206004820152600b60248201526a08adae0e8f240c4c2e8c6d60ab1b60448201526064016106a4565b6113c48585858585611f03565b6114045760405162461bcd60e51b8152602060048201526011602482015270496e76616c6964207369676e617475726560781b60448201526064016106a4565b60005b828110156117455760008484838181106114235761142361269e565b90506080020180360381019061143991906126b4565b9050468160600151146114835760405162461bcd60e51b81526020600482015260126024820152712bb937b733903a30b933b2ba1031b430b4b760711b60448201526064016106a4565b80516001600160a01b031660009081526007602052604090205460ff166114e05760405162461bcd60e51b81526020600482015260116024820152702ab739bab83837b93a32b2103a37b5b2b760791b60448201526064016106a4565b60008160200151116115255760405162461bcd60e51b815260206004820152600e60248201526d125b9d985b1a5908185b5bdd5b9d60921b60448201526064016106a4565b60408101516001600160a01b03166115735760405162461bcd60e51b8152602060048201526011602482015270125b9d985b1a59081c9958da5c1a595b9d607a1b60448201526064016106a4565b80516001600160a01b031673eeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeee146116205780516040808301516020840151915163a9059cbb60e01b81526001600160a01b03918216600482015260248101929092529091169063a9059cbb906044015b6020604051808303816000875af11580156115f6573d6000803e3d6000fd5b505050506040513d601f19601f8201168201806040525081019061161a9190612739565b50611732565b6000868152600860205260409020546001600160a01b03161561168c57600086815260086020908152604091829020548383015191840151925163a9059cbb60e01b81526001600160a01b0392831660048201526024810193909352169063a9059cbb906044016115d7565b600081604001516001600160a01b0316826020015160405160006040518083038185875af1925050503d80600081146116e1576040519150601f19603f3d011682016040523d82523d6000602084013e6116e6565b606091505b50509050806117305760405162461bcd60e51b815260206004820152601660248201527513985d1a5d99481d1c985b9cd9995c8819985a5b195960521b60448201526064016106a4565b505b508061173d81612685565b915050611407565b5060008481526006602090815260408083208884529091528120805460ff19166001179055849086907f18c566205ba366e24a9c85bd24eb5898c5a92a03afecf70e43368b802d0976e99086908690816117a1576117a161269e565b90506080020160600135426040516117c3929190918252602082015260400190565b60405180910390a3505060018055505050565b6004546001600160a01b031633146118005760405162461bcd60e51b81526004016106a490612638565b6001600160a01b0381166000908152600a602052604090205460ff1661185a5760405162461bcd60e51b815260206004820152600f60248201526e2737ba1030b71037b832b930ba37b960891b60448201526064016106a4565b6001600160a01b0381166000818152600a6020526040808220805460ff19169055517f80c0b871b97b595b16a7741c1b06fed0c6f6f558639f18ccbce50724325dc40d9190a250565b6004546001600160a01b031633146118cd5760405162461bcd60e51b81526004016106a490612638565b6000811180156118df5750600b548111155b61191f5760405162461bcd60e51b8152602060048201526011602482015270125b9d985b1a59081d1a1c995cda1bdb19607a1b60448201526064016106a4565b600c8190556040518181527fb80e2882b04d3a1aafac893a86209c8091982ad85b5c4accc973ec9d5ca475589060200160405180910390a150565b611962611d8e565b73eeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeed196001600160a01b038216016119bf5760405162461bcd60e51b815260206004820152600d60248201526c24b73b30b634b2103a37b5b2b760991b60448201526064016106a4565b60008281526008602090815260409182902080546001600160a01b0319166001600160a01b038516908117909155915191825283917f143495f603447fec72f81998de92c3c8451e2dc2bc595809dca7664ec2c05feb910160405180910390a25050565b6004546001600160a01b03163314611a4d5760405162461bcd60e51b81526004016106a490612638565b6001600160a01b03811660009081526009602052604090205460ff16611aa75760405162461bcd60e51b815260206004820152600f60248201526e2737ba1030b71030ba3a32b9ba32b960891b60448201526064016106a4565b600c54600b5411611afa5760405162461bcd60e51b815260206004820152601b60248201527f5468726573686f6c64206578636565647320617474657374657273000000000060448201526064016106a4565b6001600160a01b0381166000908152600960205260408120805460ff19169055600b805491611b288361284a565b90915550506040516001600160a01b038216907f368846ed588a2fb12b4fabffde520ad537df26ea80ad789b60afc37c5cc7fe6b90600090a250565b6004546001600160a01b03163314611b8e5760405162461bcd60e51b81526004016106a490612638565b73eeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeed196001600160a01b03841601611c55576000826001600160a01b03168260405160006040518083038185875af1925050503d8060008114611c00576040519150601f19603f3d011682016040523d82523d6000602084013e611c05565b606091505b5050905080611c4f5760405162461bcd60e51b815260206004820152601660248201527513985d1a5d99481d1c985b9cd9995c8819985a5b195960521b60448201526064016106a4565b50505050565b60405163a9059cbb60e01b81526001600160a01b0383811660048301526024820183905284169063a9059cbb906044016020604051808303816000875af1158015611ca4573d6000803e3d6000fd5b505050506040513d601f19601f82011682018060405250810190611c4f9190612739565b611cd0611d8e565b6001600160a01b038116611d355760405162461bcd60e51b815260206004820152602660248201527f4f776e61626c653a206e6577206f776e657220697320746865207a65726f206160448201526564647265737360d01b60648201526084016106a4565b611d3e81611e3d565b50565b600054600160a01b900460ff1615610b9e5760405162461bcd60e51b815260206004820152601060248201526f14185d5cd8589b194e881c185d5cd95960821b60448201526064016106a4565b6000546001600160a01b03163314610b9e5760405162461bcd60e51b815260206004820181905260248201527f4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e657260448201526064016106a4565b611df061203c565b6000805460ff60a01b191690557f5db9ee0a495bf2e6ff9c91a7834c1ba4fdd244a5e8aa4e537bd38aeae4b073aa335b6040516001600160a01b03909116815260200160405180910390a1565b600080546001600160a01b038381166001600160a01b0319831681178455604051919092169283917f8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e09190a35050565b6000611eba611e9a61208c565b8360405161190160f01b8152600281019290925260228201526042902090565b92915050565b611ec8611d41565b6000805460ff60a01b1916600160a01b1790557f62e78cea01bee320cd4e420270b5ea74000d11b0c9f74754ebdbfc544b05a258611e203390565b6000815160001480611f21575060418251611f1e9190612877565b15155b15611f2e57506000612033565b600060418351611f3e919061288b565b9050600c54811015611f54576000915050612033565b6000611f6288888888610d3c565b90506000805b8381101561202a5760418102860160208101516040820151606090920151909190600090811a9080611f9c888487876121bc565b90925090506000816004811115611fb557611fb561289f565b141580611fdb57506001600160a01b03821660009081526009602052604090205460ff16155b80611ff85750866001600160a01b0316826001600160a01b031611155b1561200f5760009950505050505050505050612033565b8196505050505050808061202290612685565b915050611f68565b50600193505050505b95945050505050565b600054600160a01b900460ff16610b9e5760405162461bcd60e51b815260206004820152601460248201527314185d5cd8589b194e881b9bdd081c185d5cd95960621b60448201526064016106a4565b6000306001600160a01b037f0000000000000000000000000000000000000000000000000000000000000000161480156120e557507f000000000000000000000000000000000000000000000000000000000000000046145b1561210f57507f000000000000000000000000000000000000000000000000000000000000000090565b6121b7604080517f8b73c3c69bb8fe3d512ecc4cf759cc79239f7b179b0ffacaa9a75d522b39400f60208201527f0000000000000000000000000000000000000000000000000000000000000000918101919091527f000000000000000000000000000000000000000000000000000000000000000060608201524660808201523060a082015260009060c00160405160208183030381529060405280519060200120905090565b905090565b6000807f7fffffffffffffffffffffffffffffff5d576e7357a4501ddfe92f46681b20a08311156121f35750600090506003612277565b6040805160008082526020820180845289905260ff881692820192909252606081018690526080810185905260019060a0016020604051602081039080840390855afa158015612247573d6000803e3d6000fd5b5050604051601f1901519150506001600160a01b03811661227057600060019250925050612277565b9150600090505b94509492505050565b80356001600160a01b038116811461229757600080fd5b919050565b6000602082840312156122ae57600080fd5b6122b782612280565b9392505050565b60008083601f8401126122d057600080fd5b50813567ffffffffffffffff8111156122e857600080fd5b6020830191508360208260071b850101111561230357600080fd5b9250929050565b6000806020838503121561231d57600080fd5b823567ffffffffffffffff81111561233457600080fd5b612340858286016122be565b90969095509350505050565b6000806000806060858703121561236257600080fd5b8435935060208501359250604085013567ffffffffffffffff81111561238757600080fd5b612393878288016122be565b95989497509550505050565b6000815180845260005b818110156123c5576020818501810151868301820152016123a9565b506000602082860101526020601f19601f83011685010191505092915050565b60ff60f81b881681526000602060e08184015261240560e084018a61239f565b8381036040850152612417818a61239f565b606085018990526001600160a01b038816608086015260a0850187905284810360c0860152855180825283870192509083019060005b818110156124695783518352928401929184019160010161244d565b50909c9b505050505050505050505050565b634e487b7160e01b600052604160045260246000fd5b604051601f8201601f1916810167ffffffffffffffff811182821017156124ba576124ba61247b565b604052919050565b6000806000806000608086880312156124da57600080fd5b853594506020808701359450604087013567ffffffffffffffff8082111561250157600080fd5b61250d8a838b016122be565b9096509450606089013591508082111561252657600080fd5b818901915089601f83011261253a57600080fd5b81358181111561254c5761254c61247b565b61255e601f8201601f19168501612491565b91508082528a8482850101111561257457600080fd5b80848401858401376000848284010152508093505050509295509295909350565b6000602082840312156125a757600080fd5b5035919050565b600080604083850312156125c157600080fd5b823591506125d160208401612280565b90509250929050565b600080604083850312156125ed57600080fd5b50508035926020909101359150565b60008060006060848603121561261157600080fd5b61261a84612280565b925061262860208501612280565b9150604084013590509250925092565b60208082526017908201527f43616c6c6572206973206e6f74207468652061646d696e000000000000000000604082015260600190565b634e487b7160e01b600052601160045260246000fd5b6000600182016126975761269761266f565b5060010190565b634e487b7160e01b600052603260045260246000fd5b6000608082840312156126c657600080fd5b6040516080810181811067ffffffffffffffff821117156126e9576126e961247b565b6040526126f583612280565b81526020830135602082015261270d60408401612280565b6040820152606083013560608201528091505092915050565b80820180821115611eba57611eba61266f565b60006020828403121561274b57600080fd5b815180151581146122b757600080fd5b6040808252818101849052600090606080840187845b888110156127c4576001600160a01b038061278b84612280565b16845260208381013590850152806127a4878501612280565b168487015250818401358484015260809283019290910190600101612771565b5050809350505050826020830152949350505050565b815160009082906020808601845b83811015612804578151855293820193908201906001016127e8565b50929695505050505050565b600181811c9082168061282457607f821691505b60208210810361284457634e487b7160e01b600052602260045260246000fd5b50919050565b6000816128595761285961266f565b506000190190565b634e487b7160e01b600052601260045260246000fd5b60008261288657612886612861565b500690565b60008261289a5761289a612861565b500490565b634e487b7160e01b600052602160045260246000fdfea2646970667358221220b7492f7b452a0e99550f1fca9fec4e7d2a1bbb0cbcb638613f59f93bdbe9044a64736f6c63430008150033",
+}
+
+// BatchBridgeABI is the input ABI used to generate the binding from.
+// Deprecated: Use BatchBridgeMetaData.ABI instead.
+var BatchBridgeABI = BatchBridgeMetaData.ABI
+
+// BatchBridgeBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use BatchBridgeMetaData.Bin instead.
+var BatchBridgeBin = BatchBridgeMetaData.Bin
+
+// DeployBatchBridge deploys a new Ethereum contract, binding an instance of BatchBridge to it.
+func DeployBatchBridge(auth *bind.TransactOpts, backend bind.ContractBackend, admin_ common.Address) (common.Address, *types.Transaction, *BatchBridge, error) {
+	parsed, err := BatchBridgeMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(BatchBridgeBin), backend, admin_)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &BatchBridge{BatchBridgeCaller: BatchBridgeCaller{contract: contract}, BatchBridgeTransactor: BatchBridgeTransactor{contract: contract}, BatchBridgeFilterer: BatchBridgeFilterer{contract: contract}}, nil
+}
+
+// BatchBridge is an auto generated Go binding around an Ethereum contract.
+type BatchBridge struct {
+	BatchBridgeCaller     // Read-only binding to the contract
+	BatchBridgeTransactor // Write-only binding to the contract
+	BatchBridgeFilterer   // Log filterer for contract events
+}
+
+// BatchBridgeCaller is an auto generated read-only Go binding around an Ethereum contract.
+type BatchBridgeCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// BatchBridgeTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type BatchBridgeTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// BatchBridgeFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type BatchBridgeFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// BatchBridgeSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type BatchBridgeSession struct {
+	Contract     *BatchBridge      // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// BatchBridgeCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type BatchBridgeCallerSession struct {
+	Contract *BatchBridgeCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts      // Call options to use throughout this session
+}
+
+// BatchBridgeTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type BatchBridgeTransactorSession struct {
+	Contract     *BatchBridgeTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts      // Transaction auth options to use throughout this session
+}
+
+// BatchBridgeRaw is an auto generated low-level Go binding around an Ethereum contract.
+type BatchBridgeRaw struct {
+	Contract *BatchBridge // Generic contract binding to access the raw methods on
+}
+
+// BatchBridgeCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type BatchBridgeCallerRaw struct {
+	Contract *BatchBridgeCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// BatchBridgeTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type BatchBridgeTransactorRaw struct {
+	Contract *BatchBridgeTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewBatchBridge creates a new instance of BatchBridge, bound to a specific deployed contract.
+func NewBatchBridge(address common.Address, backend bind.ContractBackend) (*BatchBridge, error) {
+	contract, err := bindBatchBridge(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &BatchBridge{BatchBridgeCaller: BatchBridgeCaller{contract: contract}, BatchBridgeTransactor: BatchBridgeTransactor{contract: contract}, BatchBridgeFilterer: BatchBridgeFilterer{contract: contract}}, nil
+}
+
+// NewBatchBridgeCaller creates a new read-only instance of BatchBridge, bound to a specific deployed contract.
+func NewBatchBridgeCaller(address common.Address, caller bind.ContractCaller) (*BatchBridgeCaller, error) {
+	contract, err := bindBatchBridge(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &BatchBridgeCaller{contract: contract}, nil
+}
+
+// NewBatchBridgeTransactor creates a new write-only instance of BatchBridge, bound to a specific deployed contract.
+func NewBatchBridgeTransactor(address common.Address, transactor bind.ContractTransactor) (*BatchBridgeTransactor, error) {
+	contract, err := bindBatchBridge(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &BatchBridgeTransactor{contract: contract}, nil
+}
+
+// NewBatchBridgeFilterer creates a new log filterer instance of BatchBridge, bound to a specific deployed contract.
+func NewBatchBridgeFilterer(address common.Address, filterer bind.ContractFilterer) (*BatchBridgeFilterer, error) {
+	contract, err := bindBatchBridge(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &BatchBridgeFilterer{contract: contract}, nil
+}
+
+// bindBatchBridge binds a generic wrapper to an already deployed contract.
+func bindBatchBridge(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := BatchBridgeMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_BatchBridge *BatchBridgeRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _BatchBridge.Contract.BatchBridgeCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_BatchBridge *BatchBridgeRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _BatchBridge.Contract.BatchBridgeTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_BatchBridge *BatchBridgeRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _BatchBridge.Contract.BatchBridgeTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_BatchBridge *BatchBridgeCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _BatchBridge.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_BatchBridge *BatchBridgeTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _BatchBridge.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_BatchBridge *BatchBridgeTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _BatchBridge.Contract.contract.Transact(opts, method, params...)
+}
+
+// BATCHATTESTATIONTYPEHASH is a free data retrieval call binding the contract method 0xe40e4214.
+//
+// Solidity: function BATCH_ATTESTATION_TYPEHASH() view returns(bytes32)
+func (_BatchBridge *BatchBridgeCaller) BATCHATTESTATIONTYPEHASH(opts *bind.CallOpts) ([32]byte, error) {
+	var out []interface{}
+	err := _BatchBridge.contract.Call(opts, &out, "BATCH_ATTESTATION_TYPEHASH")
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// BATCHATTESTATIONTYPEHASH is a free data retrieval call binding the contract method 0xe40e4214.
+//
+// Solidity: function BATCH_ATTESTATION_TYPEHASH() view returns(bytes32)
+func (_BatchBridge *BatchBridgeSession) BATCHATTESTATIONTYPEHASH() ([32]byte, error) {
+	return _BatchBridge.Contract.BATCHATTESTATIONTYPEHASH(&_BatchBridge.CallOpts)
+}
+
+// BATCHATTESTATIONTYPEHASH is a free data retrieval call binding the contract method 0xe40e4214.
+//
+// Solidity: function BATCH_ATTESTATION_TYPEHASH() view returns(bytes32)
+func (_BatchBridge *BatchBridgeCallerSession) BATCHATTESTATIONTYPEHASH() ([32]byte, error) {
+	return _BatchBridge.Contract.BATCHATTESTATIONTYPEHASH(&_BatchBridge.CallOpts)
+}
+
+// NATIVETOKEN is a free data retrieval call binding the contract method 0x31f7d964.
+//
+// Solidity: function NATIVE_TOKEN() view returns(address)
+func (_BatchBridge *BatchBridgeCaller) NATIVETOKEN(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _BatchBridge.contract.Call(opts, &out, "NATIVE_TOKEN")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// NATIVETOKEN is a free data retrieval call binding the contract method 0x31f7d964.
+//
+// Solidity: function NATIVE_TOKEN() view returns(address)
+func (_BatchBridge *BatchBridgeSession) NATIVETOKEN() (common.Address, error) {
+	return _BatchBridge.Contract.NATIVETOKEN(&_BatchBridge.CallOpts)
+}
+
+// NATIVETOKEN is a free data retrieval call binding the contract method 0x31f7d964.
+//
+// Solidity: function NATIVE_TOKEN() view returns(address)
+func (_BatchBridge *BatchBridgeCallerSession) NATIVETOKEN() (common.Address, error) {
+	return _BatchBridge.Contract.NATIVETOKEN(&_BatchBridge.CallOpts)
+}
+
+// SWAPREQUESTTYPEHASH is a free data retrieval call binding the contract method 0xdd349ff4.
+//
+// Solidity: function SWAP_REQUEST_TYPEHASH() view returns(bytes32)
+func (_BatchBridge *BatchBridgeCaller) SWAPREQUESTTYPEHASH(opts *bind.CallOpts) ([32]byte, error) {
+	var out []interface{}
+	err := _BatchBridge.contract.Call(opts, &out, "SWAP_REQUEST_TYPEHASH")
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// SWAPREQUESTTYPEHASH is a free data retrieval call binding the contract method 0xdd349ff4.
+//
+// Solidity: function SWAP_REQUEST_TYPEHASH() view returns(bytes32)
+func (_BatchBridge *BatchBridgeSession) SWAPREQUESTTYPEHASH() ([32]byte, error) {
+	return _BatchBridge.Contract.SWAPREQUESTTYPEHASH(&_BatchBridge.CallOpts)
+}
+
+// SWAPREQUESTTYPEHASH is a free data retrieval call binding the contract method 0xdd349ff4.
+//
+// Solidity: function SWAP_REQUEST_TYPEHASH() view returns(bytes32)
+func (_BatchBridge *BatchBridgeCallerSession) SWAPREQUESTTYPEHASH() ([32]byte, error) {
+	return _BatchBridge.Contract.SWAPREQUESTTYPEHASH(&_BatchBridge.CallOpts)
+}
+
+// Admin is a free data retrieval call binding the contract method 0xf851a440.
+//
+// Solidity: function admin() view returns(address)
+func (_BatchBridge *BatchBridgeCaller) Admin(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _BatchBridge.contract.Call(opts, &out, "admin")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Admin is a free data retrieval call binding the contract method 0xf851a440.
+//
+// Solidity: function admin() view returns(address)
+func (_BatchBridge *BatchBridgeSession) Admin() (common.Address, error) {
+	return _BatchBridge.Contract.Admin(&_BatchBridge.CallOpts)
+}
+
+// Admin is a free data retrieval call binding the contract method 0xf851a440.
+//
+// Solidity: function admin() view returns(address)
+func (_BatchBridge *BatchBridgeCallerSession) Admin() (common.Address, error) {
+	return _BatchBridge.Contract.Admin(&_BatchBridge.CallOpts)
+}
+
+// AttesterCount is a free data retrieval call binding the contract method 0x1481103f.
+//
+// Solidity: function attesterCount() view returns(uint256)
+func (_BatchBridge *BatchBridgeCaller) AttesterCount(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _BatchBridge.contract.Call(opts, &out, "attesterCount")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// AttesterCount is a free data retrieval call binding the contract method 0x1481103f.
+//
+// Solidity: function attesterCount() view returns(uint256)
+func (_BatchBridge *BatchBridgeSession) AttesterCount() (*big.Int, error) {
+	return _BatchBridge.Contract.AttesterCount(&_BatchBridge.CallOpts)
+}
+
+// AttesterCount is a free data retrieval call binding the contract method 0x1481103f.
+//
+// Solidity: function attesterCount() view returns(uint256)
+func (_BatchBridge *BatchBridgeCallerSession) AttesterCount() (*big.Int, error) {
+	return _BatchBridge.Contract.AttesterCount(&_BatchBridge.CallOpts)
+}
+
+// AttesterThreshold is a free data retrieval call binding the contract method 0x9cb20b73.
+//
+// Solidity: function attesterThreshold() view returns(uint256)
+func (_BatchBridge *BatchBridgeCaller) AttesterThreshold(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _BatchBridge.contract.Call(opts, &out, "attesterThreshold")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// AttesterThreshold is a free data retrieval call binding the contract method 0x9cb20b73.
+//
+// Solidity: function attesterThreshold() view returns(uint256)
+func (_BatchBridge *BatchBridgeSession) AttesterThreshold() (*big.Int, error) {
+	return _BatchBridge.Contract.AttesterThreshold(&_BatchBridge.CallOpts)
+}
+
+// AttesterThreshold is a free data retrieval call binding the contract method 0x9cb20b73.
+//
+// Solidity: function attesterThreshold() view returns(uint256)
+func (_BatchBridge *BatchBridgeCallerSession) AttesterThreshold() (*big.Int, error) {
+	return _BatchBridge.Contract.AttesterThreshold(&_BatchBridge.CallOpts)
+}
+
+// Attesters is a free data retrieval call binding the contract method 0x131a91ad.
+//
+// Solidity: function attesters(address ) view returns(bool)
+func (_BatchBridge *BatchBridgeCaller) Attesters(opts *bind.CallOpts, arg0 common.Address) (bool, error) {
+	var out []interface{}
+	err := _BatchBridge.contract.Call(opts, &out, "attesters", arg0)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// Attesters is a free data retrieval call binding the contract method 0x131a91ad.
+//
+// Solidity: function attesters(address ) view returns(bool)
+func (_BatchBridge *BatchBridgeSession) Attesters(arg0 common.Address) (bool, error) {
+	return _BatchBridge.Contract.Attesters(&_BatchBridge.CallOpts, arg0)
+}
+
+// Attesters is a free data retrieval call binding the contract method 0x131a91ad.
+//
+// Solidity: function attesters(address ) view returns(bool)
+func (_BatchBridge *BatchBridgeCallerSession) Attesters(arg0 common.Address) (bool, error) {
+	return _BatchBridge.Contract.Attesters(&_BatchBridge.CallOpts, arg0)
+}
+
+// BatchCount is a free data retrieval call binding the contract method 0x06f13056.
+//
+// Solidity: function batchCount() view returns(uint256)
+func (_BatchBridge *BatchBridgeCaller) BatchCount(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _BatchBridge.contract.Call(opts, &out, "batchCount")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// BatchCount is a free data retrieval call binding the contract method 0x06f13056.
+//
+// Solidity: function batchCount() view returns(uint256)
+func (_BatchBridge *BatchBridgeSession) BatchCount() (*big.Int, error) {
+	return _BatchBridge.Contract.BatchCount(&_BatchBridge.CallOpts)
+}
+
+// BatchCount is a free data retrieval call binding the contract method 0x06f13056.
+//
+// Solidity: function batchCount() view returns(uint256)
+func (_BatchBridge *BatchBridgeCallerSession) BatchCount() (*big.Int, error) {
+	return _BatchBridge.Contract.BatchCount(&_BatchBridge.CallOpts)
+}
+
+// CompletedBatches is a free data retrieval call binding the contract method 0xd9d50015.
+//
+// Solidity: function completedBatches(uint256 , bytes32 ) view returns(bool)
+func (_BatchBridge *BatchBridgeCaller) CompletedBatches(opts *bind.CallOpts, arg0 *big.Int, arg1 [32]byte) (bool, error) {
+	var out []interface{}
+	err := _BatchBridge.contract.Call(opts, &out, "completedBatches", arg0, arg1)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// CompletedBatches is a free data retrieval call binding the contract method 0xd9d50015.
+//
+// Solidity: function completedBatches(uint256 , bytes32 ) view returns(bool)
+func (_BatchBridge *BatchBridgeSession) CompletedBatches(arg0 *big.Int, arg1 [32]byte) (bool, error) {
+	return _BatchBridge.Contract.CompletedBatches(&_BatchBridge.CallOpts, arg0, arg1)
+}
+
+// CompletedBatches is a free data retrieval call binding the contract method 0xd9d50015.
+//
+// Solidity: function completedBatches(uint256 , bytes32 ) view returns(bool)
+func (_BatchBridge *BatchBridgeCallerSession) CompletedBatches(arg0 *big.Int, arg1 [32]byte) (bool, error) {
+	return _BatchBridge.Contract.CompletedBatches(&_BatchBridge.CallOpts, arg0, arg1)
+}
+
+// Eip712Domain is a free data retrieval call binding the contract method 0x84b0196e.
+//
+// Solidity: function eip712Domain() view returns(bytes1 fields, string name, string version, uint256 chainId, address verifyingContract, bytes32 salt, uint256[] extensions)
+func (_BatchBridge *BatchBridgeCaller) Eip712Domain(opts *bind.CallOpts) (struct {
+	Fields            [1]byte
+	Name              string
+	Version           string
+	ChainId           *big.Int
+	VerifyingContract common.Address
+	Salt              [32]byte
+	Extensions        []*big.Int
+}, error) {
+	var out []interface{}
+	err := _BatchBridge.contract.Call(opts, &out, "eip712Domain")
+
+	outstruct := new(struct {
+		Fields            [1]byte
+		Name              string
+		Version           string
+		ChainId           *big.Int
+		VerifyingContract common.Address
+		Salt              [32]byte
+		Extensions        []*big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Fields = *abi.ConvertType(out[0], new([1]byte)).(*[1]byte)
+	outstruct.Name = *abi.ConvertType(out[1], new(string)).(*string)
+	outstruct.Version = *abi.ConvertType(out[2], new(string)).(*string)
+	outstruct.ChainId = *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+	outstruct.VerifyingContract = *abi.ConvertType(out[4], new(common.Address)).(*common.Address)
+	outstruct.Salt = *abi.ConvertType(out[5], new([32]byte)).(*[32]byte)
+	outstruct.Extensions = *abi.ConvertType(out[6], new([]*big.Int)).(*[]*big.Int)
+
+	return *outstruct, err
+
+}
+
+// Eip712Domain is a free data retrieval call binding the contract method 0x84b0196e.
+//
+// Solidity: function eip712Domain() view returns(bytes1 fields, string name, string version, uint256 chainId, address verifyingContract, bytes32 salt, uint256[] extensions)
+func (_BatchBridge *BatchBridgeSession) Eip712Domain() (struct {
+	Fields            [1]byte
+	Name              string
+	Version           string
+	ChainId           *big.Int
+	VerifyingContract common.Address
+	Salt              [32]byte
+	Extensions        []*big.Int
+}, error) {
+	return _BatchBridge.Contract.Eip712Domain(&_BatchBridge.CallOpts)
+}
+
+// Eip712Domain is a free data retrieval call binding the contract method 0x84b0196e.
+//
+// Solidity: function eip712Domain() view returns(bytes1 fields, string name, string version, uint256 chainId, address verifyingContract, bytes32 salt, uint256[] extensions)
+func (_BatchBridge *BatchBridgeCallerSession) Eip712Domain() (struct {
+	Fields            [1]byte
+	Name              string
+	Version           string
+	ChainId           *big.Int
+	VerifyingContract common.Address
+	Salt              [32]byte
+	Extensions        []*big.Int
+}, error) {
+	return _BatchBridge.Contract.Eip712Domain(&_BatchBridge.CallOpts)
+}
+
+// HashBatchAttestation is a free data retrieval call binding the contract method 0x821dce33.
+//
+// Solidity: function hashBatchAttestation(bytes32 batchId, uint256 sourceChainId, (address,uint256,address,uint256)[] requests) view returns(bytes32)
+func (_BatchBridge *BatchBridgeCaller) HashBatchAttestation(opts *bind.CallOpts, batchId [32]byte, sourceChainId *big.Int, requests []BatchBridgeSwapRequest) ([32]byte, error) {
+	var out []interface{}
+	err := _BatchBridge.contract.Call(opts, &out, "hashBatchAttestation", batchId, sourceChainId, requests)
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// HashBatchAttestation is a free data retrieval call binding the contract method 0x821dce33.
+//
+// Solidity: function hashBatchAttestation(bytes32 batchId, uint256 sourceChainId, (address,uint256,address,uint256)[] requests) view returns(bytes32)
+func (_BatchBridge *BatchBridgeSession) HashBatchAttestation(batchId [32]byte, sourceChainId *big.Int, requests []BatchBridgeSwapRequest) ([32]byte, error) {
+	return _BatchBridge.Contract.HashBatchAttestation(&_BatchBridge.CallOpts, batchId, sourceChainId, requests)
+}
+
+// HashBatchAttestation is a free data retrieval call binding the contract method 0x821dce33.
+//
+// Solidity: function hashBatchAttestation(bytes32 batchId, uint256 sourceChainId, (address,uint256,address,uint256)[] requests) view returns(bytes32)
+func (_BatchBridge *BatchBridgeCallerSession) HashBatchAttestation(batchId [32]byte, sourceChainId *big.Int, requests []BatchBridgeSwapRequest) ([32]byte, error) {
+	return _BatchBridge.Contract.HashBatchAttestation(&_BatchBridge.CallOpts, batchId, sourceChainId, requests)
+}
+
+// Operators is a free data retrieval call binding the contract method 0x13e7c9d8.
+//
+// Solidity: function operators(address ) view returns(bool)
+func (_BatchBridge *BatchBridgeCaller) Operators(opts *bind.CallOpts, arg0 common.Address) (bool, error) {
+	var out []interface{}
+	err := _BatchBridge.contract.Call(opts, &out, "operators", arg0)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// Operators is a free data retrieval call binding the contract method 0x13e7c9d8.
+//
+// Solidity: function operators(address ) view returns(bool)
+func (_BatchBridge *BatchBridgeSession) Operators(arg0 common.Address) (bool, error) {
+	return _BatchBridge.Contract.Operators(&_BatchBridge.CallOpts, arg0)
+}
+
+// Operators is a free data retrieval call binding the contract method 0x13e7c9d8.
+//
+// Solidity: function operators(address ) view returns(bool)
+func (_BatchBridge *BatchBridgeCallerSession) Operators(arg0 common.Address) (bool, error) {
+	return _BatchBridge.Contract.Operators(&_BatchBridge.CallOpts, arg0)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_BatchBridge *BatchBridgeCaller) Owner(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _BatchBridge.contract.Call(opts, &out, "owner")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_BatchBridge *BatchBridgeSession) Owner() (common.Address, error) {
+	return _BatchBridge.Contract.Owner(&_BatchBridge.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_BatchBridge *BatchBridgeCallerSession) Owner() (common.Address, error) {
+	return _BatchBridge.Contract.Owner(&_BatchBridge.CallOpts)
+}
+
+// Paused is a free data retrieval call binding the contract method 0x5c975abb.
+//
+// Solidity: function paused() view returns(bool)
+func (_BatchBridge *BatchBridgeCaller) Paused(opts *bind.CallOpts) (bool, error) {
+	var out []interface{}
+	err := _BatchBridge.contract.Call(opts, &out, "paused")
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// Paused is a free data retrieval call binding the contract method 0x5c975abb.
+//
+// Solidity: function paused() view returns(bool)
+func (_BatchBridge *BatchBridgeSession) Paused() (bool, error) {
+	return _BatchBridge.Contract.Paused(&_BatchBridge.CallOpts)
+}
+
+// Paused is a free data retrieval call binding the contract method 0x5c975abb.
+//
+// Solidity: function paused() view returns(bool)
+func (_BatchBridge *BatchBridgeCallerSession) Paused() (bool, error) {
+	return _BatchBridge.Contract.Paused(&_BatchBridge.CallOpts)
+}
+
+// SupportedTokens is a free data retrieval call binding the contract method 0x68c4ac26.
+//
+// Solidity: function supportedTokens(address ) view returns(bool)
+func (_BatchBridge *BatchBridgeCaller) SupportedTokens(opts *bind.CallOpts, arg0 common.Address) (bool, error) {
+	var out []interface{}
+	err := _BatchBridge.contract.Call(opts, &out, "supportedTokens", arg0)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// SupportedTokens is a free data retrieval call binding the contract method 0x68c4ac26.
+//
+// Solidity: function supportedTokens(address ) view returns(bool)
+func (_BatchBridge *BatchBridgeSession) SupportedTokens(arg0 common.Address) (bool, error) {
+	return _BatchBridge.Contract.SupportedTokens(&_BatchBridge.CallOpts, arg0)
+}
+
+// SupportedTokens is a free data retrieval call binding the contract method 0x68c4ac26.
+//
+// Solidity: function supportedTokens(address ) view returns(bool)
+func (_BatchBridge *BatchBridgeCallerSession) SupportedTokens(arg0 common.Address) (bool, error) {
+	return _BatchBridge.Contract.SupportedTokens(&_BatchBridge.CallOpts, arg0)
+}
+
+// WrappedNativeTokens is a free data retrieval call binding the contract method 0xc6ef5d04.
+//
+// Solidity: function wrappedNativeTokens(uint256 ) view returns(address)
+func (_BatchBridge *BatchBridgeCaller) WrappedNativeTokens(opts *bind.CallOpts, arg0 *big.Int) (common.Address, error) {
+	var out []interface{}
+	err := _BatchBridge.contract.Call(opts, &out, "wrappedNativeTokens", arg0)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// WrappedNativeTokens is a free data retrieval call binding the contract method 0xc6ef5d04.
+//
+// Solidity: function wrappedNativeTokens(uint256 ) view returns(address)
+func (_BatchBridge *BatchBridgeSession) WrappedNativeTokens(arg0 *big.Int) (common.Address, error) {
+	return _BatchBridge.Contract.WrappedNativeTokens(&_BatchBridge.CallOpts, arg0)
+}
+
+// WrappedNativeTokens is a free data retrieval call binding the contract method 0xc6ef5d04.
+//
+// Solidity: function wrappedNativeTokens(uint256 ) view returns(address)
+func (_BatchBridge *BatchBridgeCallerSession) WrappedNativeTokens(arg0 *big.Int) (common.Address, error) {
+	return _BatchBridge.Contract.WrappedNativeTokens(&_BatchBridge.CallOpts, arg0)
+}
+
+// AddAttester is a paid mutator transaction binding the contract method 0x02bef847.
+//
+// Solidity: function addAttester(address attester) returns()
+func (_BatchBridge *BatchBridgeTransactor) AddAttester(opts *bind.TransactOpts, attester common.Address) (*types.Transaction, error) {
+	return _BatchBridge.contract.Transact(opts, "addAttester", attester)
+}
+
+// AddAttester is a paid mutator transaction binding the contract method 0x02bef847.
+//
+// Solidity: function addAttester(address attester) returns()
+func (_BatchBridge *BatchBridgeSession) AddAttester(attester common.Address) (*types.Transaction, error) {
+	return _BatchBridge.Contract.AddAttester(&_BatchBridge.TransactOpts, attester)
+}
+
+// AddAttester is a paid mutator transaction binding the contract method 0x02bef847.
+//
+// Solidity: function addAttester(address attester) returns()
+func (_BatchBridge *BatchBridgeTransactorSession) AddAttester(attester common.Address) (*types.Transaction, error) {
+	return _BatchBridge.Contract.AddAttester(&_BatchBridge.TransactOpts, attester)
+}
+
+// AddOperator is a paid mutator transaction binding the contract method 0x9870d7fe.
+//
+// Solidity: function addOperator(address operator) returns()
+func (_BatchBridge *BatchBridgeTransactor) AddOperator(opts *bind.TransactOpts, operator common.Address) (*types.Transaction, error) {
+	return _BatchBridge.contract.Transact(opts, "addOperator", operator)
+}
+
+// AddOperator is a paid mutator transaction binding the contract method 0x9870d7fe.
+//
+// Solidity: function addOperator(address operator) returns()
+func (_BatchBridge *BatchBridgeSession) AddOperator(operator common.Address) (*types.Transaction, error) {
+	return _BatchBridge.Contract.AddOperator(&_BatchBridge.TransactOpts, operator)
+}
+
+// AddOperator is a paid mutator transaction binding the contract method 0x9870d7fe.
+//
+// Solidity: function addOperator(address operator) returns()
+func (_BatchBridge *BatchBridgeTransactorSession) AddOperator(operator common.Address) (*types.Transaction, error) {
+	return _BatchBridge.Contract.AddOperator(&_BatchBridge.TransactOpts, operator)
+}
+
+// AddSupportedToken is a paid mutator transaction binding the contract method 0x6d69fcaf.
+//
+// Solidity: function addSupportedToken(address token) returns()
+func (_BatchBridge *BatchBridgeTransactor) AddSupportedToken(opts *bind.TransactOpts, token common.Address) (*types.Transaction, error) {
+	return _BatchBridge.contract.Transact(opts, "addSupportedToken", token)
+}
+
+// AddSupportedToken is a paid mutator transaction binding the contract method 0x6d69fcaf.
+//
+// Solidity: function addSupportedToken(address token) returns()
+func (_BatchBridge *BatchBridgeSession) AddSupportedToken(token common.Address) (*types.Transaction, error) {
+	return _BatchBridge.Contract.AddSupportedToken(&_BatchBridge.TransactOpts, token)
+}
+
+// AddSupportedToken is a paid mutator transaction binding the contract method 0x6d69fcaf.
+//
+// Solidity: function addSupportedToken(address token) returns()
+func (_BatchBridge *BatchBridgeTransactorSession) AddSupportedToken(token common.Address) (*types.Transaction, error) {
+	return _BatchBridge.Contract.AddSupportedToken(&_BatchBridge.TransactOpts, token)
+}
+
+// BatchCompleteSwap is a paid mutator transaction binding the contract method 0xa4cf845d.
+//
+// Solidity: function batchCompleteSwap(bytes32 batchId, uint256 sourceChainId, (address,uint256,address,uint256)[] requests, bytes signature) returns()
+func (_BatchBridge *BatchBridgeTransactor) BatchCompleteSwap(opts *bind.TransactOpts, batchId [32]byte, sourceChainId *big.Int, requests []BatchBridgeSwapRequest, signature []byte) (*types.Transaction, error) {
+	return _BatchBridge.contract.Transact(opts, "batchCompleteSwap", batchId, sourceChainId, requests, signature)
+}
+
+// BatchCompleteSwap is a paid mutator transaction binding the contract method 0xa4cf845d.
+//
+// Solidity: function batchCompleteSwap(bytes32 batchId, uint256 sourceChainId, (address,uint256,address,uint256)[] requests, bytes signature) returns()
+func (_BatchBridge *BatchBridgeSession) BatchCompleteSwap(batchId [32]byte, sourceChainId *big.Int, requests []BatchBridgeSwapRequest, signature []byte) (*types.Transaction, error) {
+	return _BatchBridge.Contract.BatchCompleteSwap(&_BatchBridge.TransactOpts, batchId, sourceChainId, requests, signature)
+}
+
+// BatchCompleteSwap is a paid mutator transaction binding the contract method 0xa4cf845d.
+//
+// Solidity: function batchCompleteSwap(bytes32 batchId, uint256 sourceChainId, (address,uint256,address,uint256)[] requests, bytes signature) returns()
+func (_BatchBridge *BatchBridgeTransactorSession) BatchCompleteSwap(batchId [32]byte, sourceChainId *big.Int, requests []BatchBridgeSwapRequest, signature []byte) (*types.Transaction, error) {
+	return _BatchBridge.Contract.BatchCompleteSwap(&_BatchBridge.TransactOpts, batchId, sourceChainId, requests, signature)
+}
+
+// BatchInitiateSwap is a paid mutator transaction binding the contract method 0x0ee88e6c.
+//
+// Solidity: function batchInitiateSwap((address,uint256,address,uint256)[] requests) payable returns()
+func (_BatchBridge *BatchBridgeTransactor) BatchInitiateSwap(opts *bind.TransactOpts, requests []BatchBridgeSwapRequest) (*types.Transaction, error) {
+	return _BatchBridge.contract.Transact(opts, "batchInitiateSwap", requests)
+}
+
+// BatchInitiateSwap is a paid mutator transaction binding the contract method 0x0ee88e6c.
+//
+// Solidity: function batchInitiateSwap((address,uint256,address,uint256)[] requests) payable returns()
+func (_BatchBridge *BatchBridgeSession) BatchInitiateSwap(requests []BatchBridgeSwapRequest) (*types.Transaction, error) {
+	return _BatchBridge.Contract.BatchInitiateSwap(&_BatchBridge.TransactOpts, requests)
+}
+
+// BatchInitiateSwap is a paid mutator transaction binding the contract method 0x0ee88e6c.
+//
+// Solidity: function batchInitiateSwap((address,uint256,address,uint256)[] requests) payable returns()
+func (_BatchBridge *BatchBridgeTransactorSession) BatchInitiateSwap(requests []BatchBridgeSwapRequest) (*types.Transaction, error) {
+	return _BatchBridge.Contract.BatchInitiateSwap(&_BatchBridge.TransactOpts, requests)
+}
+
+// EmergencyWithdraw is a paid mutator transaction binding the contract method 0xe63ea408.
+//
+// Solidity: function emergencyWithdraw(address token, address recipient, uint256 amount) returns()
+func (_BatchBridge *BatchBridgeTransactor) EmergencyWithdraw(opts *bind.TransactOpts, token common.Address, recipient common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _BatchBridge.contract.Transact(opts, "emergencyWithdraw", token, recipient, amount)
+}
+
+// EmergencyWithdraw is a paid mutator transaction binding the contract method 0xe63ea408.
+//
+// Solidity: function emergencyWithdraw(address token, address recipient, uint256 amount) returns()
+func (_BatchBridge *BatchBridgeSession) EmergencyWithdraw(token common.Address, recipient common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _BatchBridge.Contract.EmergencyWithdraw(&_BatchBridge.TransactOpts, token, recipient, amount)
+}
+
+// EmergencyWithdraw is a paid mutator transaction binding the contract method 0xe63ea408.
+//
+// Solidity: function emergencyWithdraw(address token, address recipient, uint256 amount) returns()
+func (_BatchBridge *BatchBridgeTransactorSession) EmergencyWithdraw(token common.Address, recipient common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _BatchBridge.Contract.EmergencyWithdraw(&_BatchBridge.TransactOpts, token, recipient, amount)
+}
+
+// Pause is a paid mutator transaction binding the contract method 0x8456cb59.
+//
+// Solidity: function pause() returns()
+func (_BatchBridge *BatchBridgeTransactor) Pause(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _BatchBridge.contract.Transact(opts, "pause")
+}
+
+// Pause is a paid mutator transaction binding the contract method 0x8456cb59.
+//
+// Solidity: function pause() returns()
+func (_BatchBridge *BatchBridgeSession) Pause() (*types.Transaction, error) {
+	return _BatchBridge.Contract.Pause(&_BatchBridge.TransactOpts)
+}
+
+// Pause is a paid mutator transaction binding the contract method 0x8456cb59.
+//
+// Solidity: function pause() returns()
+func (_BatchBridge *BatchBridgeTransactorSession) Pause() (*types.Transaction, error) {
+	return _BatchBridge.Contract.Pause(&_BatchBridge.TransactOpts)
+}
+
+// RemoveAttester is a paid mutator transaction binding the contract method 0xcc78fef1.
+//
+// Solidity: function removeAttester(address attester) returns()
+func (_BatchBridge *BatchBridgeTransactor) RemoveAttester(opts *bind.TransactOpts, attester common.Address) (*types.Transaction, error) {
+	return _BatchBridge.contract.Transact(opts, "removeAttester", attester)
+}
+
+// RemoveAttester is a paid mutator transaction binding the contract method 0xcc78fef1.
+//
+// Solidity: function removeAttester(address attester) returns()
+func (_BatchBridge *BatchBridgeSession) RemoveAttester(attester common.Address) (*types.Transaction, error) {
+	return _BatchBridge.Contract.RemoveAttester(&_BatchBridge.TransactOpts, attester)
+}
+
+// RemoveAttester is a paid mutator transaction binding the contract method 0xcc78fef1.
+//
+// Solidity: function removeAttester(address attester) returns()
+func (_BatchBridge *BatchBridgeTransactorSession) RemoveAttester(attester common.Address) (*types.Transaction, error) {
+	return _BatchBridge.Contract.RemoveAttester(&_BatchBridge.TransactOpts, attester)
+}
+
+// RemoveOperator is a paid mutator transaction binding the contract method 0xac8a584a.
+//
+// Solidity: function removeOperator(address operator) returns()
+func (_BatchBridge *BatchBridgeTransactor) RemoveOperator(opts *bind.TransactOpts, operator common.Address) (*types.Transaction, error) {
+	return _BatchBridge.contract.Transact(opts, "removeOperator", operator)
+}
+
+// RemoveOperator is a paid mutator transaction binding the contract method 0xac8a584a.
+//
+// Solidity: function removeOperator(address operator) returns()
+func (_BatchBridge *BatchBridgeSession) RemoveOperator(operator common.Address) (*types.Transaction, error) {
+	return _BatchBridge.Contract.RemoveOperator(&_BatchBridge.TransactOpts, operator)
+}
+
+// RemoveOperator is a paid mutator transaction binding the contract method 0xac8a584a.
+//
+// Solidity: function removeOperator(address operator) returns()
+func (_BatchBridge *BatchBridgeTransactorSession) RemoveOperator(operator common.Address) (*types.Transaction, error) {
+	return _BatchBridge.Contract.RemoveOperator(&_BatchBridge.TransactOpts, operator)
+}
+
+// RemoveSupportedToken is a paid mutator transaction binding the contract method 0x76319190.
+//
+// Solidity: function removeSupportedToken(address token) returns()
+func (_BatchBridge *BatchBridgeTransactor) RemoveSupportedToken(opts *bind.TransactOpts, token common.Address) (*types.Transaction, error) {
+	return _BatchBridge.contract.Transact(opts, "removeSupportedToken", token)
+}
+
+// RemoveSupportedToken is a paid mutator transaction binding the contract method 0x76319190.
+//
+// Solidity: function removeSupportedToken(address token) returns()
+func (_BatchBridge *BatchBridgeSession) RemoveSupportedToken(token common.Address) (*types.Transaction, error) {
+	return _BatchBridge.Contract.RemoveSupportedToken(&_BatchBridge.TransactOpts, token)
+}
+
+// RemoveSupportedToken is a paid mutator transaction binding the contract method 0x76319190.
+//
+// Solidity: function removeSupportedToken(address token) returns()
+func (_BatchBridge *BatchBridgeTransactorSession) RemoveSupportedToken(token common.Address) (*types.Transaction, error) {
+	return _BatchBridge.Contract.RemoveSupportedToken(&_BatchBridge.TransactOpts, token)
+}
+
+// RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
+//
+// Solidity: function renounceOwnership() returns()
+func (_BatchBridge *BatchBridgeTransactor) RenounceOwnership(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _BatchBridge.contract.Transact(opts, "renounceOwnership")
+}
+
+// RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
+//
+// Solidity: function renounceOwnership() returns()
+func (_BatchBridge *BatchBridgeSession) RenounceOwnership() (*types.Transaction, error) {
+	return _BatchBridge.Contract.RenounceOwnership(&_BatchBridge.TransactOpts)
+}
+
+// RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
+//
+// Solidity: function renounceOwnership() returns()
+func (_BatchBridge *BatchBridgeTransactorSession) RenounceOwnership() (*types.Transaction, error) {
+	return _BatchBridge.Contract.RenounceOwnership(&_BatchBridge.TransactOpts)
+}
+
+// SetAttesterThreshold is a paid mutator transaction binding the contract method 0xae18ba2f.
+//
+// Solidity: function setAttesterThreshold(uint256 threshold) returns()
+func (_BatchBridge *BatchBridgeTransactor) SetAttesterThreshold(opts *bind.TransactOpts, threshold *big.Int) (*types.Transaction, error) {
+	return _BatchBridge.contract.Transact(opts, "setAttesterThreshold", threshold)
+}
+
+// SetAttesterThreshold is a paid mutator transaction binding the contract method 0xae18ba2f.
+//
+// Solidity: function setAttesterThreshold(uint256 threshold) returns()
+func (_BatchBridge *BatchBridgeSession) SetAttesterThreshold(threshold *big.Int) (*types.Transaction, error) {
+	return _BatchBridge.Contract.SetAttesterThreshold(&_BatchBridge.TransactOpts, threshold)
+}
+
+// SetAttesterThreshold is a paid mutator transaction binding the contract method 0xae18ba2f.
+//
+// Solidity: function setAttesterThreshold(uint256 threshold) returns()
+func (_BatchBridge *BatchBridgeTransactorSession) SetAttesterThreshold(threshold *big.Int) (*types.Transaction, error) {
+	return _BatchBridge.Contract.SetAttesterThreshold(&_BatchBridge.TransactOpts, threshold)
+}
+
+// SetWrappedNativeToken is a paid mutator transaction binding the contract method 0xb19e7728.
+//
+// Solidity: function setWrappedNativeToken(uint256 sourceChainId, address token) returns()
+func (_BatchBridge *BatchBridgeTransactor) SetWrappedNativeToken(opts *bind.TransactOpts, sourceChainId *big.Int, token common.Address) (*types.Transaction, error) {
+	return _BatchBridge.contract.Transact(opts, "setWrappedNativeToken", sourceChainId, token)
+}
+
+// SetWrappedNativeToken is a paid mutator transaction binding the contract method 0xb19e7728.
+//
+// Solidity: function setWrappedNativeToken(uint256 sourceChainId, address token) returns()
+func (_BatchBridge *BatchBridgeSession) SetWrappedNativeToken(sourceChainId *big.Int, token common.Address) (*types.Transaction, error) {
+	return _BatchBridge.Contract.SetWrappedNativeToken(&_BatchBridge.TransactOpts, sourceChainId, token)
+}
+
+// SetWrappedNativeToken is a paid mutator transaction binding the contract method 0xb19e7728.
+//
+// Solidity: function setWrappedNativeToken(uint256 sourceChainId, address token) returns()
+func (_BatchBridge *BatchBridgeTransactorSession) SetWrappedNativeToken(sourceChainId *big.Int, token common.Address) (*types.Transaction, error) {
+	return _BatchBridge.Contract.SetWrappedNativeToken(&_BatchBridge.TransactOpts, sourceChainId, token)
+}
+
+// TransferAdmin is a paid mutator transaction binding the contract method 0x75829def.
+//
+// Solidity: function transferAdmin(address newAdmin) returns()
+func (_BatchBridge *BatchBridgeTransactor) TransferAdmin(opts *bind.TransactOpts, newAdmin common.Address) (*types.Transaction, error) {
+	return _BatchBridge.contract.Transact(opts, "transferAdmin", newAdmin)
+}
+
+// TransferAdmin is a paid mutator transaction binding the contract method 0x75829def.
+//
+// Solidity: function transferAdmin(address newAdmin) returns()
+func (_BatchBridge *BatchBridgeSession) TransferAdmin(newAdmin common.Address) (*types.Transaction, error) {
+	return _BatchBridge.Contract.TransferAdmin(&_BatchBridge.TransactOpts, newAdmin)
+}
+
+// TransferAdmin is a paid mutator transaction binding the contract method 0x75829def.
+//
+// Solidity: function transferAdmin(address newAdmin) returns()
+func (_BatchBridge *BatchBridgeTransactorSession) TransferAdmin(newAdmin common.Address) (*types.Transaction, error) {
+	return _BatchBridge.Contract.TransferAdmin(&_BatchBridge.TransactOpts, newAdmin)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_BatchBridge *BatchBridgeTransactor) TransferOwnership(opts *bind.TransactOpts, newOwner common.Address) (*types.Transaction, error) {
+	return _BatchBridge.contract.Transact(opts, "transferOwnership", newOwner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_BatchBridge *BatchBridgeSession) TransferOwnership(newOwner common.Address) (*types.Transaction, error) {
+	return _BatchBridge.Contract.TransferOwnership(&_BatchBridge.TransactOpts, newOwner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_BatchBridge *BatchBridgeTransactorSession) TransferOwnership(newOwner common.Address) (*types.Transaction, error) {
+	return _BatchBridge.Contract.TransferOwnership(&_BatchBridge.TransactOpts, newOwner)
+}
+
+// Unpause is a paid mutator transaction binding the contract method 0x3f4ba83a.
+//
+// Solidity: function unpause() returns()
+func (_BatchBridge *BatchBridgeTransactor) Unpause(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _BatchBridge.contract.Transact(opts, "unpause")
+}
+
+// Unpause is a paid mutator transaction binding the contract method 0x3f4ba83a.
+//
+// Solidity: function unpause() returns()
+func (_BatchBridge *BatchBridgeSession) Unpause() (*types.Transaction, error) {
+	return _BatchBridge.Contract.Unpause(&_BatchBridge.TransactOpts)
+}
+
+// Unpause is a paid mutator transaction binding the contract method 0x3f4ba83a.
+//
+// Solidity: function unpause() returns()
+func (_BatchBridge *BatchBridgeTransactorSession) Unpause() (*types.Transaction, error) {
+	return _BatchBridge.Contract.Unpause(&_BatchBridge.TransactOpts)
+}
+
+// Receive is a paid mutator transaction binding the contract receive function.
+//
+// Solidity: receive() payable returns()
+func (_BatchBridge *BatchBridgeTransactor) Receive(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _BatchBridge.contract.RawTransact(opts, nil) // calldata is disallowed for receive function
+}
+
+// Receive is a paid mutator transaction binding the contract receive function.
+//
+// Solidity: receive() payable returns()
+func (_BatchBridge *BatchBridgeSession) Receive() (*types.Transaction, error) {
+	return _BatchBridge.Contract.Receive(&_BatchBridge.TransactOpts)
+}
+
+// Receive is a paid mutator transaction binding the contract receive function.
+//
+// Solidity: receive() payable returns()
+func (_BatchBridge *BatchBridgeTransactorSession) Receive() (*types.Transaction, error) {
+	return _BatchBridge.Contract.Receive(&_BatchBridge.TransactOpts)
+}
+
+// BatchBridgeAdminTransferredIterator is returned from FilterAdminTransferred and is used to iterate over the raw logs and unpacked data for AdminTransferred events raised by the BatchBridge contract.
+type BatchBridgeAdminTransferredIterator struct {
+	Event *BatchBridgeAdminTransferred // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *BatchBridgeAdminTransferredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(BatchBridgeAdminTransferred)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(BatchBridgeAdminTransferred)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *BatchBridgeAdminTransferredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *BatchBridgeAdminTransferredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// BatchBridgeAdminTransferred represents a AdminTransferred event raised by the BatchBridge contract.
+type BatchBridgeAdminTransferred struct {
+	PreviousAdmin common.Address
+	NewAdmin      common.Address
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterAdminTransferred is a free log retrieval operation binding the contract event 0xf8ccb027dfcd135e000e9d45e6cc2d662578a8825d4c45b5e32e0adf67e79ec6.
+//
+// Solidity: event AdminTransferred(address indexed previousAdmin, address indexed newAdmin)
+func (_BatchBridge *BatchBridgeFilterer) FilterAdminTransferred(opts *bind.FilterOpts, previousAdmin []common.Address, newAdmin []common.Address) (*BatchBridgeAdminTransferredIterator, error) {
+
+	var previousAdminRule []interface{}
+	for _, previousAdminItem := range previousAdmin {
+		previousAdminRule = append(previousAdminRule, previousAdminItem)
+	}
+	var newAdminRule []interface{}
+	for _, newAdminItem := range newAdmin {
+		newAdminRule = append(newAdminRule, newAdminItem)
+	}
+
+	logs, sub, err := _BatchBridge.contract.FilterLogs(opts, "AdminTransferred", previousAdminRule, newAdminRule)
+	if err != nil {
+		return nil, err
+	}
+	return &BatchBridgeAdminTransferredIterator{contract: _BatchBridge.contract, event: "AdminTransferred", logs: logs, sub: sub}, nil
+}
+
+// WatchAdminTransferred is a free log subscription operation binding the contract event 0xf8ccb027dfcd135e000e9d45e6cc2d662578a8825d4c45b5e32e0adf67e79ec6.
+//
+// Solidity: event AdminTransferred(address indexed previousAdmin, address indexed newAdmin)
+func (_BatchBridge *BatchBridgeFilterer) WatchAdminTransferred(opts *bind.WatchOpts, sink chan<- *BatchBridgeAdminTransferred, previousAdmin []common.Address, newAdmin []common.Address) (event.Subscription, error) {
+
+	var previousAdminRule []interface{}
+	for _, previousAdminItem := range previousAdmin {
+		previousAdminRule = append(previousAdminRule, previousAdminItem)
+	}
+	var newAdminRule []interface{}
+	for _, newAdminItem := range newAdmin {
+		newAdminRule = append(newAdminRule, newAdminItem)
+	}
+
+	logs, sub, err := _BatchBridge.contract.WatchLogs(opts, "AdminTransferred", previousAdminRule, newAdminRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(BatchBridgeAdminTransferred)
+				if err := _BatchBridge.contract.UnpackLog(event, "AdminTransferred", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseAdminTransferred is a log parse operation binding the contract event 0xf8ccb027dfcd135e000e9d45e6cc2d662578a8825d4c45b5e32e0adf67e79ec6.
+//
+// Solidity: event AdminTransferred(address indexed previousAdmin, address indexed newAdmin)
+func (_BatchBridge *BatchBridgeFilterer) ParseAdminTransferred(log types.Log) (*BatchBridgeAdminTransferred, error) {
+	event := new(BatchBridgeAdminTransferred)
+	if err := _BatchBridge.contract.UnpackLog(event, "AdminTransferred", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// BatchBridgeAttesterAddedIterator is returned from FilterAttesterAdded and is used to iterate over the raw logs and unpacked data for AttesterAdded events raised by the BatchBridge contract.
+type BatchBridgeAttesterAddedIterator struct {
+	Event *BatchBridgeAttesterAdded // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *BatchBridgeAttesterAddedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(BatchBridgeAttesterAdded)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(BatchBridgeAttesterAdded)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *BatchBridgeAttesterAddedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *BatchBridgeAttesterAddedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// BatchBridgeAttesterAdded represents a AttesterAdded event raised by the BatchBridge contract.
+type BatchBridgeAttesterAdded struct {
+	Attester common.Address
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterAttesterAdded is a free log retrieval operation binding the contract event 0x6ba7a3f25b8b53ecc6d1367a381fde6e7bd6ae793acb6b3f4726c425fc0caec7.
+//
+// Solidity: event AttesterAdded(address indexed attester)
+func (_BatchBridge *BatchBridgeFilterer) FilterAttesterAdded(opts *bind.FilterOpts, attester []common.Address) (*BatchBridgeAttesterAddedIterator, error) {
+
+	var attesterRule []interface{}
+	for _, attesterItem := range attester {
+		attesterRule = append(attesterRule, attesterItem)
+	}
+
+	logs, sub, err := _BatchBridge.contract.FilterLogs(opts, "AttesterAdded", attesterRule)
+	if err != nil {
+		return nil, err
+	}
+	return &BatchBridgeAttesterAddedIterator{contract: _BatchBridge.contract, event: "AttesterAdded", logs: logs, sub: sub}, nil
+}
+
+// WatchAttesterAdded is a free log subscription operation binding the contract event 0x6ba7a3f25b8b53ecc6d1367a381fde6e7bd6ae793acb6b3f4726c425fc0caec7.
+//
+// Solidity: event AttesterAdded(address indexed attester)
+func (_BatchBridge *BatchBridgeFilterer) WatchAttesterAdded(opts *bind.WatchOpts, sink chan<- *BatchBridgeAttesterAdded, attester []common.Address) (event.Subscription, error) {
+
+	var attesterRule []interface{}
+	for _, attesterItem := range attester {
+		attesterRule = append(attesterRule, attesterItem)
+	}
+
+	logs, sub, err := _BatchBridge.contract.WatchLogs(opts, "AttesterAdded", attesterRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(BatchBridgeAttesterAdded)
+				if err := _BatchBridge.contract.UnpackLog(event, "AttesterAdded", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseAttesterAdded is a log parse operation binding the contract event 0x6ba7a3f25b8b53ecc6d1367a381fde6e7bd6ae793acb6b3f4726c425fc0caec7.
+//
+// Solidity: event AttesterAdded(address indexed attester)
+func (_BatchBridge *BatchBridgeFilterer) ParseAttesterAdded(log types.Log) (*BatchBridgeAttesterAdded, error) {
+	event := new(BatchBridgeAttesterAdded)
+	if err := _BatchBridge.contract.UnpackLog(event, "AttesterAdded", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// BatchBridgeAttesterRemovedIterator is returned from FilterAttesterRemoved and is used to iterate over the raw logs and unpacked data for AttesterRemoved events raised by the BatchBridge contract.
+type BatchBridgeAttesterRemovedIterator struct {
+	Event *BatchBridgeAttesterRemoved // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *BatchBridgeAttesterRemovedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(BatchBridgeAttesterRemoved)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(BatchBridgeAttesterRemoved)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *BatchBridgeAttesterRemovedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *BatchBridgeAttesterRemovedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// BatchBridgeAttesterRemoved represents a AttesterRemoved event raised by the BatchBridge contract.
+type BatchBridgeAttesterRemoved struct {
+	Attester common.Address
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterAttesterRemoved is a free log retrieval operation binding the contract event 0x368846ed588a2fb12b4fabffde520ad537df26ea80ad789b60afc37c5cc7fe6b.
+//
+// Solidity: event AttesterRemoved(address indexed attester)
+func (_BatchBridge *BatchBridgeFilterer) FilterAttesterRemoved(opts *bind.FilterOpts, attester []common.Address) (*BatchBridgeAttesterRemovedIterator, error) {
+
+	var attesterRule []interface{}
+	for _, attesterItem := range attester {
+		attesterRule = append(attesterRule, attesterItem)
+	}
+
+	logs, sub, err := _BatchBridge.contract.FilterLogs(opts, "AttesterRemoved", attesterRule)
+	if err != nil {
+		return nil, err
+	}
+	return &BatchBridgeAttesterRemovedIterator{contract: _BatchBridge.contract, event: "AttesterRemoved", logs: logs, sub: sub}, nil
+}
+
+// WatchAttesterRemoved is a free log subscription operation binding the contract event 0x368846ed588a2fb12b4fabffde520ad537df26ea80ad789b60afc37c5cc7fe6b.
+//
+// Solidity: event AttesterRemoved(address indexed attester)
+func (_BatchBridge *BatchBridgeFilterer) WatchAttesterRemoved(opts *bind.WatchOpts, sink chan<- *BatchBridgeAttesterRemoved, attester []common.Address) (event.Subscription, error) {
+
+	var attesterRule []interface{}
+	for _, attesterItem := range attester {
+		attesterRule = append(attesterRule, attesterItem)
+	}
+
+	logs, sub, err := _BatchBridge.contract.WatchLogs(opts, "AttesterRemoved", attesterRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(BatchBridgeAttesterRemoved)
+				if err := _BatchBridge.contract.UnpackLog(event, "AttesterRemoved", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseAttesterRemoved is a log parse operation binding the contract event 0x368846ed588a2fb12b4fabffde520ad537df26ea80ad789b60afc37c5cc7fe6b.
+//
+// Solidity: event AttesterRemoved(address indexed attester)
+func (_BatchBridge *BatchBridgeFilterer) ParseAttesterRemoved(log types.Log) (*BatchBridgeAttesterRemoved, error) {
+	event := new(BatchBridgeAttesterRemoved)
+	if err := _BatchBridge.contract.UnpackLog(event, "AttesterRemoved", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// BatchBridgeAttesterThresholdChangedIterator is returned from FilterAttesterThresholdChanged and is used to iterate over the raw logs and unpacked data for AttesterThresholdChanged events raised by the BatchBridge contract.
+type BatchBridgeAttesterThresholdChangedIterator struct {
+	Event *BatchBridgeAttesterThresholdChanged // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *BatchBridgeAttesterThresholdChangedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(BatchBridgeAttesterThresholdChanged)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(BatchBridgeAttesterThresholdChanged)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *BatchBridgeAttesterThresholdChangedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *BatchBridgeAttesterThresholdChangedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// BatchBridgeAttesterThresholdChanged represents a AttesterThresholdChanged event raised by the BatchBridge contract.
+type BatchBridgeAttesterThresholdChanged struct {
+	Threshold *big.Int
+	Raw       types.Log // Blockchain specific contextual infos
+}
+
+// FilterAttesterThresholdChanged is a free log retrieval operation binding the contract event 0xb80e2882b04d3a1aafac893a86209c8091982ad85b5c4accc973ec9d5ca47558.
+//
+// Solidity: event AttesterThresholdChanged(uint256 threshold)
+func (_BatchBridge *BatchBridgeFilterer) FilterAttesterThresholdChanged(opts *bind.FilterOpts) (*BatchBridgeAttesterThresholdChangedIterator, error) {
+
+	logs, sub, err := _BatchBridge.contract.FilterLogs(opts, "AttesterThresholdChanged")
+	if err != nil {
+		return nil, err
+	}
+	return &BatchBridgeAttesterThresholdChangedIterator{contract: _BatchBridge.contract, event: "AttesterThresholdChanged", logs: logs, sub: sub}, nil
+}
+
+// WatchAttesterThresholdChanged is a free log subscription operation binding the contract event 0xb80e2882b04d3a1aafac893a86209c8091982ad85b5c4accc973ec9d5ca47558.
+//
+// Solidity: event AttesterThresholdChanged(uint256 threshold)
+func (_BatchBridge *BatchBridgeFilterer) WatchAttesterThresholdChanged(opts *bind.WatchOpts, sink chan<- *BatchBridgeAttesterThresholdChanged) (event.Subscription, error) {
+
+	logs, sub, err := _BatchBridge.contract.WatchLogs(opts, "AttesterThresholdChanged")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(BatchBridgeAttesterThresholdChanged)
+				if err := _BatchBridge.contract.UnpackLog(event, "AttesterThresholdChanged", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseAttesterThresholdChanged is a log parse operation binding the contract event 0xb80e2882b04d3a1aafac893a86209c8091982ad85b5c4accc973ec9d5ca47558.
+//
+// Solidity: event AttesterThresholdChanged(uint256 threshold)
+func (_BatchBridge *BatchBridgeFilterer) ParseAttesterThresholdChanged(log types.Log) (*BatchBridgeAttesterThresholdChanged, error) {
+	event := new(BatchBridgeAttesterThresholdChanged)
+	if err := _BatchBridge.contract.UnpackLog(event, "AttesterThresholdChanged", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// BatchBridgeBatchSwapCompletedIterator is returned from FilterBatchSwapCompleted and is used to iterate over the raw logs and unpacked data for BatchSwapCompleted events raised by the BatchBridge contract.
+type BatchBridgeBatchSwapCompletedIterator struct {
+	Event *BatchBridgeBatchSwapCompleted // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *BatchBridgeBatchSwapCompletedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(BatchBridgeBatchSwapCompleted)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(BatchBridgeBatchSwapCompleted)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *BatchBridgeBatchSwapCompletedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *BatchBridgeBatchSwapCompletedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// BatchBridgeBatchSwapCompleted represents a BatchSwapCompleted event raised by the BatchBridge contract.
+type BatchBridgeBatchSwapCompleted struct {
+	BatchId       [32]byte
+	SourceChainId *big.Int
+	TargetChainId *big.Int
+	Timestamp     *big.Int
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterBatchSwapCompleted is a free log retrieval operation binding the contract event 0x18c566205ba366e24a9c85bd24eb5898c5a92a03afecf70e43368b802d0976e9.
+//
+// Solidity: event BatchSwapCompleted(bytes32 indexed batchId, uint256 indexed sourceChainId, uint256 targetChainId, uint256 timestamp)
+func (_BatchBridge *BatchBridgeFilterer) FilterBatchSwapCompleted(opts *bind.FilterOpts, batchId [][32]byte, sourceChainId []*big.Int) (*BatchBridgeBatchSwapCompletedIterator, error) {
+
+	var batchIdRule []interface{}
+	for _, batchIdItem := range batchId {
+		batchIdRule = append(batchIdRule, batchIdItem)
+	}
+	var sourceChainIdRule []interface{}
+	for _, sourceChainIdItem := range sourceChainId {
+		sourceChainIdRule = append(sourceChainIdRule, sourceChainIdItem)
+	}
+
+	logs, sub, err := _BatchBridge.contract.FilterLogs(opts, "BatchSwapCompleted", batchIdRule, sourceChainIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &BatchBridgeBatchSwapCompletedIterator{contract: _BatchBridge.contract, event: "BatchSwapCompleted", logs: logs, sub: sub}, nil
+}
+
+// WatchBatchSwapCompleted is a free log subscription operation binding the contract event 0x18c566205ba366e24a9c85bd24eb5898c5a92a03afecf70e43368b802d0976e9.
+//
+// Solidity: event BatchSwapCompleted(bytes32 indexed batchId, uint256 indexed sourceChainId, uint256 targetChainId, uint256 timestamp)
+func (_BatchBridge *BatchBridgeFilterer) WatchBatchSwapCompleted(opts *bind.WatchOpts, sink chan<- *BatchBridgeBatchSwapCompleted, batchId [][32]byte, sourceChainId []*big.Int) (event.Subscription, error) {
+
+	var batchIdRule []interface{}
+	for _, batchIdItem := range batchId {
+		batchIdRule = append(batchIdRule, batchIdItem)
+	}
+	var sourceChainIdRule []interface{}
+	for _, sourceChainIdItem := range sourceChainId {
+		sourceChainIdRule = append(sourceChainIdRule, sourceChainIdItem)
+	}
+
+	logs, sub, err := _BatchBridge.contract.WatchLogs(opts, "BatchSwapCompleted", batchIdRule, sourceChainIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(BatchBridgeBatchSwapCompleted)
+				if err := _BatchBridge.contract.UnpackLog(event, "BatchSwapCompleted", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseBatchSwapCompleted is a log parse operation binding the contract event 0x18c566205ba366e24a9c85bd24eb5898c5a92a03afecf70e43368b802d0976e9.
+//
+// Solidity: event BatchSwapCompleted(bytes32 indexed batchId, uint256 indexed sourceChainId, uint256 targetChainId, uint256 timestamp)
+func (_BatchBridge *BatchBridgeFilterer) ParseBatchSwapCompleted(log types.Log) (*BatchBridgeBatchSwapCompleted, error) {
+	event := new(BatchBridgeBatchSwapCompleted)
+	if err := _BatchBridge.contract.UnpackLog(event, "BatchSwapCompleted", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// BatchBridgeBatchSwapInitiatedIterator is returned from FilterBatchSwapInitiated and is used to iterate over the raw logs and unpacked data for BatchSwapInitiated events raised by the BatchBridge contract.
+type BatchBridgeBatchSwapInitiatedIterator struct {
+	Event *BatchBridgeBatchSwapInitiated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *BatchBridgeBatchSwapInitiatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(BatchBridgeBatchSwapInitiated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(BatchBridgeBatchSwapInitiated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *BatchBridgeBatchSwapInitiatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *BatchBridgeBatchSwapInitiatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// BatchBridgeBatchSwapInitiated represents a BatchSwapInitiated event raised by the BatchBridge contract.
+type BatchBridgeBatchSwapInitiated struct {
+	BatchId   [32]byte
+	Requests  []BatchBridgeSwapRequest
+	Timestamp *big.Int
+	Raw       types.Log // Blockchain specific contextual infos
+}
+
+// FilterBatchSwapInitiated is a free log retrieval operation binding the contract event 0xc7c8c8ef2a7ef6e758132a316f95d2a22400e408a2898568490fc579e93d220d.
+//
+// Solidity: event BatchSwapInitiated(bytes32 indexed batchId, (address,uint256,address,uint256)[] requests, uint256 timestamp)
+func (_BatchBridge *BatchBridgeFilterer) FilterBatchSwapInitiated(opts *bind.FilterOpts, batchId [][32]byte) (*BatchBridgeBatchSwapInitiatedIterator, error) {
+
+	var batchIdRule []interface{}
+	for _, batchIdItem := range batchId {
+		batchIdRule = append(batchIdRule, batchIdItem)
+	}
+
+	logs, sub, err := _BatchBridge.contract.FilterLogs(opts, "BatchSwapInitiated", batchIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &BatchBridgeBatchSwapInitiatedIterator{contract: _BatchBridge.contract, event: "BatchSwapInitiated", logs: logs, sub: sub}, nil
+}
+
+// WatchBatchSwapInitiated is a free log subscription operation binding the contract event 0xc7c8c8ef2a7ef6e758132a316f95d2a22400e408a2898568490fc579e93d220d.
+//
+// Solidity: event BatchSwapInitiated(bytes32 indexed batchId, (address,uint256,address,uint256)[] requests, uint256 timestamp)
+func (_BatchBridge *BatchBridgeFilterer) WatchBatchSwapInitiated(opts *bind.WatchOpts, sink chan<- *BatchBridgeBatchSwapInitiated, batchId [][32]byte) (event.Subscription, error) {
+
+	var batchIdRule []interface{}
+	for _, batchIdItem := range batchId {
+		batchIdRule = append(batchIdRule, batchIdItem)
+	}
+
+	logs, sub, err := _BatchBridge.contract.WatchLogs(opts, "BatchSwapInitiated", batchIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(BatchBridgeBatchSwapInitiated)
+				if err := _BatchBridge.contract.UnpackLog(event, "BatchSwapInitiated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseBatchSwapInitiated is a log parse operation binding the contract event 0xc7c8c8ef2a7ef6e758132a316f95d2a22400e408a2898568490fc579e93d220d.
+//
+// Solidity: event BatchSwapInitiated(bytes32 indexed batchId, (address,uint256,address,uint256)[] requests, uint256 timestamp)
+func (_BatchBridge *BatchBridgeFilterer) ParseBatchSwapInitiated(log types.Log) (*BatchBridgeBatchSwapInitiated, error) {
+	event := new(BatchBridgeBatchSwapInitiated)
+	if err := _BatchBridge.contract.UnpackLog(event, "BatchSwapInitiated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// BatchBridgeEIP712DomainChangedIterator is returned from FilterEIP712DomainChanged and is used to iterate over the raw logs and unpacked data for EIP712DomainChanged events raised by the BatchBridge contract.
+type BatchBridgeEIP712DomainChangedIterator struct {
+	Event *BatchBridgeEIP712DomainChanged // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *BatchBridgeEIP712DomainChangedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(BatchBridgeEIP712DomainChanged)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(BatchBridgeEIP712DomainChanged)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *BatchBridgeEIP712DomainChangedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *BatchBridgeEIP712DomainChangedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// BatchBridgeEIP712DomainChanged represents a EIP712DomainChanged event raised by the BatchBridge contract.
+type BatchBridgeEIP712DomainChanged struct {
+	Raw types.Log // Blockchain specific contextual infos
+}
+
+// FilterEIP712DomainChanged is a free log retrieval operation binding the contract event 0x0a6387c9ea3628b88a633bb4f3b151770f70085117a15f9bf3787cda53f13d31.
+//
+// Solidity: event EIP712DomainChanged()
+func (_BatchBridge *BatchBridgeFilterer) FilterEIP712DomainChanged(opts *bind.FilterOpts) (*BatchBridgeEIP712DomainChangedIterator, error) {
+
+	logs, sub, err := _BatchBridge.contract.FilterLogs(opts, "EIP712DomainChanged")
+	if err != nil {
+		return nil, err
+	}
+	return &BatchBridgeEIP712DomainChangedIterator{contract: _BatchBridge.contract, event: "EIP712DomainChanged", logs: logs, sub: sub}, nil
+}
+
+// WatchEIP712DomainChanged is a free log subscription operation binding the contract event 0x0a6387c9ea3628b88a633bb4f3b151770f70085117a15f9bf3787cda53f13d31.
+//
+// Solidity: event EIP712DomainChanged()
+func (_BatchBridge *BatchBridgeFilterer) WatchEIP712DomainChanged(opts *bind.WatchOpts, sink chan<- *BatchBridgeEIP712DomainChanged) (event.Subscription, error) {
+
+	logs, sub, err := _BatchBridge.contract.WatchLogs(opts, "EIP712DomainChanged")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(BatchBridgeEIP712DomainChanged)
+				if err := _BatchBridge.contract.UnpackLog(event, "EIP712DomainChanged", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseEIP712DomainChanged is a log parse operation binding the contract event 0x0a6387c9ea3628b88a633bb4f3b151770f70085117a15f9bf3787cda53f13d31.
+//
+// Solidity: event EIP712DomainChanged()
+func (_BatchBridge *BatchBridgeFilterer) ParseEIP712DomainChanged(log types.Log) (*BatchBridgeEIP712DomainChanged, error) {
+	event := new(BatchBridgeEIP712DomainChanged)
+	if err := _BatchBridge.contract.UnpackLog(event, "EIP712DomainChanged", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// BatchBridgeOperatorAddedIterator is returned from FilterOperatorAdded and is used to iterate over the raw logs and unpacked data for OperatorAdded events raised by the BatchBridge contract.
+type BatchBridgeOperatorAddedIterator struct {
+	Event *BatchBridgeOperatorAdded // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *BatchBridgeOperatorAddedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(BatchBridgeOperatorAdded)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(BatchBridgeOperatorAdded)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *BatchBridgeOperatorAddedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *BatchBridgeOperatorAddedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// BatchBridgeOperatorAdded represents a OperatorAdded event raised by the BatchBridge contract.
+type BatchBridgeOperatorAdded struct {
+	Operator common.Address
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterOperatorAdded is a free log retrieval operation binding the contract event 0xac6fa858e9350a46cec16539926e0fde25b7629f84b5a72bffaae4df888ae86d.
+//
+// Solidity: event OperatorAdded(address indexed operator)
+func (_BatchBridge *BatchBridgeFilterer) FilterOperatorAdded(opts *bind.FilterOpts, operator []common.Address) (*BatchBridgeOperatorAddedIterator, error) {
+
+	var operatorRule []interface{}
+	for _, operatorItem := range operator {
+		operatorRule = append(operatorRule, operatorItem)
+	}
+
+	logs, sub, err := _BatchBridge.contract.FilterLogs(opts, "OperatorAdded", operatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return &BatchBridgeOperatorAddedIterator{contract: _BatchBridge.contract, event: "OperatorAdded", logs: logs, sub: sub}, nil
+}
+
+// WatchOperatorAdded is a free log subscription operation binding the contract event 0xac6fa858e9350a46cec16539926e0fde25b7629f84b5a72bffaae4df888ae86d.
+//
+// Solidity: event OperatorAdded(address indexed operator)
+func (_BatchBridge *BatchBridgeFilterer) WatchOperatorAdded(opts *bind.WatchOpts, sink chan<- *BatchBridgeOperatorAdded, operator []common.Address) (event.Subscription, error) {
+
+	var operatorRule []interface{}
+	for _, operatorItem := range operator {
+		operatorRule = append(operatorRule, operatorItem)
+	}
+
+	logs, sub, err := _BatchBridge.contract.WatchLogs(opts, "OperatorAdded", operatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(BatchBridgeOperatorAdded)
+				if err := _BatchBridge.contract.UnpackLog(event, "OperatorAdded", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseOperatorAdded is a log parse operation binding the contract event 0xac6fa858e9350a46cec16539926e0fde25b7629f84b5a72bffaae4df888ae86d.
+//
+// Solidity: event OperatorAdded(address indexed operator)
+func (_BatchBridge *BatchBridgeFilterer) ParseOperatorAdded(log types.Log) (*BatchBridgeOperatorAdded, error) {
+	event := new(BatchBridgeOperatorAdded)
+	if err := _BatchBridge.contract.UnpackLog(event, "OperatorAdded", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// BatchBridgeOperatorRemovedIterator is returned from FilterOperatorRemoved and is used to iterate over the raw logs and unpacked data for OperatorRemoved events raised by the BatchBridge contract.
+type BatchBridgeOperatorRemovedIterator struct {
+	Event *BatchBridgeOperatorRemoved // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *BatchBridgeOperatorRemovedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(BatchBridgeOperatorRemoved)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(BatchBridgeOperatorRemoved)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *BatchBridgeOperatorRemovedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *BatchBridgeOperatorRemovedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// BatchBridgeOperatorRemoved represents a OperatorRemoved event raised by the BatchBridge contract.
+type BatchBridgeOperatorRemoved struct {
+	Operator common.Address
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterOperatorRemoved is a free log retrieval operation binding the contract event 0x80c0b871b97b595b16a7741c1b06fed0c6f6f558639f18ccbce50724325dc40d.
+//
+// Solidity: event OperatorRemoved(address indexed operator)
+func (_BatchBridge *BatchBridgeFilterer) FilterOperatorRemoved(opts *bind.FilterOpts, operator []common.Address) (*BatchBridgeOperatorRemovedIterator, error) {
+
+	var operatorRule []interface{}
+	for _, operatorItem := range operator {
+		operatorRule = append(operatorRule, operatorItem)
+	}
+
+	logs, sub, err := _BatchBridge.contract.FilterLogs(opts, "OperatorRemoved", operatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return &BatchBridgeOperatorRemovedIterator{contract: _BatchBridge.contract, event: "OperatorRemoved", logs: logs, sub: sub}, nil
+}
+
+// WatchOperatorRemoved is a free log subscription operation binding the contract event 0x80c0b871b97b595b16a7741c1b06fed0c6f6f558639f18ccbce50724325dc40d.
+//
+// Solidity: event OperatorRemoved(address indexed operator)
+func (_BatchBridge *BatchBridgeFilterer) WatchOperatorRemoved(opts *bind.WatchOpts, sink chan<- *BatchBridgeOperatorRemoved, operator []common.Address) (event.Subscription, error) {
+
+	var operatorRule []interface{}
+	for _, operatorItem := range operator {
+		operatorRule = append(operatorRule, operatorItem)
+	}
+
+	logs, sub, err := _BatchBridge.contract.WatchLogs(opts, "OperatorRemoved", operatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(BatchBridgeOperatorRemoved)
+				if err := _BatchBridge.contract.UnpackLog(event, "OperatorRemoved", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseOperatorRemoved is a log parse operation binding the contract event 0x80c0b871b97b595b16a7741c1b06fed0c6f6f558639f18ccbce50724325dc40d.
+//
+// Solidity: event OperatorRemoved(address indexed operator)
+func (_BatchBridge *BatchBridgeFilterer) ParseOperatorRemoved(log types.Log) (*BatchBridgeOperatorRemoved, error) {
+	event := new(BatchBridgeOperatorRemoved)
+	if err := _BatchBridge.contract.UnpackLog(event, "OperatorRemoved", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// BatchBridgeOwnershipTransferredIterator is returned from FilterOwnershipTransferred and is used to iterate over the raw logs and unpacked data for OwnershipTransferred events raised by the BatchBridge contract.
+type BatchBridgeOwnershipTransferredIterator struct {
+	Event *BatchBridgeOwnershipTransferred // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *BatchBridgeOwnershipTransferredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(BatchBridgeOwnershipTransferred)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(BatchBridgeOwnershipTransferred)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *BatchBridgeOwnershipTransferredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *BatchBridgeOwnershipTransferredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// BatchBridgeOwnershipTransferred represents a OwnershipTransferred event raised by the BatchBridge contract.
+type BatchBridgeOwnershipTransferred struct {
+	PreviousOwner common.Address
+	NewOwner      common.Address
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterOwnershipTransferred is a free log retrieval operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_BatchBridge *BatchBridgeFilterer) FilterOwnershipTransferred(opts *bind.FilterOpts, previousOwner []common.Address, newOwner []common.Address) (*BatchBridgeOwnershipTransferredIterator, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _BatchBridge.contract.FilterLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &BatchBridgeOwnershipTransferredIterator{contract: _BatchBridge.contract, event: "OwnershipTransferred", logs: logs, sub: sub}, nil
+}
+
+// WatchOwnershipTransferred is a free log subscription operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_BatchBridge *BatchBridgeFilterer) WatchOwnershipTransferred(opts *bind.WatchOpts, sink chan<- *BatchBridgeOwnershipTransferred, previousOwner []common.Address, newOwner []common.Address) (event.Subscription, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _BatchBridge.contract.WatchLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(BatchBridgeOwnershipTransferred)
+				if err := _BatchBridge.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseOwnershipTransferred is a log parse operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_BatchBridge *BatchBridgeFilterer) ParseOwnershipTransferred(log types.Log) (*BatchBridgeOwnershipTransferred, error) {
+	event := new(BatchBridgeOwnershipTransferred)
+	if err := _BatchBridge.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// BatchBridgePausedIterator is returned from FilterPaused and is used to iterate over the raw logs and unpacked data for Paused events raised by the BatchBridge contract.
+type BatchBridgePausedIterator struct {
+	Event *BatchBridgePaused // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *BatchBridgePausedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(BatchBridgePaused)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(BatchBridgePaused)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *BatchBridgePausedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *BatchBridgePausedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// BatchBridgePaused represents a Paused event raised by the BatchBridge contract.
+type BatchBridgePaused struct {
+	Account common.Address
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterPaused is a free log retrieval operation binding the contract event 0x62e78cea01bee320cd4e420270b5ea74000d11b0c9f74754ebdbfc544b05a258.
+//
+// Solidity: event Paused(address account)
+func (_BatchBridge *BatchBridgeFilterer) FilterPaused(opts *bind.FilterOpts) (*BatchBridgePausedIterator, error) {
+
+	logs, sub, err := _BatchBridge.contract.FilterLogs(opts, "Paused")
+	if err != nil {
+		return nil, err
+	}
+	return &BatchBridgePausedIterator{contract: _BatchBridge.contract, event: "Paused", logs: logs, sub: sub}, nil
+}
+
+// WatchPaused is a free log subscription operation binding the contract event 0x62e78cea01bee320cd4e420270b5ea74000d11b0c9f74754ebdbfc544b05a258.
+//
+// Solidity: event Paused(address account)
+func (_BatchBridge *BatchBridgeFilterer) WatchPaused(opts *bind.WatchOpts, sink chan<- *BatchBridgePaused) (event.Subscription, error) {
+
+	logs, sub, err := _BatchBridge.contract.WatchLogs(opts, "Paused")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(BatchBridgePaused)
+				if err := _BatchBridge.contract.UnpackLog(event, "Paused", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParsePaused is a log parse operation binding the contract event 0x62e78cea01bee320cd4e420270b5ea74000d11b0c9f74754ebdbfc544b05a258.
+//
+// Solidity: event Paused(address account)
+func (_BatchBridge *BatchBridgeFilterer) ParsePaused(log types.Log) (*BatchBridgePaused, error) {
+	event := new(BatchBridgePaused)
+	if err := _BatchBridge.contract.UnpackLog(event, "Paused", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// BatchBridgeUnpausedIterator is returned from FilterUnpaused and is used to iterate over the raw logs and unpacked data for Unpaused events raised by the BatchBridge contract.
+type BatchBridgeUnpausedIterator struct {
+	Event *BatchBridgeUnpaused // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *BatchBridgeUnpausedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(BatchBridgeUnpaused)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(BatchBridgeUnpaused)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *BatchBridgeUnpausedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *BatchBridgeUnpausedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// BatchBridgeUnpaused represents a Unpaused event raised by the BatchBridge contract.
+type BatchBridgeUnpaused struct {
+	Account common.Address
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterUnpaused is a free log retrieval operation binding the contract event 0x5db9ee0a495bf2e6ff9c91a7834c1ba4fdd244a5e8aa4e537bd38aeae4b073aa.
+//
+// Solidity: event Unpaused(address account)
+func (_BatchBridge *BatchBridgeFilterer) FilterUnpaused(opts *bind.FilterOpts) (*BatchBridgeUnpausedIterator, error) {
+
+	logs, sub, err := _BatchBridge.contract.FilterLogs(opts, "Unpaused")
+	if err != nil {
+		return nil, err
+	}
+	return &BatchBridgeUnpausedIterator{contract: _BatchBridge.contract, event: "Unpaused", logs: logs, sub: sub}, nil
+}
+
+// WatchUnpaused is a free log subscription operation binding the contract event 0x5db9ee0a495bf2e6ff9c91a7834c1ba4fdd244a5e8aa4e537bd38aeae4b073aa.
+//
+// Solidity: event Unpaused(address account)
+func (_BatchBridge *BatchBridgeFilterer) WatchUnpaused(opts *bind.WatchOpts, sink chan<- *BatchBridgeUnpaused) (event.Subscription, error) {
+
+	logs, sub, err := _BatchBridge.contract.WatchLogs(opts, "Unpaused")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(BatchBridgeUnpaused)
+				if err := _BatchBridge.contract.UnpackLog(event, "Unpaused", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseUnpaused is a log parse operation binding the contract event 0x5db9ee0a495bf2e6ff9c91a7834c1ba4fdd244a5e8aa4e537bd38aeae4b073aa.
+//
+// Solidity: event Unpaused(address account)
+func (_BatchBridge *BatchBridgeFilterer) ParseUnpaused(log types.Log) (*BatchBridgeUnpaused, error) {
+	event := new(BatchBridgeUnpaused)
+	if err := _BatchBridge.contract.UnpackLog(event, "Unpaused", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// BatchBridgeWrappedNativeTokenSetIterator is returned from FilterWrappedNativeTokenSet and is used to iterate over the raw logs and unpacked data for WrappedNativeTokenSet events raised by the BatchBridge contract.
+type BatchBridgeWrappedNativeTokenSetIterator struct {
+	Event *BatchBridgeWrappedNativeTokenSet // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *BatchBridgeWrappedNativeTokenSetIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(BatchBridgeWrappedNativeTokenSet)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(BatchBridgeWrappedNativeTokenSet)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *BatchBridgeWrappedNativeTokenSetIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *BatchBridgeWrappedNativeTokenSetIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// BatchBridgeWrappedNativeTokenSet represents a WrappedNativeTokenSet event raised by the BatchBridge contract.
+type BatchBridgeWrappedNativeTokenSet struct {
+	SourceChainId *big.Int
+	Token         common.Address
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterWrappedNativeTokenSet is a free log retrieval operation binding the contract event 0x143495f603447fec72f81998de92c3c8451e2dc2bc595809dca7664ec2c05feb.
+//
+// Solidity: event WrappedNativeTokenSet(uint256 indexed sourceChainId, address token)
+func (_BatchBridge *BatchBridgeFilterer) FilterWrappedNativeTokenSet(opts *bind.FilterOpts, sourceChainId []*big.Int) (*BatchBridgeWrappedNativeTokenSetIterator, error) {
+
+	var sourceChainIdRule []interface{}
+	for _, sourceChainIdItem := range sourceChainId {
+		sourceChainIdRule = append(sourceChainIdRule, sourceChainIdItem)
+	}
+
+	logs, sub, err := _BatchBridge.contract.FilterLogs(opts, "WrappedNativeTokenSet", sourceChainIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &BatchBridgeWrappedNativeTokenSetIterator{contract: _BatchBridge.contract, event: "WrappedNativeTokenSet", logs: logs, sub: sub}, nil
+}
+
+// WatchWrappedNativeTokenSet is a free log subscription operation binding the contract event 0x143495f603447fec72f81998de92c3c8451e2dc2bc595809dca7664ec2c05feb.
+//
+// Solidity: event WrappedNativeTokenSet(uint256 indexed sourceChainId, address token)
+func (_BatchBridge *BatchBridgeFilterer) WatchWrappedNativeTokenSet(opts *bind.WatchOpts, sink chan<- *BatchBridgeWrappedNativeTokenSet, sourceChainId []*big.Int) (event.Subscription, error) {
+
+	var sourceChainIdRule []interface{}
+	for _, sourceChainIdItem := range sourceChainId {
+		sourceChainIdRule = append(sourceChainIdRule, sourceChainIdItem)
+	}
+
+	logs, sub, err := _BatchBridge.contract.WatchLogs(opts, "WrappedNativeTokenSet", sourceChainIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(BatchBridgeWrappedNativeTokenSet)
+				if err := _BatchBridge.contract.UnpackLog(event, "WrappedNativeTokenSet", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseWrappedNativeTokenSet is a log parse operation binding the contract event 0x143495f603447fec72f81998de92c3c8451e2dc2bc595809dca7664ec2c05feb.
+//
+// Solidity: event WrappedNativeTokenSet(uint256 indexed sourceChainId, address token)
+func (_BatchBridge *BatchBridgeFilterer) ParseWrappedNativeTokenSet(log types.Log) (*BatchBridgeWrappedNativeTokenSet, error) {
+	event := new(BatchBridgeWrappedNativeTokenSet)
+	if err := _BatchBridge.contract.UnpackLog(event, "WrappedNativeTokenSet", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
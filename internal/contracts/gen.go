@@ -0,0 +1,12 @@
+// Package contracts holds the Go bindings for the bridge smart contracts.
+//
+// The bindings are generated with solc and abigen; run `npm install` in
+// ./contracts first so the OpenZeppelin imports resolve, then `go generate`.
+package contracts
+
+//go:generate solc --optimize --evm-version paris --abi --bin --overwrite -o ../../contracts/build --base-path ../../contracts --include-path ../../contracts/node_modules ../../contracts/BatchBridge.sol ../../contracts/test/MockERC20.sol ../../contracts/interfaces/L2GasOracles.sol ../../contracts/node_modules/@openzeppelin/contracts/token/ERC20/IERC20.sol
+//go:generate abigen --abi ../../contracts/build/BatchBridge.abi --bin ../../contracts/build/BatchBridge.bin --pkg contracts --type BatchBridge --out batch_bridge.go
+//go:generate abigen --abi ../../contracts/build/MockERC20.abi --bin ../../contracts/build/MockERC20.bin --pkg contracts --type MockERC20 --out mock_erc20.go
+//go:generate abigen --abi ../../contracts/build/IERC20.abi --pkg contracts --type ERC20 --out erc20.go
+//go:generate abigen --abi ../../contracts/build/IGasPriceOracle.abi --pkg contracts --type GasPriceOracle --out gas_price_oracle.go
+//go:generate abigen --abi ../../contracts/build/INodeInterface.abi --pkg contracts --type NodeInterface --out node_interface.go
@@ -0,0 +1,118 @@
+package gas
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/contracts"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/models"
+)
+
+var (
+	// GasPriceOracleAddress is the OP-stack predeploy that prices L1 data.
+	GasPriceOracleAddress = common.HexToAddress("0x420000000000000000000000000000000000000F")
+	// NodeInterfaceAddress is Arbitrum's virtual contract for gas estimates.
+	NodeInterfaceAddress = common.HexToAddress("0x00000000000000000000000000000000000000C8")
+)
+
+// IsRollup reports whether chains of chainType pay to post their
+// transaction data to L1 on top of L2 execution.
+func IsRollup(chainType string) bool {
+	return chainType == "optimism" || chainType == "arbitrum"
+}
+
+// Cost is what a transaction pays, in wei: L2 execution, and on rollups the
+// fee for posting its data to L1.
+type Cost struct {
+	Execution *big.Int
+	L1Data    *big.Int
+}
+
+// Total returns the execution and L1 data fees together.
+func (c *Cost) Total() *big.Int {
+	return new(big.Int).Add(c.Execution, c.L1Data)
+}
+
+// CheckCost returns ErrGasPriceTooHigh when a transaction costing cost at
+// price per gas pays more than the chain's max_gas_price per gas of L2
+// execution once its L1 data fee is added. The oracle only prices
+// execution, so on rollups this is what keeps the L1 data fee under the
+// same cap.
+func CheckCost(config *models.ChainConfig, cost *Cost, price *big.Int) error {
+	maxPrice, err := maxGasPrice(config)
+	if err != nil || maxPrice == nil || cost.Execution.Sign() == 0 {
+		return err
+	}
+
+	// Execution/price is the execution gas, which excludes the L1 gas
+	// Arbitrum charges
+	perGas := new(big.Int).Mul(cost.Total(), price)
+	perGas.Div(perGas, cost.Execution)
+	if perGas.Cmp(maxPrice) > 0 {
+		return fmt.Errorf("%w: %s > %s with the L1 data fee", ErrGasPriceTooHigh, perGas, maxPrice)
+	}
+	return nil
+}
+
+// EstimateCost splits what tx costs on a chain of chainType when it uses
+// gasUsed gas at price, with the L1 fee read as of block (nil for the
+// latest). tx is only read on rollups and may be nil elsewhere.
+//
+// OP-stack chains charge the L1 data fee separately from gas; it is read
+// from the GasPriceOracle for the signed transaction, a few bytes more than
+// the unsigned encoding the chain prices. Arbitrum charges it as extra L2
+// gas, which NodeInterface estimates and which is part of gasUsed.
+func EstimateCost(ctx context.Context, caller bind.ContractCaller, chainType string, tx *types.Transaction, gasUsed uint64, price, block *big.Int) (*Cost, error) {
+	execution := new(big.Int).Mul(new(big.Int).SetUint64(gasUsed), price)
+	opts := &bind.CallOpts{Context: ctx, BlockNumber: block}
+
+	switch chainType {
+	case "optimism":
+		oracle, err := contracts.NewGasPriceOracleCaller(GasPriceOracleAddress, caller)
+		if err != nil {
+			return nil, fmt.Errorf("error binding gas price oracle: %v", err)
+		}
+		data, err := tx.MarshalBinary()
+		if err != nil {
+			return nil, fmt.Errorf("error encoding transaction: %v", err)
+		}
+		l1Fee, err := oracle.GetL1Fee(opts, data)
+		if err != nil {
+			return nil, fmt.Errorf("error getting L1 fee: %v", err)
+		}
+		return &Cost{Execution: execution, L1Data: l1Fee}, nil
+
+	case "arbitrum":
+		node, err := contracts.NewNodeInterfaceCaller(NodeInterfaceAddress, caller)
+		if err != nil {
+			return nil, fmt.Errorf("error binding node interface: %v", err)
+		}
+		// gasEstimateL1Component is payable, so the binding only has a
+		// transactor for it; the raw caller runs it through eth_call.
+		var out []interface{}
+		raw := &contracts.NodeInterfaceCallerRaw{Contract: node}
+		if err := raw.Call(opts, &out, "gasEstimateL1Component", *tx.To(), false, tx.Data()); err != nil {
+			return nil, fmt.Errorf("error getting L1 gas: %v", err)
+		}
+		if len(out) == 0 {
+			return nil, fmt.Errorf("error getting L1 gas: empty result")
+		}
+		gasEstimate, ok := out[0].(uint64)
+		if !ok {
+			return nil, fmt.Errorf("error getting L1 gas: unexpected result type %T", out[0])
+		}
+		l1Gas := new(big.Int).SetUint64(gasEstimate)
+		if l1Gas.Cmp(new(big.Int).SetUint64(gasUsed)) > 0 {
+			l1Gas.SetUint64(gasUsed)
+		}
+		l1Fee := new(big.Int).Mul(l1Gas, price)
+		return &Cost{Execution: execution.Sub(execution, l1Fee), L1Data: l1Fee}, nil
+
+	default:
+		return &Cost{Execution: execution, L1Data: new(big.Int)}, nil
+	}
+}
@@ -0,0 +1,108 @@
+package gas
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/contracts"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+// rollupCaller answers the L1 fee calls of one rollup contract.
+type rollupCaller struct {
+	contract common.Address
+	abi      string
+	method   string
+	results  []interface{}
+	// block is the block the last call was made at.
+	block *big.Int
+}
+
+func (c *rollupCaller) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return []byte{0x1}, nil
+}
+
+func (c *rollupCaller) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if call.To == nil || *call.To != c.contract {
+		return nil, fmt.Errorf("unexpected call to %v", call.To)
+	}
+	parsed, err := abi.JSON(strings.NewReader(c.abi))
+	if err != nil {
+		return nil, err
+	}
+	method, err := parsed.MethodById(call.Data)
+	if err != nil || method.Name != c.method {
+		return nil, fmt.Errorf("unexpected method: %v", err)
+	}
+	c.block = blockNumber
+	return method.Outputs.Pack(c.results...)
+}
+
+func batchTx() *types.Transaction {
+	to := common.HexToAddress("0xb1d9")
+	return types.NewTx(&types.DynamicFeeTx{To: &to, Gas: 300000, Data: []byte{0x01, 0x02, 0x03}})
+}
+
+func TestEstimateCostOptimism(t *testing.T) {
+	caller := &rollupCaller{
+		contract: GasPriceOracleAddress,
+		abi:      contracts.GasPriceOracleMetaData.ABI,
+		method:   "getL1Fee",
+		results:  []interface{}{big.NewInt(5000)},
+	}
+
+	cost, err := EstimateCost(context.Background(), caller, "optimism", batchTx(), 200000, big.NewInt(2), big.NewInt(77))
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(400000), cost.Execution)
+	require.Equal(t, big.NewInt(5000), cost.L1Data)
+	require.Equal(t, big.NewInt(405000), cost.Total())
+	require.Equal(t, big.NewInt(77), caller.block)
+}
+
+func TestEstimateCostArbitrum(t *testing.T) {
+	caller := &rollupCaller{
+		contract: NodeInterfaceAddress,
+		abi:      contracts.NodeInterfaceMetaData.ABI,
+		method:   "gasEstimateL1Component",
+		results:  []interface{}{uint64(50000), big.NewInt(1), big.NewInt(30)},
+	}
+
+	// The L1 gas is part of the gas used.
+	cost, err := EstimateCost(context.Background(), caller, "arbitrum", batchTx(), 200000, big.NewInt(2), nil)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(300000), cost.Execution)
+	require.Equal(t, big.NewInt(100000), cost.L1Data)
+	require.Equal(t, big.NewInt(400000), cost.Total())
+}
+
+func TestEstimateCostL1Chain(t *testing.T) {
+	for _, chainType := range []string{"ethereum", "bsc"} {
+		cost, err := EstimateCost(context.Background(), nil, chainType, nil, 200000, big.NewInt(2), nil)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(400000), cost.Execution)
+		require.Zero(t, cost.L1Data.Sign())
+	}
+}
+
+func TestCheckCostIncludesL1DataFee(t *testing.T) {
+	config := &models.ChainConfig{ChainID: 10, MaxGasPrice: strPtr("15")}
+	price := big.NewInt(10)
+
+	// 100000 gas at 10 with 40% on top for L1 data is 14 per gas
+	cost := &Cost{Execution: big.NewInt(1_000_000), L1Data: big.NewInt(400_000)}
+	require.NoError(t, CheckCost(config, cost, price))
+
+	cost.L1Data = big.NewInt(600_000)
+	require.ErrorIs(t, CheckCost(config, cost, price), ErrGasPriceTooHigh)
+
+	// Without a maximum nothing is held
+	require.NoError(t, CheckCost(&models.ChainConfig{ChainID: 10}, cost, price))
+}
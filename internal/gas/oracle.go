@@ -0,0 +1,221 @@
+// Package gas prices bridge transactions for each chain.
+package gas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/models"
+)
+
+const (
+	// DefaultHistoryBlocks is how many recent blocks the priority tip is
+	// sampled from.
+	DefaultHistoryBlocks = 10
+	// DefaultRewardPercentile is the percentile of each block's priority
+	// fees the tip is based on.
+	DefaultRewardPercentile = 50
+	// ReplacementBump is the percentage a replacement has to raise both fee
+	// fields by for a node to accept it in place of a pending transaction
+	// with the same nonce. It is the go-ethereum and BSC txpool default.
+	ReplacementBump = 10
+)
+
+// ErrGasPriceTooHigh is returned when the current price is above the
+// chain's max_gas_price.
+var ErrGasPriceTooHigh = errors.New("gas price above chain maximum")
+
+// Client is the part of an ethclient.Client the oracle uses.
+type Client interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	ethereum.GasPricer
+	ethereum.GasPricer1559
+	ethereum.FeeHistoryReader
+}
+
+// Fees is what a transaction pays per gas. Dynamic-fee chains set BaseFee,
+// GasTipCap and GasFeeCap; legacy chains set GasPrice only.
+type Fees struct {
+	BaseFee   *big.Int
+	GasTipCap *big.Int
+	GasFeeCap *big.Int
+	GasPrice  *big.Int
+}
+
+// Legacy reports whether the fees are for a legacy gasPrice transaction.
+func (f *Fees) Legacy() bool {
+	return f.GasPrice != nil
+}
+
+// Effective returns the price per gas the transaction is expected to pay:
+// the gas price, or the base fee plus tip bounded by the fee cap.
+func (f *Fees) Effective() *big.Int {
+	if f.Legacy() {
+		return new(big.Int).Set(f.GasPrice)
+	}
+	price := new(big.Int).Add(f.BaseFee, f.GasTipCap)
+	if price.Cmp(f.GasFeeCap) > 0 {
+		price.Set(f.GasFeeCap)
+	}
+	return price
+}
+
+// Oracle prices transactions from the chain's recent fee history.
+type Oracle struct {
+	historyBlocks uint64
+	percentile    float64
+}
+
+func NewOracle() *Oracle {
+	return &Oracle{
+		historyBlocks: DefaultHistoryBlocks,
+		percentile:    DefaultRewardPercentile,
+	}
+}
+
+// Fees returns the fees to send a transaction with on the chain config
+// describes, or ErrGasPriceTooHigh when they exceed its max_gas_price.
+//
+// On EIP-1559 chains the tip is the average of the recent blocks' reward
+// percentile and the fee cap leaves room for the base fee to double, clamped
+// to max_gas_price. BSC, and any chain whose head has no base fee, is priced
+// with the node's legacy gas price.
+func (o *Oracle) Fees(ctx context.Context, client Client, config *models.ChainConfig) (*Fees, error) {
+	maxPrice, err := maxGasPrice(config)
+	if err != nil {
+		return nil, err
+	}
+
+	head, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting head: %v", err)
+	}
+
+	if config.ChainType == "bsc" || head.BaseFee == nil {
+		gasPrice, err := client.SuggestGasPrice(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error getting gas price: %v", err)
+		}
+		if maxPrice != nil && gasPrice.Cmp(maxPrice) > 0 {
+			return nil, fmt.Errorf("%w: %s > %s", ErrGasPriceTooHigh, gasPrice, maxPrice)
+		}
+		return &Fees{GasPrice: gasPrice}, nil
+	}
+
+	history, err := client.FeeHistory(ctx, o.historyBlocks, nil, []float64{o.percentile})
+	if err != nil {
+		return nil, fmt.Errorf("error getting fee history: %v", err)
+	}
+	if len(history.BaseFee) == 0 {
+		return nil, fmt.Errorf("empty fee history")
+	}
+	// The last entry is the base fee of the next block.
+	baseFee := history.BaseFee[len(history.BaseFee)-1]
+
+	// Empty blocks report a zero reward and would drag the average below
+	// what miners accept, so only blocks with transactions are sampled.
+	tip := new(big.Int)
+	var sampled int64
+	for _, rewards := range history.Reward {
+		if len(rewards) > 0 && rewards[0].Sign() > 0 {
+			tip.Add(tip, rewards[0])
+			sampled++
+		}
+	}
+	if sampled > 0 {
+		tip.Div(tip, big.NewInt(sampled))
+	} else {
+		// No recent tips to go by; ask the node instead.
+		if tip, err = client.SuggestGasTipCap(ctx); err != nil {
+			return nil, fmt.Errorf("error getting gas tip: %v", err)
+		}
+	}
+
+	feeCap := new(big.Int).Add(new(big.Int).Mul(baseFee, big.NewInt(2)), tip)
+	if maxPrice != nil {
+		if price := new(big.Int).Add(baseFee, tip); price.Cmp(maxPrice) > 0 {
+			return nil, fmt.Errorf("%w: %s > %s", ErrGasPriceTooHigh, price, maxPrice)
+		}
+		if feeCap.Cmp(maxPrice) > 0 {
+			feeCap.Set(maxPrice)
+		}
+	}
+
+	return &Fees{
+		BaseFee:   baseFee,
+		GasTipCap: tip,
+		GasFeeCap: feeCap,
+	}, nil
+}
+
+// Replacement returns the fees to replace a pending transaction paying
+// pending with: the current fees, but at least ReplacementBump percent above
+// the pending ones. It returns ErrGasPriceTooHigh when that would exceed the
+// chain's max_gas_price.
+func (o *Oracle) Replacement(ctx context.Context, client Client, config *models.ChainConfig, pending *Fees) (*Fees, error) {
+	maxPrice, err := maxGasPrice(config)
+	if err != nil {
+		return nil, err
+	}
+	current, err := o.Fees(ctx, client, config)
+	if err != nil {
+		return nil, err
+	}
+
+	if pending.Legacy() {
+		price := atLeast(bump(pending.GasPrice), current.Effective())
+		if maxPrice != nil && price.Cmp(maxPrice) > 0 {
+			return nil, fmt.Errorf("%w: replacement %s > %s", ErrGasPriceTooHigh, price, maxPrice)
+		}
+		return &Fees{GasPrice: price}, nil
+	}
+
+	// A pending dynamic-fee transaction keeps its type, even on a chain the
+	// oracle now prices with a legacy gas price.
+	fees := &Fees{
+		BaseFee:   current.BaseFee,
+		GasTipCap: bump(pending.GasTipCap),
+		GasFeeCap: bump(pending.GasFeeCap),
+	}
+	if current.Legacy() {
+		fees.BaseFee = new(big.Int)
+		fees.GasFeeCap = atLeast(fees.GasFeeCap, current.GasPrice)
+	} else {
+		fees.GasTipCap = atLeast(fees.GasTipCap, current.GasTipCap)
+		fees.GasFeeCap = atLeast(fees.GasFeeCap, current.GasFeeCap)
+	}
+	fees.GasFeeCap = atLeast(fees.GasFeeCap, fees.GasTipCap)
+	if maxPrice != nil && fees.GasFeeCap.Cmp(maxPrice) > 0 {
+		return nil, fmt.Errorf("%w: replacement fee cap %s > %s", ErrGasPriceTooHigh, fees.GasFeeCap, maxPrice)
+	}
+	return fees, nil
+}
+
+// bump raises a fee by ReplacementBump percent, rounding up.
+func bump(fee *big.Int) *big.Int {
+	bumped := new(big.Int).Mul(fee, big.NewInt(100+ReplacementBump))
+	bumped.Add(bumped, big.NewInt(99))
+	return bumped.Div(bumped, big.NewInt(100))
+}
+
+func atLeast(fee, floor *big.Int) *big.Int {
+	if fee.Cmp(floor) < 0 {
+		return new(big.Int).Set(floor)
+	}
+	return fee
+}
+
+func maxGasPrice(config *models.ChainConfig) (*big.Int, error) {
+	if config.MaxGasPrice == nil {
+		return nil, nil
+	}
+	maxPrice, ok := new(big.Int).SetString(*config.MaxGasPrice, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid max gas price %q for chain %d", *config.MaxGasPrice, config.ChainID)
+	}
+	return maxPrice, nil
+}
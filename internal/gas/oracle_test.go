@@ -0,0 +1,132 @@
+package gas
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/models"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestOracleDynamicFees(t *testing.T) {
+	chain := testutil.NewChain(t)
+	// Blocks with transactions give the fee history rewards to sample.
+	chain.Mine(t, chain.SendValue(t, chain.Owner, common.HexToAddress("0xbeef"), testutil.Ether(1)))
+
+	fees, err := NewOracle().Fees(context.Background(), chain.Client, &models.ChainConfig{
+		ChainID:   chain.ID,
+		ChainType: "ethereum",
+	})
+	require.NoError(t, err)
+	require.False(t, fees.Legacy())
+	require.Positive(t, fees.BaseFee.Sign())
+	require.Positive(t, fees.GasTipCap.Sign())
+
+	wantCap := new(big.Int).Add(new(big.Int).Mul(fees.BaseFee, big.NewInt(2)), fees.GasTipCap)
+	require.Equal(t, wantCap, fees.GasFeeCap)
+	require.Equal(t, new(big.Int).Add(fees.BaseFee, fees.GasTipCap), fees.Effective())
+}
+
+func TestOracleClampsFeeCap(t *testing.T) {
+	chain := testutil.NewChain(t)
+	oracle := NewOracle()
+	config := &models.ChainConfig{ChainID: chain.ID, ChainType: "ethereum"}
+
+	fees, err := oracle.Fees(context.Background(), chain.Client, config)
+	require.NoError(t, err)
+
+	// Room for the current price but not for the base fee to double.
+	price := new(big.Int).Add(fees.BaseFee, fees.GasTipCap)
+	config.MaxGasPrice = strPtr(price.String())
+	fees, err = oracle.Fees(context.Background(), chain.Client, config)
+	require.NoError(t, err)
+	require.Equal(t, price, fees.GasFeeCap)
+	require.Equal(t, price, fees.Effective())
+}
+
+func TestOracleHoldsAboveMaxGasPrice(t *testing.T) {
+	chain := testutil.NewChain(t)
+
+	for _, chainType := range []string{"ethereum", "bsc"} {
+		t.Run(chainType, func(t *testing.T) {
+			_, err := NewOracle().Fees(context.Background(), chain.Client, &models.ChainConfig{
+				ChainID:     chain.ID,
+				ChainType:   chainType,
+				MaxGasPrice: strPtr("1"),
+			})
+			require.ErrorIs(t, err, ErrGasPriceTooHigh)
+		})
+	}
+}
+
+func TestOracleLegacyFeesForBSC(t *testing.T) {
+	chain := testutil.NewChain(t)
+
+	fees, err := NewOracle().Fees(context.Background(), chain.Client, &models.ChainConfig{
+		ChainID:   chain.ID,
+		ChainType: "bsc",
+	})
+	require.NoError(t, err)
+	require.True(t, fees.Legacy())
+	require.Nil(t, fees.GasFeeCap)
+	require.Equal(t, fees.GasPrice, fees.Effective())
+}
+
+func TestOracleRejectsInvalidMaxGasPrice(t *testing.T) {
+	chain := testutil.NewChain(t)
+
+	_, err := NewOracle().Fees(context.Background(), chain.Client, &models.ChainConfig{
+		ChainID:     chain.ID,
+		MaxGasPrice: strPtr("lots"),
+	})
+	require.ErrorContains(t, err, "invalid max gas price")
+}
+
+func TestReplacementBumpsPendingFees(t *testing.T) {
+	chain := testutil.NewChain(t)
+	oracle := NewOracle()
+	config := &models.ChainConfig{ChainID: chain.ID, ChainType: "ethereum"}
+
+	current, err := oracle.Fees(context.Background(), chain.Client, config)
+	require.NoError(t, err)
+
+	// Pending at the current fees: both fields go up by the bump.
+	fees, err := oracle.Replacement(context.Background(), chain.Client, config, current)
+	require.NoError(t, err)
+	require.Equal(t, bump(current.GasTipCap), fees.GasTipCap)
+	require.Equal(t, bump(current.GasFeeCap), fees.GasFeeCap)
+
+	// Pending far below the market: the current fees are used.
+	low := &Fees{BaseFee: new(big.Int), GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(1)}
+	fees, err = oracle.Replacement(context.Background(), chain.Client, config, low)
+	require.NoError(t, err)
+	require.Equal(t, current.GasTipCap, fees.GasTipCap)
+	require.Equal(t, current.GasFeeCap, fees.GasFeeCap)
+}
+
+func TestReplacementBumpsLegacyPrice(t *testing.T) {
+	chain := testutil.NewChain(t)
+	config := &models.ChainConfig{ChainID: chain.ID, ChainType: "bsc"}
+
+	pending := &Fees{GasPrice: testutil.Ether(1)}
+	fees, err := NewOracle().Replacement(context.Background(), chain.Client, config, pending)
+	require.NoError(t, err)
+	require.True(t, fees.Legacy())
+	require.Equal(t, new(big.Int).Div(testutil.Ether(11), big.NewInt(10)), fees.GasPrice)
+
+	config.MaxGasPrice = strPtr(testutil.Ether(1).String())
+	_, err = NewOracle().Replacement(context.Background(), chain.Client, config, pending)
+	require.ErrorIs(t, err, ErrGasPriceTooHigh)
+}
+
+func TestBumpRoundsUp(t *testing.T) {
+	require.Equal(t, big.NewInt(110), bump(big.NewInt(100)))
+	require.Equal(t, big.NewInt(2), bump(big.NewInt(1)))
+}
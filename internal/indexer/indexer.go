@@ -0,0 +1,274 @@
+// Package indexer follows BatchBridge events on every configured chain and
+// records them in the database.
+package indexer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/contracts"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/models"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/processor"
+)
+
+const (
+	DefaultPollInterval = 15 * time.Second
+	DefaultBlockRange   = 2000
+)
+
+type Config struct {
+	PollInterval time.Duration
+	// BlockRange caps how many blocks a single eth_getLogs query spans.
+	BlockRange uint64
+}
+
+// Store is the part of the database the indexer uses.
+type Store interface {
+	GetChainConfig(ctx context.Context, chainID int64) (*models.ChainConfig, error)
+	GetCheckpoint(ctx context.Context, chainID int64) (*models.Checkpoint, error)
+	MarkBackfilled(ctx context.Context, chainID int64, fromBlock uint64) error
+	SaveBridgeEvents(ctx context.Context, chainID int64, events []*models.BridgeEvent, fromBlock, lastBlock uint64) error
+	GetBatchSwaps(ctx context.Context, batchID int64) ([]*models.Swap, error)
+}
+
+type Indexer struct {
+	chains processor.ChainSet
+	db     Store
+	config Config
+}
+
+func New(chains processor.ChainSet, db Store, config Config) *Indexer {
+	if config.PollInterval == 0 {
+		config.PollInterval = DefaultPollInterval
+	}
+	if config.BlockRange == 0 {
+		config.BlockRange = DefaultBlockRange
+	}
+	return &Indexer{
+		chains: chains,
+		db:     db,
+		config: config,
+	}
+}
+
+// Start runs one indexing loop per chain until ctx is cancelled.
+func (ix *Indexer) Start(ctx context.Context) {
+	go processor.Follow(ctx, ix.chains, ix.run)
+}
+
+// run indexes chain until ctx is cancelled. The chain's start_block is
+// where indexing begins when it has no checkpoint yet; when the indexed
+// range begins after it, the blocks in between are backfilled first.
+func (ix *Indexer) run(ctx context.Context, chain *processor.Chain) {
+	var start uint64
+	config, err := ix.db.GetChainConfig(ctx, chain.ID)
+	if err != nil {
+		log.Printf("indexer: chain %d: %v", chain.ID, err)
+	} else if config.StartBlock != nil && *config.StartBlock > 0 {
+		start = uint64(*config.StartBlock)
+		if err := ix.Backfill(ctx, chain.ID, start); err != nil {
+			log.Printf("indexer: backfill of chain %d from block %d failed: %v", chain.ID, start, err)
+		}
+	}
+
+	ticker := time.NewTicker(ix.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := ix.poll(ctx, chain, start); err != nil {
+			log.Printf("indexer: chain %d: %v", chain.ID, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll indexes every block after the chain's checkpoint, or from start
+// when there is none, up to the head.
+func (ix *Indexer) poll(ctx context.Context, chain *processor.Chain, start uint64) error {
+	head, err := chain.Client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error getting head: %v", err)
+	}
+
+	checkpoint, err := ix.db.GetCheckpoint(ctx, chain.ID)
+	if err != nil {
+		return err
+	}
+
+	var from uint64
+	switch {
+	case checkpoint != nil:
+		from = checkpoint.LastBlock + 1
+	case start > 0:
+		from = start
+	default:
+		// Nothing to resume from and no start block: follow from the head.
+		from = head.Number.Uint64()
+	}
+
+	return ix.indexRange(ctx, chain, from, head.Number.Uint64())
+}
+
+// Backfill indexes a chain from fromBlock up to where its indexed range
+// begins, and records the range as starting at fromBlock. It does nothing
+// when the range already starts there or earlier, or when the chain has no
+// checkpoint yet, since indexing then starts at the chain's start block.
+// Events already stored are left untouched.
+func (ix *Indexer) Backfill(ctx context.Context, chainID int64, fromBlock uint64) error {
+	chain := ix.chains.Chain(chainID)
+	if chain == nil {
+		return fmt.Errorf("chain %d is not configured", chainID)
+	}
+
+	checkpoint, err := ix.db.GetCheckpoint(ctx, chainID)
+	if err != nil || checkpoint == nil {
+		return err
+	}
+	to := checkpoint.LastBlock
+	if checkpoint.FirstBlock != nil {
+		if *checkpoint.FirstBlock <= fromBlock {
+			return nil
+		}
+		to = *checkpoint.FirstBlock - 1
+	}
+
+	if err := ix.indexRange(ctx, chain, fromBlock, to); err != nil {
+		return err
+	}
+	return ix.db.MarkBackfilled(ctx, chainID, fromBlock)
+}
+
+func (ix *Indexer) indexRange(ctx context.Context, chain *processor.Chain, from, to uint64) error {
+	for from <= to {
+		end := from + ix.config.BlockRange - 1
+		if end > to {
+			end = to
+		}
+
+		events, err := ix.fetchEvents(ctx, chain, from, end)
+		if err != nil {
+			return err
+		}
+		if err := ix.db.SaveBridgeEvents(ctx, chain.ID, events, from, end); err != nil {
+			return err
+		}
+		for _, event := range events {
+			if event.EventName == models.EventBatchSwapInitiated && event.BatchID != nil {
+				ix.verifyBatch(ctx, event)
+			}
+		}
+
+		from = end + 1
+	}
+	return nil
+}
+
+func (ix *Indexer) fetchEvents(ctx context.Context, chain *processor.Chain, from, to uint64) ([]*models.BridgeEvent, error) {
+	filterer, err := contracts.NewBatchBridgeFilterer(chain.Bridge, chain.Client)
+	if err != nil {
+		return nil, fmt.Errorf("error binding bridge contract: %v", err)
+	}
+	opts := &bind.FilterOpts{Start: from, End: &to, Context: ctx}
+
+	var events []*models.BridgeEvent
+
+	initiated, err := filterer.FilterBatchSwapInitiated(opts, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error filtering %s logs: %v", models.EventBatchSwapInitiated, err)
+	}
+	defer initiated.Close()
+	for initiated.Next() {
+		payload, err := json.Marshal(initiated.Event.Requests)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding swap requests: %v", err)
+		}
+		events = append(events, newEvent(chain.ID, models.EventBatchSwapInitiated, initiated.Event.BatchId, initiated.Event.Raw, payload))
+	}
+	if err := initiated.Error(); err != nil {
+		return nil, fmt.Errorf("error decoding %s logs: %v", models.EventBatchSwapInitiated, err)
+	}
+
+	completed, err := filterer.FilterBatchSwapCompleted(opts, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error filtering %s logs: %v", models.EventBatchSwapCompleted, err)
+	}
+	defer completed.Close()
+	for completed.Next() {
+		payload, err := json.Marshal(struct {
+			SourceChainID *big.Int `json:"sourceChainId"`
+			TargetChainID *big.Int `json:"targetChainId"`
+		}{completed.Event.SourceChainId, completed.Event.TargetChainId})
+		if err != nil {
+			return nil, fmt.Errorf("error encoding completion: %v", err)
+		}
+		event := newEvent(chain.ID, models.EventBatchSwapCompleted, completed.Event.BatchId, completed.Event.Raw, payload)
+		sourceChainID := completed.Event.SourceChainId.Int64()
+		event.SourceChainID = &sourceChainID
+		events = append(events, event)
+	}
+	if err := completed.Error(); err != nil {
+		return nil, fmt.Errorf("error decoding %s logs: %v", models.EventBatchSwapCompleted, err)
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].BlockNumber != events[j].BlockNumber {
+			return events[i].BlockNumber < events[j].BlockNumber
+		}
+		return events[i].LogIndex < events[j].LogIndex
+	})
+	return events, nil
+}
+
+// verifyBatch checks the requests of an initiated event against the swaps
+// recorded for its batch and reports any divergence.
+func (ix *Indexer) verifyBatch(ctx context.Context, event *models.BridgeEvent) {
+	var requests []contracts.BatchBridgeSwapRequest
+	if err := json.Unmarshal(event.Payload, &requests); err != nil {
+		log.Printf("indexer: cannot decode requests of batch %s: %v", event.OnchainBatchID, err)
+		return
+	}
+
+	swaps, err := ix.db.GetBatchSwaps(ctx, *event.BatchID)
+	if err != nil {
+		log.Printf("indexer: cannot load swaps of batch %d: %v", *event.BatchID, err)
+		return
+	}
+	if len(swaps) != len(requests) {
+		log.Printf("indexer: batch %s has %d on-chain requests but %d recorded swaps", event.OnchainBatchID, len(requests), len(swaps))
+		return
+	}
+
+	for i, swap := range swaps {
+		req := requests[i]
+		if req.Token != swap.TokenAddress || req.Recipient != swap.Recipient ||
+			req.Amount.String() != swap.Amount || req.TargetChainId.Int64() != swap.ToChainID {
+			log.Printf("indexer: batch %s request %d does not match swap %s", event.OnchainBatchID, i, swap.RequestID)
+		}
+	}
+}
+
+func newEvent(chainID int64, name string, batchID [32]byte, raw types.Log, payload []byte) *models.BridgeEvent {
+	return &models.BridgeEvent{
+		ChainID:        chainID,
+		EventName:      name,
+		OnchainBatchID: common.Hash(batchID).Hex(),
+		BlockNumber:    int64(raw.BlockNumber),
+		BlockHash:      raw.BlockHash.Hex(),
+		TxHash:         raw.TxHash.Hex(),
+		LogIndex:       int(raw.Index),
+		Payload:        payload,
+	}
+}
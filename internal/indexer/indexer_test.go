@@ -0,0 +1,296 @@
+package indexer
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/attestation"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/contracts"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/models"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/processor"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+const sourceChainID = 56
+
+type eventKey struct {
+	txHash   string
+	logIndex int
+}
+
+// memStore links and deduplicates events the way SaveBridgeEvents does:
+// initiated events by their batch's source transaction, completed events
+// by the source chain and on-chain batch id, and every event once per
+// transaction log.
+type memStore struct {
+	mu         sync.Mutex
+	config     *models.ChainConfig
+	checkpoint *models.Checkpoint
+	batches    map[int64]*models.Batch
+	swaps      map[int64][]*models.Swap
+	events     map[eventKey]*models.BridgeEvent
+	// ranges are the block ranges saved, in order.
+	ranges [][2]uint64
+}
+
+func newMemStore(startBlock int64) *memStore {
+	return &memStore{
+		config:  &models.ChainConfig{ChainID: testutil.SimulatedChainID, StartBlock: &startBlock},
+		batches: make(map[int64]*models.Batch),
+		swaps:   make(map[int64][]*models.Swap),
+		events:  make(map[eventKey]*models.BridgeEvent),
+	}
+}
+
+func (s *memStore) GetChainConfig(ctx context.Context, chainID int64) (*models.ChainConfig, error) {
+	return s.config, nil
+}
+
+func (s *memStore) GetCheckpoint(ctx context.Context, chainID int64) (*models.Checkpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.checkpoint == nil {
+		return nil, nil
+	}
+	copied := *s.checkpoint
+	return &copied, nil
+}
+
+func (s *memStore) MarkBackfilled(ctx context.Context, chainID int64, fromBlock uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.checkpoint.FirstBlock == nil || fromBlock < *s.checkpoint.FirstBlock {
+		s.checkpoint.FirstBlock = &fromBlock
+	}
+	return nil
+}
+
+func (s *memStore) SaveBridgeEvents(ctx context.Context, chainID int64, events []*models.BridgeEvent, fromBlock, lastBlock uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, event := range events {
+		for _, b := range s.batches {
+			switch {
+			case event.EventName == models.EventBatchSwapInitiated && b.ChainID == chainID &&
+				b.SourceTxHash != nil && *b.SourceTxHash == event.TxHash:
+				b.OnchainBatchID = &event.OnchainBatchID
+				event.BatchID = &b.ID
+			case event.EventName == models.EventBatchSwapCompleted && b.TargetChainID == chainID &&
+				b.ChainID == *event.SourceChainID &&
+				b.OnchainBatchID != nil && *b.OnchainBatchID == event.OnchainBatchID:
+				b.TargetTxHash = &event.TxHash
+				event.BatchID = &b.ID
+			}
+		}
+		key := eventKey{event.TxHash, event.LogIndex}
+		if _, ok := s.events[key]; !ok {
+			s.events[key] = event
+		}
+	}
+
+	s.ranges = append(s.ranges, [2]uint64{fromBlock, lastBlock})
+	if s.checkpoint == nil {
+		s.checkpoint = &models.Checkpoint{FirstBlock: &fromBlock}
+	}
+	s.checkpoint.LastBlock = max(s.checkpoint.LastBlock, lastBlock)
+	return nil
+}
+
+func (s *memStore) GetBatchSwaps(ctx context.Context, batchID int64) ([]*models.Swap, error) {
+	return s.swaps[batchID], nil
+}
+
+func (s *memStore) eventCount(name string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := 0
+	for _, event := range s.events {
+		if event.EventName == name {
+			n++
+		}
+	}
+	return n
+}
+
+type fixture struct {
+	chain   *testutil.Chain
+	pc      *processor.Chain
+	store   *memStore
+	indexer *Indexer
+}
+
+func newFixture(t *testing.T) *fixture {
+	chain := testutil.NewChain(t)
+	pc := &processor.Chain{ID: testutil.SimulatedChainID, Client: chain.Client, Bridge: chain.BridgeAddr}
+	store := newMemStore(1)
+	return &fixture{
+		chain:   chain,
+		pc:      pc,
+		store:   store,
+		indexer: New(processor.StaticChains{pc}, store, Config{BlockRange: 4}),
+	}
+}
+
+// initiate mines a one-swap batch from the bridge owner and returns its
+// transaction hash.
+func (f *fixture) initiate(t *testing.T) string {
+	tx, err := f.chain.Bridge.BatchInitiateSwap(f.chain.Transactor(t, f.chain.Owner), []contracts.BatchBridgeSwapRequest{{
+		Token:         f.chain.TokenAddr,
+		Amount:        testutil.Ether(1),
+		Recipient:     common.HexToAddress("0x01"),
+		TargetChainId: big.NewInt(sourceChainID),
+	}})
+	require.NoError(t, err)
+	f.chain.Mine(t, tx)
+	return tx.Hash().Hex()
+}
+
+func (f *fixture) poll(t *testing.T) {
+	require.NoError(t, f.indexer.poll(context.Background(), f.pc, uint64(*f.store.config.StartBlock)))
+}
+
+func (f *fixture) head(t *testing.T) uint64 {
+	head, err := f.chain.Client.BlockNumber(context.Background())
+	require.NoError(t, err)
+	return head
+}
+
+func TestIndexerResumesFromCheckpoint(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+	f.initiate(t)
+
+	// Without a checkpoint indexing starts at the start block.
+	f.poll(t)
+	head := f.head(t)
+	require.Equal(t, uint64(1), f.store.ranges[0][0])
+	require.Equal(t, head, f.store.checkpoint.LastBlock)
+	require.Equal(t, uint64(1), *f.store.checkpoint.FirstBlock)
+	require.Equal(t, 1, f.store.eventCount(models.EventBatchSwapInitiated))
+
+	// A restart does not scan the history again: the start block is
+	// already indexed, and polling resumes after the checkpoint.
+	saved := len(f.store.ranges)
+	require.NoError(t, f.indexer.Backfill(ctx, testutil.SimulatedChainID, 1))
+	require.Len(t, f.store.ranges, saved)
+
+	f.initiate(t)
+	f.poll(t)
+	require.Equal(t, head+1, f.store.ranges[saved][0])
+	require.Equal(t, 2, f.store.eventCount(models.EventBatchSwapInitiated))
+}
+
+func TestIndexerBackfillsOnce(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+	f.initiate(t)
+	f.initiate(t)
+
+	// A checkpoint saved before the first indexed block was recorded.
+	head := f.head(t)
+	f.store.checkpoint = &models.Checkpoint{LastBlock: head}
+	require.NoError(t, f.indexer.Backfill(ctx, testutil.SimulatedChainID, 1))
+	require.Equal(t, 2, f.store.eventCount(models.EventBatchSwapInitiated))
+	require.Equal(t, uint64(1), *f.store.checkpoint.FirstBlock)
+	require.Equal(t, head, f.store.checkpoint.LastBlock)
+
+	saved := len(f.store.ranges)
+	require.NoError(t, f.indexer.Backfill(ctx, testutil.SimulatedChainID, 1))
+	require.Len(t, f.store.ranges, saved)
+
+	// Lowering the start block backfills only the blocks below the range.
+	*f.store.checkpoint.FirstBlock = 3
+	require.NoError(t, f.indexer.Backfill(ctx, testutil.SimulatedChainID, 1))
+	require.Equal(t, [2]uint64{1, 2}, f.store.ranges[saved])
+	require.Equal(t, uint64(1), *f.store.checkpoint.FirstBlock)
+}
+
+func TestIndexerSkipsLogsSeenBefore(t *testing.T) {
+	f := newFixture(t)
+	f.initiate(t)
+	f.poll(t)
+	require.Len(t, f.store.events, 1)
+
+	// Re-scanning the same blocks yields the same logs, which are stored
+	// once.
+	require.NoError(t, f.indexer.indexRange(context.Background(), f.pc, 1, f.head(t)))
+	require.Len(t, f.store.events, 1)
+}
+
+func TestIndexerLinksEventsToBatches(t *testing.T) {
+	f := newFixture(t)
+
+	// A batch sent from this chain, with its recorded swap.
+	txHash := f.initiate(t)
+	f.store.batches[1] = &models.Batch{
+		ID:            1,
+		ChainID:       testutil.SimulatedChainID,
+		TargetChainID: sourceChainID,
+		SourceTxHash:  &txHash,
+	}
+	f.store.swaps[1] = []*models.Swap{{
+		ID:           1,
+		FromChainID:  testutil.SimulatedChainID,
+		ToChainID:    sourceChainID,
+		TokenAddress: f.chain.TokenAddr,
+		Amount:       testutil.Ether(1).String(),
+		Recipient:    common.HexToAddress("0x01"),
+	}}
+
+	// And a batch from the other chain, completed on this one.
+	attesterKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	attester := attestation.NewSigner(attesterKey)
+	tx, err := f.chain.Bridge.AddAttester(f.chain.Transactor(t, f.chain.Admin), attester.Address)
+	require.NoError(t, err)
+	f.chain.Mine(t, tx)
+	tx, err = f.chain.Token.Transfer(f.chain.Transactor(t, f.chain.Owner), f.chain.BridgeAddr, testutil.Ether(5))
+	require.NoError(t, err)
+	f.chain.Mine(t, tx)
+
+	incoming := &attestation.Batch{
+		BatchID:       common.HexToHash("0xb1"),
+		SourceChainID: sourceChainID,
+		TargetChainID: testutil.SimulatedChainID,
+		Requests: []contracts.BatchBridgeSwapRequest{{
+			Token:         f.chain.TokenAddr,
+			Amount:        testutil.Ether(2),
+			Recipient:     common.HexToAddress("0xbeef"),
+			TargetChainId: big.NewInt(testutil.SimulatedChainID),
+		}},
+	}
+	onchainID := incoming.BatchID.Hex()
+	f.store.batches[2] = &models.Batch{
+		ID:             2,
+		ChainID:        sourceChainID,
+		TargetChainID:  testutil.SimulatedChainID,
+		OnchainBatchID: &onchainID,
+	}
+	signature, err := attester.Sign(f.chain.BridgeAddr, incoming)
+	require.NoError(t, err)
+	tx, err = f.chain.Bridge.BatchCompleteSwap(f.chain.Transactor(t, f.chain.Owner), incoming.BatchID, big.NewInt(sourceChainID), incoming.Requests, signature)
+	require.NoError(t, err)
+	f.chain.Mine(t, tx)
+
+	f.poll(t)
+	require.NotNil(t, f.store.batches[1].OnchainBatchID)
+	require.Equal(t, tx.Hash().Hex(), *f.store.batches[2].TargetTxHash)
+	linked := map[string]int64{}
+	for _, event := range f.store.events {
+		require.NotNil(t, event.BatchID)
+		linked[event.EventName] = *event.BatchID
+	}
+	require.Equal(t, map[string]int64{
+		models.EventBatchSwapInitiated: 1,
+		models.EventBatchSwapCompleted: 2,
+	}, linked)
+}
@@ -0,0 +1,296 @@
+// Package liquidity tracks what each bridge holds of the tokens it pays out
+// against what the swaps in flight to it owe.
+package liquidity
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/contracts"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/models"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/processor"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/tokens"
+)
+
+const DefaultPollInterval = 30 * time.Second
+
+// Store is the part of models.Database the monitor uses.
+type Store interface {
+	GetObligations(ctx context.Context, chainID int64) ([]*models.Obligation, error)
+	SaveBridgeLiquidity(ctx context.Context, liquidity *models.BridgeLiquidity) error
+}
+
+// Route is the liquidity behind swaps of a source token to a destination
+// chain. Balance, Obligations and Available are in target token units;
+// Capacity is Available in source token units, the most that can still be
+// swapped along the route.
+type Route struct {
+	FromChainID int64          `json:"fromChainId"`
+	ToChainID   int64          `json:"toChainId"`
+	SourceToken common.Address `json:"sourceToken"`
+	TargetToken common.Address `json:"targetToken"`
+	Balance     string         `json:"balance"`
+	Obligations string         `json:"obligations"`
+	Available   string         `json:"available"`
+	Capacity    string         `json:"capacity"`
+	UpdatedAt   time.Time      `json:"updatedAt"`
+}
+
+type key struct {
+	chainID int64
+	token   common.Address
+}
+
+// reservation is what an accepted swap will pay out. storedAt is when the
+// swap was stored, and is zero until then.
+type reservation struct {
+	amount   *big.Int
+	storedAt time.Time
+}
+
+// position is a bridge's balance of a token and what it owes in it, as of
+// the last refresh, plus the swaps accepted but not yet part of those
+// obligations, by request id.
+type position struct {
+	balance      *big.Int
+	obligations  *big.Int
+	reservations map[string]*reservation
+	updatedAt    time.Time
+}
+
+func (p *position) available() *big.Int {
+	available := new(big.Int).Sub(p.balance, p.obligations)
+	for _, r := range p.reservations {
+		available.Sub(available, r.amount)
+	}
+	if available.Sign() < 0 {
+		available.SetInt64(0)
+	}
+	return available
+}
+
+// Monitor polls the bridge balance of every token listed on each chain and
+// sums the target amounts of the swaps to the chain that are not yet
+// completed. Swaps are only accepted while the destination bridge can pay
+// them on top of those obligations.
+type Monitor struct {
+	chains   processor.ChainSet
+	tokens   *tokens.Registry
+	db       Store
+	interval time.Duration
+
+	positions map[key]*position
+	mutex     sync.Mutex
+}
+
+func New(chains processor.ChainSet, tokens *tokens.Registry, db Store, interval time.Duration) *Monitor {
+	if interval == 0 {
+		interval = DefaultPollInterval
+	}
+	return &Monitor{
+		chains:    chains,
+		tokens:    tokens,
+		db:        db,
+		interval:  interval,
+		positions: make(map[key]*position),
+	}
+}
+
+// Start runs one polling loop per chain until ctx is cancelled.
+func (m *Monitor) Start(ctx context.Context) {
+	go processor.Follow(ctx, m.chains, m.run)
+}
+
+func (m *Monitor) run(ctx context.Context, chain *processor.Chain) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := m.Refresh(ctx, chain); err != nil {
+			log.Printf("liquidity: chain %d: %v", chain.ID, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Refresh reads the bridge balances and the obligations of chain. Tokens
+// whose balance cannot be read keep their last known position.
+func (m *Monitor) Refresh(ctx context.Context, chain *processor.Chain) error {
+	// Swaps stored before the obligations are read are part of them.
+	queriedAt := time.Now()
+	obligations, err := m.db.GetObligations(ctx, chain.ID)
+	if err != nil {
+		return err
+	}
+
+	owed := make(map[common.Address]*big.Int)
+	for _, t := range m.tokens.Tokens(chain.ID) {
+		owed[t.Address] = new(big.Int)
+	}
+	for _, o := range obligations {
+		owed[o.Token] = o.Amount
+	}
+
+	for token, amount := range owed {
+		balance, err := m.balance(ctx, chain, token)
+		if err != nil {
+			log.Printf("liquidity: chain %d: %v", chain.ID, err)
+			continue
+		}
+
+		liquidity := &models.BridgeLiquidity{ChainID: chain.ID, Token: token, Balance: balance, Obligations: amount}
+		if err := m.db.SaveBridgeLiquidity(ctx, liquidity); err != nil {
+			log.Printf("liquidity: chain %d: %v", chain.ID, err)
+		}
+		m.update(key{chain.ID, token}, balance, amount, queriedAt)
+	}
+	return nil
+}
+
+func (m *Monitor) update(k key, balance, obligations *big.Int, queriedAt time.Time) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	p, ok := m.positions[k]
+	if !ok {
+		p = &position{reservations: make(map[string]*reservation)}
+		m.positions[k] = p
+	}
+	p.balance = balance
+	p.obligations = obligations
+	p.updatedAt = time.Now()
+
+	for requestID, r := range p.reservations {
+		if !r.storedAt.IsZero() && r.storedAt.Before(queriedAt) {
+			delete(p.reservations, requestID)
+		}
+	}
+}
+
+// balance returns what chain's bridge holds of token.
+func (m *Monitor) balance(ctx context.Context, chain *processor.Chain, token common.Address) (*big.Int, error) {
+	if processor.IsNative(token) {
+		balance, err := chain.Client.BalanceAt(ctx, chain.Bridge, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting native balance of bridge: %v", err)
+		}
+		return balance, nil
+	}
+
+	erc20, err := contracts.NewERC20Caller(token, chain.Client)
+	if err != nil {
+		return nil, fmt.Errorf("error binding token contract: %v", err)
+	}
+	balance, err := erc20.BalanceOf(&bind.CallOpts{Context: ctx}, chain.Bridge)
+	if err != nil {
+		return nil, fmt.Errorf("error getting bridge balance of token %s: %v", token.Hex(), err)
+	}
+	return balance, nil
+}
+
+// Available returns how much of token the bridge on chainID can still pay
+// out. It is false until the bridge's balance has been read.
+func (m *Monitor) Available(chainID int64, token common.Address) (*big.Int, bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	p, ok := m.positions[key{chainID, token}]
+	if !ok {
+		return nil, false
+	}
+	return p.available(), true
+}
+
+// Reserve sets amount of token aside for the swap requestID paying out on
+// chainID, or returns a *models.ValidationError when the bridge cannot
+// cover it. The reservation lasts until the swap is either handed back with
+// Release or marked Stored and counted in the obligations of a refresh.
+func (m *Monitor) Reserve(chainID int64, token common.Address, requestID string, amount *big.Int) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	p, ok := m.positions[key{chainID, token}]
+	if !ok {
+		return &models.ValidationError{
+			Rule:    models.RuleInsufficientLiquidity,
+			Message: fmt.Sprintf("liquidity of token %s on chain %d is not known yet", token.Hex(), chainID),
+			ChainID: chainID,
+		}
+	}
+	if available := p.available(); amount.Cmp(available) > 0 {
+		return &models.ValidationError{
+			Rule:    models.RuleInsufficientLiquidity,
+			Message: fmt.Sprintf("amount %s is above the %s of token %s the bridge on chain %d can pay out", amount, available, token.Hex(), chainID),
+			ChainID: chainID,
+		}
+	}
+	p.reservations[requestID] = &reservation{amount: new(big.Int).Set(amount)}
+	return nil
+}
+
+// Stored marks the reservation of the swap requestID as stored. It is kept
+// until a refresh whose obligations were read after this, and so include
+// the swap.
+func (m *Monitor) Stored(chainID int64, token common.Address, requestID string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if p, ok := m.positions[key{chainID, token}]; ok {
+		if r, ok := p.reservations[requestID]; ok {
+			r.storedAt = time.Now()
+		}
+	}
+}
+
+// Release hands back the reservation made by Reserve for the swap
+// requestID, which was not stored after all.
+func (m *Monitor) Release(chainID int64, token common.Address, requestID string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if p, ok := m.positions[key{chainID, token}]; ok {
+		delete(p.reservations, requestID)
+	}
+}
+
+// Routes returns the liquidity of every token mapping whose destination
+// bridge balance is known.
+func (m *Monitor) Routes() []*Route {
+	mappings := m.tokens.Mappings()
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	routes := make([]*Route, 0, len(mappings))
+	for _, mapping := range mappings {
+		p, ok := m.positions[key{mapping.TargetChainID, mapping.TargetToken}]
+		if !ok {
+			continue
+		}
+		available := p.available()
+		capacity, _ := tokens.Convert(available, mapping.TargetDecimals, mapping.SourceDecimals)
+		routes = append(routes, &Route{
+			FromChainID: mapping.SourceChainID,
+			ToChainID:   mapping.TargetChainID,
+			SourceToken: mapping.SourceToken,
+			TargetToken: mapping.TargetToken,
+			Balance:     p.balance.String(),
+			Obligations: p.obligations.String(),
+			Available:   available.String(),
+			Capacity:    capacity.String(),
+			UpdatedAt:   p.updatedAt,
+		})
+	}
+	return routes
+}
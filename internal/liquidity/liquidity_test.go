@@ -0,0 +1,154 @@
+package liquidity
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/models"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/processor"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/testutil"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/tokens"
+	"github.com/stretchr/testify/require"
+)
+
+const destChainID = 56
+
+type memStore struct {
+	tokens      []*models.SupportedToken
+	mappings    []*models.TokenMapping
+	obligations []*models.Obligation
+	saved       map[common.Address]*models.BridgeLiquidity
+}
+
+func (s *memStore) GetSupportedTokens(ctx context.Context) ([]*models.SupportedToken, error) {
+	return s.tokens, nil
+}
+
+func (s *memStore) GetTokenMappings(ctx context.Context) ([]*models.TokenMapping, error) {
+	return s.mappings, nil
+}
+
+func (s *memStore) GetObligations(ctx context.Context, chainID int64) ([]*models.Obligation, error) {
+	return s.obligations, nil
+}
+
+func (s *memStore) SaveBridgeLiquidity(ctx context.Context, liquidity *models.BridgeLiquidity) error {
+	s.saved[liquidity.Token] = liquidity
+	return nil
+}
+
+func TestMonitorTracksCapacity(t *testing.T) {
+	ctx := context.Background()
+	owner, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	dest := testutil.NewChainWithID(t, destChainID, owner)
+	tx, err := dest.Token.Transfer(dest.Transactor(t, owner), dest.BridgeAddr, testutil.Ether(10))
+	require.NoError(t, err)
+	dest.Mine(t, tx)
+
+	// A six-decimal token on chain 1 pays out the bridge's token on chain 56.
+	sourceToken := common.HexToAddress("0xa0b8")
+	store := &memStore{
+		tokens: []*models.SupportedToken{
+			{ID: 1, ChainID: 1, Address: sourceToken, Decimals: 6, IsActive: true},
+			{ID: 2, ChainID: destChainID, Address: dest.TokenAddr, Decimals: 18, IsActive: true},
+		},
+		mappings: []*models.TokenMapping{
+			{SourceChainID: 1, SourceToken: sourceToken, SourceDecimals: 6, TargetChainID: destChainID, TargetToken: dest.TokenAddr, TargetDecimals: 18},
+		},
+		obligations: []*models.Obligation{{ChainID: destChainID, Token: dest.TokenAddr, Amount: testutil.Ether(4)}},
+		saved:       make(map[common.Address]*models.BridgeLiquidity),
+	}
+	tokenRegistry := tokens.New(store, 0)
+	require.NoError(t, tokenRegistry.Load(ctx))
+
+	chain := &processor.Chain{ID: destChainID, Client: dest.Client, Bridge: dest.BridgeAddr}
+	monitor := New(processor.StaticChains{chain}, tokenRegistry, store, 0)
+
+	// Nothing is accepted before the balance has been read.
+	var invalid *models.ValidationError
+	require.ErrorAs(t, monitor.Reserve(destChainID, dest.TokenAddr, "swap-0", big.NewInt(1)), &invalid)
+	require.Equal(t, models.RuleInsufficientLiquidity, invalid.Rule)
+
+	require.NoError(t, monitor.Refresh(ctx, chain))
+	available, ok := monitor.Available(destChainID, dest.TokenAddr)
+	require.True(t, ok)
+	require.Equal(t, testutil.Ether(6), available)
+	require.Equal(t, testutil.Ether(10), store.saved[dest.TokenAddr].Balance)
+	require.Equal(t, testutil.Ether(4), store.saved[dest.TokenAddr].Obligations)
+
+	// Accepted swaps count against the balance until they are stored.
+	require.NoError(t, monitor.Reserve(destChainID, dest.TokenAddr, "swap-1", testutil.Ether(5)))
+	err = monitor.Reserve(destChainID, dest.TokenAddr, "swap-2", testutil.Ether(2))
+	require.ErrorAs(t, err, &invalid)
+	require.Equal(t, models.RuleInsufficientLiquidity, invalid.Rule)
+	require.Equal(t, int64(destChainID), invalid.ChainID)
+
+	// A swap that fails to be stored hands its reservation back.
+	require.NoError(t, monitor.Reserve(destChainID, dest.TokenAddr, "swap-3", testutil.Ether(1)))
+	monitor.Release(destChainID, dest.TokenAddr, "swap-3")
+	available, _ = monitor.Available(destChainID, dest.TokenAddr)
+	require.Equal(t, testutil.Ether(1), available)
+
+	routes := monitor.Routes()
+	require.Len(t, routes, 1)
+	require.Equal(t, testutil.Ether(1).String(), routes[0].Available)
+	require.Equal(t, "1000000", routes[0].Capacity)
+
+	// Once stored, the swap is part of the obligations instead.
+	monitor.Stored(destChainID, dest.TokenAddr, "swap-1")
+	store.obligations[0].Amount = testutil.Ether(9)
+	require.NoError(t, monitor.Refresh(ctx, chain))
+	available, _ = monitor.Available(destChainID, dest.TokenAddr)
+	require.Equal(t, testutil.Ether(1), available)
+}
+
+func TestMonitorKeepsReservationsUntilCounted(t *testing.T) {
+	ctx := context.Background()
+	owner, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	dest := testutil.NewChainWithID(t, destChainID, owner)
+	tx, err := dest.Token.Transfer(dest.Transactor(t, owner), dest.BridgeAddr, testutil.Ether(10))
+	require.NoError(t, err)
+	dest.Mine(t, tx)
+
+	store := &memStore{
+		tokens: []*models.SupportedToken{{ID: 1, ChainID: destChainID, Address: dest.TokenAddr, Decimals: 18, IsActive: true}},
+		saved:  make(map[common.Address]*models.BridgeLiquidity),
+	}
+	tokenRegistry := tokens.New(store, 0)
+	require.NoError(t, tokenRegistry.Load(ctx))
+	chain := &processor.Chain{ID: destChainID, Client: dest.Client, Bridge: dest.BridgeAddr}
+	monitor := New(processor.StaticChains{chain}, tokenRegistry, store, 0)
+	require.NoError(t, monitor.Refresh(ctx, chain))
+
+	// A swap reserved before a refresh but not stored yet is not in its
+	// obligations, so it still counts
+	require.NoError(t, monitor.Reserve(destChainID, dest.TokenAddr, "swap-1", testutil.Ether(4)))
+	require.NoError(t, monitor.Refresh(ctx, chain))
+	available, _ := monitor.Available(destChainID, dest.TokenAddr)
+	require.Equal(t, testutil.Ether(6), available)
+
+	// Nor is it in the obligations of a refresh that started before it was
+	// stored
+	require.NoError(t, monitor.Reserve(destChainID, dest.TokenAddr, "swap-2", testutil.Ether(1)))
+	monitor.Stored(destChainID, dest.TokenAddr, "swap-1")
+	monitor.update(key{destChainID, dest.TokenAddr}, testutil.Ether(10), new(big.Int), time.Now().Add(-time.Minute))
+	available, _ = monitor.Available(destChainID, dest.TokenAddr)
+	require.Equal(t, testutil.Ether(5), available)
+
+	// Releasing one swap leaves another of the same amount reserved
+	require.NoError(t, monitor.Reserve(destChainID, dest.TokenAddr, "swap-3", testutil.Ether(1)))
+	monitor.Release(destChainID, dest.TokenAddr, "swap-2")
+	available, _ = monitor.Available(destChainID, dest.TokenAddr)
+	require.Equal(t, testutil.Ether(5), available)
+
+	store.obligations = []*models.Obligation{{ChainID: destChainID, Token: dest.TokenAddr, Amount: testutil.Ether(4)}}
+	require.NoError(t, monitor.Refresh(ctx, chain))
+	available, _ = monitor.Available(destChainID, dest.TokenAddr)
+	require.Equal(t, testutil.Ether(5), available)
+}
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Attestation is one validator's signature over the EIP-712 digest of a
+// batch completion.
+type Attestation struct {
+	ID        int64
+	BatchID   int64
+	Digest    string
+	Attester  common.Address
+	Signature []byte
+	CreatedAt time.Time
+}
+
+// SaveAttestation stores a validator's signature. Signing the same digest
+// again is a no-op.
+func (db *Database) SaveAttestation(ctx context.Context, attestation *Attestation) error {
+	query := `
+        INSERT INTO batch_attestations (batch_id, digest, attester, signature)
+        VALUES ($1, $2, $3, $4)
+        ON CONFLICT (digest, attester) DO NOTHING
+    `
+
+	_, err := db.db.ExecContext(
+		ctx,
+		query,
+		attestation.BatchID,
+		attestation.Digest,
+		attestation.Attester.Hex(),
+		attestation.Signature,
+	)
+	if err != nil {
+		return fmt.Errorf("error saving attestation: %v", err)
+	}
+
+	return nil
+}
+
+// GetAttestations returns every signature published for a digest.
+func (db *Database) GetAttestations(ctx context.Context, digest string) ([]*Attestation, error) {
+	query := `
+        SELECT id, batch_id, digest, attester, signature, created_at
+        FROM batch_attestations
+        WHERE digest = $1
+        ORDER BY id
+    `
+
+	rows, err := db.db.QueryContext(ctx, query, digest)
+	if err != nil {
+		return nil, fmt.Errorf("error getting attestations: %v", err)
+	}
+	defer rows.Close()
+
+	var attestations []*Attestation
+	for rows.Next() {
+		attestation := &Attestation{}
+		var attester string
+		err := rows.Scan(
+			&attestation.ID,
+			&attestation.BatchID,
+			&attestation.Digest,
+			&attester,
+			&attestation.Signature,
+			&attestation.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning attestation: %v", err)
+		}
+		attestation.Attester = common.HexToAddress(attester)
+		attestations = append(attestations, attestation)
+	}
+
+	return attestations, rows.Err()
+}
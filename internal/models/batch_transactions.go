@@ -0,0 +1,202 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// BatchTransaction is one transaction sent for a batch. A replacement
+// shares the nonce of the transaction it replaces and pays higher fees.
+// GasPrice is the expected price per gas; GasTipCap and GasFeeCap are only
+// set for EIP-1559 transactions.
+type BatchTransaction struct {
+	ID            int64
+	BatchID       int64
+	WalletAddress string
+	TxHash        string
+	Nonce         uint64
+	GasPrice      string
+	GasTipCap     *string
+	GasFeeCap     *string
+	CreatedAt     time.Time
+}
+
+// AddBatchTransaction records a transaction sent for a batch.
+func (db *Database) AddBatchTransaction(ctx context.Context, batchTx *BatchTransaction) error {
+	query := `
+        INSERT INTO batch_transactions (
+            batch_id, wallet_address, tx_hash, nonce,
+            gas_price, gas_tip_cap, gas_fee_cap
+        ) VALUES ($1, $2, $3, $4, $5, $6, $7)
+        ON CONFLICT (tx_hash) DO NOTHING
+        RETURNING id, created_at
+    `
+
+	err := db.db.QueryRowContext(
+		ctx,
+		query,
+		batchTx.BatchID,
+		batchTx.WalletAddress,
+		batchTx.TxHash,
+		int64(batchTx.Nonce),
+		batchTx.GasPrice,
+		batchTx.GasTipCap,
+		batchTx.GasFeeCap,
+	).Scan(&batchTx.ID, &batchTx.CreatedAt)
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("error adding batch transaction: %v", err)
+	}
+
+	return nil
+}
+
+// GetBatchTransactions returns every transaction sent for a batch, oldest
+// first.
+func (db *Database) GetBatchTransactions(ctx context.Context, batchID int64) ([]*BatchTransaction, error) {
+	query := `
+        SELECT
+            id, batch_id, wallet_address, tx_hash, nonce,
+            gas_price, gas_tip_cap, gas_fee_cap, created_at
+        FROM batch_transactions
+        WHERE batch_id = $1
+        ORDER BY id
+    `
+
+	rows, err := db.db.QueryContext(ctx, query, batchID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting batch transactions: %v", err)
+	}
+	defer rows.Close()
+
+	var batchTxs []*BatchTransaction
+	for rows.Next() {
+		batchTx := &BatchTransaction{}
+		var nonce int64
+		err := rows.Scan(
+			&batchTx.ID,
+			&batchTx.BatchID,
+			&batchTx.WalletAddress,
+			&batchTx.TxHash,
+			&nonce,
+			&batchTx.GasPrice,
+			&batchTx.GasTipCap,
+			&batchTx.GasFeeCap,
+			&batchTx.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning batch transaction: %v", err)
+		}
+		batchTx.Nonce = uint64(nonce)
+		batchTxs = append(batchTxs, batchTx)
+	}
+
+	return batchTxs, rows.Err()
+}
+
+// ReplaceBatchTx records a replacement transaction and makes it the batch's
+// source transaction, together with the gas price it pays.
+func (db *Database) ReplaceBatchTx(ctx context.Context, batchTx *BatchTransaction) error {
+	tx, err := db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, `
+        INSERT INTO batch_transactions (
+            batch_id, wallet_address, tx_hash, nonce,
+            gas_price, gas_tip_cap, gas_fee_cap
+        ) VALUES ($1, $2, $3, $4, $5, $6, $7)
+        RETURNING id, created_at
+    `,
+		batchTx.BatchID,
+		batchTx.WalletAddress,
+		batchTx.TxHash,
+		int64(batchTx.Nonce),
+		batchTx.GasPrice,
+		batchTx.GasTipCap,
+		batchTx.GasFeeCap,
+	).Scan(&batchTx.ID, &batchTx.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("error adding batch transaction: %v", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `
+        UPDATE batches
+        SET source_tx_hash = $1, gas_price = $2, updated_at = NOW()
+        WHERE id = $3
+    `, batchTx.TxHash, batchTx.GasPrice, batchTx.BatchID)
+	if err != nil {
+		return fmt.Errorf("error updating batch source tx: %v", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting rows affected: %v", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrBatchNotFound
+	}
+
+	return tx.Commit()
+}
+
+// SetBatchSourceTx records which of a batch's transactions was mined, with
+// the gas price that transaction pays.
+func (db *Database) SetBatchSourceTx(ctx context.Context, batchID int64, txHash string) error {
+	query := `
+        UPDATE batches b
+        SET source_tx_hash = t.tx_hash, gas_price = t.gas_price, updated_at = NOW()
+        FROM batch_transactions t
+        WHERE b.id = $1 AND t.batch_id = b.id AND t.tx_hash = $2
+    `
+
+	result, err := db.db.ExecContext(ctx, query, batchID, txHash)
+	if err != nil {
+		return fmt.Errorf("error updating batch source tx: %v", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting rows affected: %v", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrBatchNotFound
+	}
+
+	return nil
+}
+
+// GetUnminedBatchNonces returns the nonces of a wallet's batch
+// transactions on a chain that are still waiting to be mined.
+func (db *Database) GetUnminedBatchNonces(ctx context.Context, address string, chainID int64) ([]uint64, error) {
+	query := `
+        SELECT DISTINCT t.nonce
+        FROM batch_transactions t
+        JOIN batches b ON b.id = t.batch_id
+        WHERE t.wallet_address = $1 AND b.chain_id = $2
+        AND b.status = 'processing' AND b.block_hash IS NULL
+        ORDER BY t.nonce
+    `
+
+	rows, err := db.db.QueryContext(ctx, query, address, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting unmined batch nonces: %v", err)
+	}
+	defer rows.Close()
+
+	var nonces []uint64
+	for rows.Next() {
+		var nonce int64
+		if err := rows.Scan(&nonce); err != nil {
+			return nil, fmt.Errorf("error scanning batch nonce: %v", err)
+		}
+		nonces = append(nonces, uint64(nonce))
+	}
+
+	return nonces, rows.Err()
+}
@@ -7,6 +7,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -17,39 +18,68 @@ var (
 	ErrSwapNotFound  = errors.New("swap not found")
 	ErrBatchNotFound = errors.New("batch not found")
 	ErrInvalidStatus = errors.New("invalid status")
+
+	ErrChainConfigNotFound = errors.New("chain config not found")
+
+	// ErrCompletionSent is returned by RollbackReorgedBatch for a batch
+	// whose destination completion was already sent.
+	ErrCompletionSent = errors.New("batch completion already sent")
+)
+
+// Finality modes a chain config can use to decide when a batch is final.
+const (
+	FinalityConfirmations = "confirmations"
+	FinalitySafe          = "safe"
+	FinalityFinalized     = "finalized"
 )
 
 type Database struct {
 	db *sql.DB
 }
 
-type SwapRequest struct {
+type Swap struct {
+	ID           int64
 	RequestID    string
 	FromChainID  int64
 	ToChainID    int64
 	TokenAddress common.Address
 	Amount       string
 	Recipient    common.Address
-	Status       string
-	ErrorMessage *string
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
+	// TargetTokenAddress and TargetAmount are what the swap pays out on
+	// the destination chain. Dust is the part of Amount, in source token
+	// units, lost converting to the destination token's decimals.
+	TargetTokenAddress common.Address
+	TargetAmount       string
+	Dust               string
+	Status             string
+	ErrorMessage       *string
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
 }
 
 type Batch struct {
-	ID            int64
-	BatchID       string
-	WalletAddress string
-	ChainID       int64
-	SourceTxHash  *string
-	TargetTxHash  *string
-	Status        string
-	GasPrice      *string
-	GasUsed       *int64
-	BlockNumber   *int64
-	ErrorMessage  *string
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
+	ID             int64
+	BatchID        string
+	WalletAddress  string
+	ChainID        int64
+	TargetChainID  int64
+	OnchainBatchID *string
+	SourceTxHash   *string
+	TargetTxHash   *string
+	TargetSentAt   *time.Time
+	Status         string
+	GasPrice       *string
+	GasUsed        *int64
+	// ExecutionFee and L1DataFee split what the batch transaction pays, in
+	// wei: estimated when it is sent, then from its receipt once mined.
+	// L1DataFee is zero except on rollups.
+	ExecutionFee *string
+	L1DataFee    *string
+	BlockNumber  *int64
+	BlockHash    *string
+	ErrorMessage *string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
 }
 
 type HotWallet struct {
@@ -72,10 +102,55 @@ type ChainConfig struct {
 	RPCUrl                string
 	BridgeAddress         string
 	RequiredConfirmations int
+	FinalityMode          string
 	MaxGasPrice           *string
-	IsActive              bool
-	CreatedAt             time.Time
-	UpdatedAt             time.Time
+	// StuckTxTimeoutSeconds is how long a batch transaction may stay
+	// unmined before it is replaced with higher fees.
+	StuckTxTimeoutSeconds int
+	// AutoApprove lets hot wallets approve the bridge for a token when
+	// their allowance cannot cover a batch.
+	AutoApprove bool
+	// StartBlock is where the event indexer starts on a chain without a
+	// checkpoint, or backfills from when it is below the checkpoint.
+	StartBlock *int64
+	// MinWalletBalance is the native balance, in wei, below which a hot
+	// wallet gets no work on the chain; TargetWalletBalance is what the
+	// treasury tops such a wallet up to. Either may be unset.
+	MinWalletBalance    *string
+	TargetWalletBalance *string
+	IsActive            bool
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+// TokenMapping pairs a token on a source chain with the token swaps of it
+// pay out on a target chain. The decimals come from supported_tokens.
+type TokenMapping struct {
+	ID             int64
+	SourceChainID  int64
+	SourceToken    common.Address
+	SourceDecimals int
+	TargetChainID  int64
+	TargetToken    common.Address
+	TargetDecimals int
+}
+
+// SupportedToken is a token listed for swaps on a chain, with the amounts
+// a swap of it must stay within.
+type SupportedToken struct {
+	ID        int64
+	ChainID   int64
+	Address   common.Address
+	Symbol    string
+	Decimals  int
+	MinAmount *big.Int
+	MaxAmount *big.Int
+	IsActive  bool
+}
+
+// StuckTxTimeout returns StuckTxTimeoutSeconds as a duration.
+func (c *ChainConfig) StuckTxTimeout() time.Duration {
+	return time.Duration(c.StuckTxTimeoutSeconds) * time.Second
 }
 
 func NewDatabase(connStr string) (*Database, error) {
@@ -98,14 +173,15 @@ func NewDatabase(connStr string) (*Database, error) {
 }
 
 // Swap related functions
-func (db *Database) CreateSwap(ctx context.Context, swap *SwapRequest) error {
+func (db *Database) CreateSwap(ctx context.Context, swap *Swap) error {
 	query := `
         INSERT INTO swaps (
             request_id, from_chain_id, to_chain_id, 
-            token_address, amount, recipient, 
+            token_address, amount, recipient,
+            target_token_address, target_amount, dust,
             status
-        ) VALUES ($1, $2, $3, $4, $5, $6, $7)
-        RETURNING id, created_at, updated_at
+        ) VALUES (COALESCE(NULLIF($1, '')::uuid, uuid_generate_v4()), $2, $3, $4, $5, $6, $7, $8, $9, $10)
+        RETURNING id, request_id, created_at, updated_at
     `
 
 	return db.db.QueryRowContext(
@@ -117,28 +193,37 @@ func (db *Database) CreateSwap(ctx context.Context, swap *SwapRequest) error {
 		swap.TokenAddress.Hex(),
 		swap.Amount,
 		swap.Recipient.Hex(),
+		swap.TargetTokenAddress.Hex(),
+		swap.TargetAmount,
+		swap.Dust,
 		swap.Status,
-	).Scan(&swap.CreatedAt, &swap.UpdatedAt)
+	).Scan(&swap.ID, &swap.RequestID, &swap.CreatedAt, &swap.UpdatedAt)
 }
 
-func (db *Database) GetSwapByRequestID(ctx context.Context, requestID string) (*SwapRequest, error) {
+func (db *Database) GetSwapByRequestID(ctx context.Context, requestID string) (*Swap, error) {
 	query := `
         SELECT 
-            request_id, from_chain_id, to_chain_id,
+            id, request_id, from_chain_id, to_chain_id,
             token_address, amount, recipient,
+            target_token_address, target_amount, dust,
             status, error_message, created_at, updated_at
         FROM swaps 
         WHERE request_id = $1
     `
 
-	swap := &SwapRequest{}
+	swap := &Swap{}
+	var tokenAddress, recipient, targetTokenAddress string
 	err := db.db.QueryRowContext(ctx, query, requestID).Scan(
+		&swap.ID,
 		&swap.RequestID,
 		&swap.FromChainID,
 		&swap.ToChainID,
-		&swap.TokenAddress,
+		&tokenAddress,
 		&swap.Amount,
-		&swap.Recipient,
+		&recipient,
+		&targetTokenAddress,
+		&swap.TargetAmount,
+		&swap.Dust,
 		&swap.Status,
 		&swap.ErrorMessage,
 		&swap.CreatedAt,
@@ -152,9 +237,56 @@ func (db *Database) GetSwapByRequestID(ctx context.Context, requestID string) (*
 		return nil, fmt.Errorf("error getting swap: %v", err)
 	}
 
+	swap.TokenAddress = common.HexToAddress(tokenAddress)
+	swap.Recipient = common.HexToAddress(recipient)
+	swap.TargetTokenAddress = common.HexToAddress(targetTokenAddress)
+
 	return swap, nil
 }
 
+func (db *Database) GetSwapStatus(ctx context.Context, requestID string) (*SwapStatus, error) {
+	query := `
+        SELECT
+            s.request_id, s.status, s.from_chain_id, s.to_chain_id,
+            s.target_token_address, s.target_amount, s.dust,
+            b.source_tx_hash, b.target_tx_hash, s.created_at, s.updated_at
+        FROM swaps s
+        LEFT JOIN batch_swaps bs ON bs.swap_id = s.id
+        LEFT JOIN batches b ON b.id = bs.batch_id
+        WHERE s.request_id = $1
+        ORDER BY b.id DESC NULLS LAST
+        LIMIT 1
+    `
+
+	status := &SwapStatus{}
+	var sourceTxHash, targetTxHash sql.NullString
+	err := db.db.QueryRowContext(ctx, query, requestID).Scan(
+		&status.RequestID,
+		&status.Status,
+		&status.FromChainID,
+		&status.ToChainID,
+		&status.TargetTokenAddress,
+		&status.TargetAmount,
+		&status.Dust,
+		&sourceTxHash,
+		&targetTxHash,
+		&status.CreatedAt,
+		&status.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, ErrSwapNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting swap status: %v", err)
+	}
+
+	status.SourceTxHash = sourceTxHash.String
+	status.TargetTxHash = targetTxHash.String
+
+	return status, nil
+}
+
 func (db *Database) UpdateSwapStatus(ctx context.Context, requestID string, status string, errorMsg *string) error {
 	query := `
         UPDATE swaps 
@@ -184,9 +316,9 @@ func (db *Database) CreateBatch(ctx context.Context, batch *Batch) error {
 	query := `
         INSERT INTO batches (
             batch_id, wallet_address, chain_id,
-            status
-        ) VALUES ($1, $2, $3, $4)
-        RETURNING id, created_at, updated_at
+            target_chain_id, status
+        ) VALUES (COALESCE(NULLIF($1, '')::uuid, uuid_generate_v4()), $2, $3, $4, $5)
+        RETURNING id, batch_id, created_at, updated_at
     `
 
 	return db.db.QueryRowContext(
@@ -195,29 +327,31 @@ func (db *Database) CreateBatch(ctx context.Context, batch *Batch) error {
 		batch.BatchID,
 		batch.WalletAddress,
 		batch.ChainID,
+		batch.TargetChainID,
 		batch.Status,
-	).Scan(&batch.ID, &batch.CreatedAt, &batch.UpdatedAt)
+	).Scan(&batch.ID, &batch.BatchID, &batch.CreatedAt, &batch.UpdatedAt)
 }
 
-func (db *Database) AddSwapsToBatch(ctx context.Context, batchID int64, swapIDs []string) error {
+func (db *Database) AddSwapsToBatch(ctx context.Context, batchID int64, swapIDs []int64) error {
 	tx, err := db.db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("error starting transaction: %v", err)
 	}
 	defer tx.Rollback()
 
-	// Insert batch_swaps mappings
+	// Insert batch_swaps mappings, keeping each swap's position in the
+	// on-chain SwapRequest[] array
 	stmt, err := tx.PrepareContext(ctx, `
-        INSERT INTO batch_swaps (batch_id, swap_id)
-        VALUES ($1, $2)
+        INSERT INTO batch_swaps (batch_id, swap_id, request_index)
+        VALUES ($1, $2, $3)
     `)
 	if err != nil {
 		return fmt.Errorf("error preparing statement: %v", err)
 	}
 	defer stmt.Close()
 
-	for _, swapID := range swapIDs {
-		_, err = stmt.ExecContext(ctx, batchID, swapID)
+	for i, swapID := range swapIDs {
+		_, err = stmt.ExecContext(ctx, batchID, swapID, i)
 		if err != nil {
 			return fmt.Errorf("error inserting batch_swap: %v", err)
 		}
@@ -227,7 +361,7 @@ func (db *Database) AddSwapsToBatch(ctx context.Context, batchID int64, swapIDs
 	_, err = tx.ExecContext(ctx, `
         UPDATE swaps 
         SET status = 'queued', updated_at = NOW()
-        WHERE request_id = ANY($1)
+        WHERE id = ANY($1)
     `, pq.Array(swapIDs))
 	if err != nil {
 		return fmt.Errorf("error updating swaps status: %v", err)
@@ -268,6 +402,485 @@ func (db *Database) UpdateBatchStatus(ctx context.Context, batchID string, statu
 	return nil
 }
 
+const batchColumns = `
+            id, batch_id, wallet_address, chain_id, target_chain_id, onchain_batch_id,
+            source_tx_hash, target_tx_hash, target_sent_at, status, gas_price, gas_used,
+            execution_fee, l1_data_fee, block_number, block_hash, error_message, created_at, updated_at
+`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanBatch(row rowScanner) (*Batch, error) {
+	batch := &Batch{}
+	err := row.Scan(
+		&batch.ID,
+		&batch.BatchID,
+		&batch.WalletAddress,
+		&batch.ChainID,
+		&batch.TargetChainID,
+		&batch.OnchainBatchID,
+		&batch.SourceTxHash,
+		&batch.TargetTxHash,
+		&batch.TargetSentAt,
+		&batch.Status,
+		&batch.GasPrice,
+		&batch.GasUsed,
+		&batch.ExecutionFee,
+		&batch.L1DataFee,
+		&batch.BlockNumber,
+		&batch.BlockHash,
+		&batch.ErrorMessage,
+		&batch.CreatedAt,
+		&batch.UpdatedAt,
+	)
+	return batch, err
+}
+
+// GetBatchesByStatus returns a chain's batches in the given status, oldest first.
+func (db *Database) GetBatchesByStatus(ctx context.Context, chainID int64, status string) ([]*Batch, error) {
+	query := `
+        SELECT` + batchColumns + `
+        FROM batches
+        WHERE chain_id = $1 AND status = $2
+        ORDER BY id
+    `
+
+	rows, err := db.db.QueryContext(ctx, query, chainID, status)
+	if err != nil {
+		return nil, fmt.Errorf("error getting batches: %v", err)
+	}
+	defer rows.Close()
+
+	var batches []*Batch
+	for rows.Next() {
+		batch, err := scanBatch(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning batch: %v", err)
+		}
+		batches = append(batches, batch)
+	}
+
+	return batches, rows.Err()
+}
+
+// GetMinedBatches returns a chain's processing, confirmed and completed
+// batches mined at or above fromBlock, the ones a reorg can still affect.
+func (db *Database) GetMinedBatches(ctx context.Context, chainID int64, fromBlock int64) ([]*Batch, error) {
+	query := `
+        SELECT` + batchColumns + `
+        FROM batches
+        WHERE chain_id = $1
+        AND status IN ('processing', 'confirmed', 'completed')
+        AND block_hash IS NOT NULL
+        AND block_number >= $2
+        ORDER BY block_number
+    `
+
+	rows, err := db.db.QueryContext(ctx, query, chainID, fromBlock)
+	if err != nil {
+		return nil, fmt.Errorf("error getting mined batches: %v", err)
+	}
+	defer rows.Close()
+
+	var batches []*Batch
+	for rows.Next() {
+		batch, err := scanBatch(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning batch: %v", err)
+		}
+		batches = append(batches, batch)
+	}
+
+	return batches, rows.Err()
+}
+
+// BatchReceipt is what the receipt of a mined batch transaction tells
+// about the batch.
+type BatchReceipt struct {
+	BlockNumber int64
+	BlockHash   string
+	GasUsed     int64
+	// GasPrice is the effective gas price the transaction paid, or nil to
+	// keep the price recorded when it was sent.
+	GasPrice *string
+	// Cost is what the transaction paid, or nil to keep the estimate
+	// recorded when it was sent.
+	Cost *BatchCost
+}
+
+// BatchCost is what a batch transaction pays, in wei, for L2 execution and,
+// on rollups, for posting its data to L1.
+type BatchCost struct {
+	ExecutionFee string
+	L1DataFee    string
+}
+
+// UpdateBatchReceipt records where a batch transaction was mined and what
+// it cost.
+func (db *Database) UpdateBatchReceipt(ctx context.Context, batchID int64, receipt *BatchReceipt) error {
+	query := `
+        UPDATE batches
+        SET block_number = $1, block_hash = $2, gas_used = $3,
+            gas_price = COALESCE($4, gas_price),
+            execution_fee = COALESCE($5, execution_fee),
+            l1_data_fee = COALESCE($6, l1_data_fee),
+            updated_at = NOW()
+        WHERE id = $7
+    `
+
+	var executionFee, l1DataFee *string
+	if receipt.Cost != nil {
+		executionFee, l1DataFee = &receipt.Cost.ExecutionFee, &receipt.Cost.L1DataFee
+	}
+
+	result, err := db.db.ExecContext(ctx, query, receipt.BlockNumber, receipt.BlockHash, receipt.GasUsed, receipt.GasPrice, executionFee, l1DataFee, batchID)
+	if err != nil {
+		return fmt.Errorf("error updating batch receipt: %v", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting rows affected: %v", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrBatchNotFound
+	}
+
+	return nil
+}
+
+// UpdateBatchCost records the estimated cost of a batch transaction that
+// was just sent.
+func (db *Database) UpdateBatchCost(ctx context.Context, batchID int64, cost *BatchCost) error {
+	query := `
+        UPDATE batches
+        SET execution_fee = $1, l1_data_fee = $2, updated_at = NOW()
+        WHERE id = $3
+    `
+
+	result, err := db.db.ExecContext(ctx, query, cost.ExecutionFee, cost.L1DataFee, batchID)
+	if err != nil {
+		return fmt.Errorf("error updating batch cost: %v", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting rows affected: %v", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrBatchNotFound
+	}
+
+	return nil
+}
+
+// UpdateBatchState moves a batch and all of its swaps to status together.
+func (db *Database) UpdateBatchState(ctx context.Context, batchID int64, status string, errorMsg *string) error {
+	tx, err := db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+        UPDATE batches
+        SET status = $1, error_message = $2, updated_at = NOW()
+        WHERE id = $3
+    `, status, errorMsg, batchID)
+	if err != nil {
+		return fmt.Errorf("error updating batch status: %v", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting rows affected: %v", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrBatchNotFound
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        UPDATE swaps
+        SET status = $1, error_message = $2, updated_at = NOW()
+        WHERE id IN (SELECT swap_id FROM batch_swaps WHERE batch_id = $3)
+    `, status, errorMsg, batchID)
+	if err != nil {
+		return fmt.Errorf("error updating batch swaps status: %v", err)
+	}
+
+	return tx.Commit()
+}
+
+// SetBatchTargetTx records the destination-chain transaction that completes
+// a batch, and when it was sent.
+func (db *Database) SetBatchTargetTx(ctx context.Context, batchID int64, txHash string) error {
+	query := `
+        UPDATE batches
+        SET target_tx_hash = $1, target_sent_at = NOW(), updated_at = NOW()
+        WHERE id = $2
+    `
+
+	result, err := db.db.ExecContext(ctx, query, txHash, batchID)
+	if err != nil {
+		return fmt.Errorf("error updating batch target tx: %v", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting rows affected: %v", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrBatchNotFound
+	}
+
+	return nil
+}
+
+// ClearBatchTargetTx forgets the destination-chain transaction of a batch
+// that reverted or was dropped, so the relayer sends the batch again.
+// errorMsg records why.
+func (db *Database) ClearBatchTargetTx(ctx context.Context, batchID int64, errorMsg *string) error {
+	query := `
+        UPDATE batches
+        SET target_tx_hash = NULL, target_sent_at = NULL, error_message = $1, updated_at = NOW()
+        WHERE id = $2
+    `
+
+	result, err := db.db.ExecContext(ctx, query, errorMsg, batchID)
+	if err != nil {
+		return fmt.Errorf("error clearing batch target tx: %v", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting rows affected: %v", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrBatchNotFound
+	}
+
+	return nil
+}
+
+// MarkBatchSubmitted records the transaction that carries a batch and the
+// gas price it pays, and moves the batch and its swaps to processing. A nil
+// gasPrice keeps the recorded one.
+func (db *Database) MarkBatchSubmitted(ctx context.Context, batchID int64, walletAddress string, txHash string, gasPrice *string) error {
+	tx, err := db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+        UPDATE batches
+        SET status = 'processing', wallet_address = $1, source_tx_hash = $2,
+            gas_price = COALESCE($3, gas_price), error_message = NULL, updated_at = NOW()
+        WHERE id = $4
+    `, walletAddress, txHash, gasPrice, batchID)
+	if err != nil {
+		return fmt.Errorf("error updating batch: %v", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting rows affected: %v", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrBatchNotFound
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        UPDATE swaps
+        SET status = 'processing', error_message = NULL, updated_at = NOW()
+        WHERE id IN (SELECT swap_id FROM batch_swaps WHERE batch_id = $1)
+    `, batchID)
+	if err != nil {
+		return fmt.Errorf("error updating batch swaps status: %v", err)
+	}
+
+	return tx.Commit()
+}
+
+// RollbackReorgedBatch undoes everything recorded from a batch transaction
+// whose block is no longer canonical. The batch is parked as reorged with
+// its swaps queued so the processor resubmits it, the events indexed from
+// the orphaned block are dropped and the chain checkpoint is rewound so the
+// canonical block is indexed again. The rollback is recorded in audit_logs.
+// A batch whose completion was sent to its destination chain is left alone
+// and ErrCompletionSent returned: resubmitting it would pay it out twice.
+func (db *Database) RollbackReorgedBatch(ctx context.Context, batch *Batch, canonicalHash *string) error {
+	if batch.BlockNumber == nil || batch.BlockHash == nil {
+		return fmt.Errorf("batch %s has no recorded block", batch.BatchID)
+	}
+
+	tx, err := db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	errorMsg := fmt.Sprintf("block %d (%s) was reorganized out", *batch.BlockNumber, *batch.BlockHash)
+	result, err := tx.ExecContext(ctx, `
+        UPDATE batches
+        SET status = 'reorged', onchain_batch_id = NULL, block_number = NULL,
+            block_hash = NULL, gas_used = NULL, error_message = $1, updated_at = NOW()
+        WHERE id = $2 AND target_tx_hash IS NULL AND status <> 'completed'
+    `, errorMsg, batch.ID)
+	if err != nil {
+		return fmt.Errorf("error rolling back batch: %v", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting rows affected: %v", err)
+	}
+	if rowsAffected == 0 {
+		return ErrCompletionSent
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        UPDATE swaps
+        SET status = 'queued', error_message = NULL, updated_at = NOW()
+        WHERE id IN (SELECT swap_id FROM batch_swaps WHERE batch_id = $1)
+    `, batch.ID)
+	if err != nil {
+		return fmt.Errorf("error rolling back batch swaps: %v", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        DELETE FROM bridge_events
+        WHERE chain_id = $1 AND block_hash = $2
+    `, batch.ChainID, *batch.BlockHash)
+	if err != nil {
+		return fmt.Errorf("error removing orphaned events: %v", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        UPDATE chain_checkpoints
+        SET last_block = LEAST(last_block, $1), updated_at = NOW()
+        WHERE chain_id = $2
+    `, *batch.BlockNumber-1, batch.ChainID)
+	if err != nil {
+		return fmt.Errorf("error rewinding checkpoint: %v", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        INSERT INTO audit_logs (entity_type, entity_id, action, old_data, new_data)
+        VALUES (
+            'batches', $1, 'REORG_ROLLBACK',
+            json_build_object('status', $2::text, 'source_tx_hash', $3::text,
+                              'block_number', $4::bigint, 'block_hash', $5::text),
+            json_build_object('status', 'reorged', 'canonical_block_hash', $6::text)
+        )
+    `, batch.ID, batch.Status, batch.SourceTxHash, *batch.BlockNumber, *batch.BlockHash, canonicalHash)
+	if err != nil {
+		return fmt.Errorf("error writing audit log: %v", err)
+	}
+
+	return tx.Commit()
+}
+
+// FlagReorgedCompletion records that the source transaction of a batch
+// whose completion was already sent is no longer canonical. The batch keeps
+// its status and is not resubmitted; its block is cleared so the reorg is
+// reported once, and the event is recorded in audit_logs for review.
+func (db *Database) FlagReorgedCompletion(ctx context.Context, batch *Batch, canonicalHash *string) error {
+	if batch.BlockNumber == nil || batch.BlockHash == nil {
+		return fmt.Errorf("batch %s has no recorded block", batch.BatchID)
+	}
+
+	tx, err := db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	errorMsg := fmt.Sprintf("block %d (%s) was reorganized out after the batch was relayed", *batch.BlockNumber, *batch.BlockHash)
+	_, err = tx.ExecContext(ctx, `
+        UPDATE batches
+        SET block_number = NULL, block_hash = NULL, error_message = $1, updated_at = NOW()
+        WHERE id = $2
+    `, errorMsg, batch.ID)
+	if err != nil {
+		return fmt.Errorf("error flagging batch: %v", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        INSERT INTO audit_logs (entity_type, entity_id, action, old_data, new_data)
+        VALUES (
+            'batches', $1, 'REORG_AFTER_COMPLETION',
+            json_build_object('status', $2::text, 'source_tx_hash', $3::text, 'target_tx_hash', $4::text,
+                              'block_number', $5::bigint, 'block_hash', $6::text),
+            json_build_object('canonical_block_hash', $7::text)
+        )
+    `, batch.ID, batch.Status, batch.SourceTxHash, batch.TargetTxHash, *batch.BlockNumber, *batch.BlockHash, canonicalHash)
+	if err != nil {
+		return fmt.Errorf("error writing audit log: %v", err)
+	}
+
+	return tx.Commit()
+}
+
+// GetBatchSwaps returns the swaps of a batch in on-chain request order.
+func (db *Database) GetBatchSwaps(ctx context.Context, batchID int64) ([]*Swap, error) {
+	query := `
+        SELECT
+            s.id, s.request_id, s.from_chain_id, s.to_chain_id,
+            s.token_address, s.amount, s.recipient,
+            s.target_token_address, s.target_amount, s.dust,
+            s.status, s.error_message, s.created_at, s.updated_at
+        FROM swaps s
+        JOIN batch_swaps bs ON bs.swap_id = s.id
+        WHERE bs.batch_id = $1
+        ORDER BY bs.request_index
+    `
+
+	rows, err := db.db.QueryContext(ctx, query, batchID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting batch swaps: %v", err)
+	}
+	defer rows.Close()
+
+	var swaps []*Swap
+	for rows.Next() {
+		swap := &Swap{}
+		var tokenAddress, recipient, targetTokenAddress string
+		err := rows.Scan(
+			&swap.ID,
+			&swap.RequestID,
+			&swap.FromChainID,
+			&swap.ToChainID,
+			&tokenAddress,
+			&swap.Amount,
+			&recipient,
+			&targetTokenAddress,
+			&swap.TargetAmount,
+			&swap.Dust,
+			&swap.Status,
+			&swap.ErrorMessage,
+			&swap.CreatedAt,
+			&swap.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning batch swap: %v", err)
+		}
+		swap.TokenAddress = common.HexToAddress(tokenAddress)
+		swap.Recipient = common.HexToAddress(recipient)
+		swap.TargetTokenAddress = common.HexToAddress(targetTokenAddress)
+		swaps = append(swaps, swap)
+	}
+
+	return swaps, rows.Err()
+}
+
 // Hot wallet related functions
 func (db *Database) GetAvailableWallet(ctx context.Context, chainID int64) (*HotWallet, error) {
 	query := `
@@ -311,57 +924,86 @@ func (db *Database) GetAvailableWallet(ctx context.Context, chainID int64) (*Hot
 	return wallet, nil
 }
 
-func (db *Database) UpdateWalletNonce(ctx context.Context, walletID int64, nonce int64) error {
+// GetWalletNonce returns the next nonce recorded for a wallet on a chain.
+// The boolean is false when none has been recorded yet.
+func (db *Database) GetWalletNonce(ctx context.Context, address string, chainID int64) (uint64, bool, error) {
 	query := `
-        UPDATE hot_wallets
-        SET nonce = $1, updated_at = NOW()
-        WHERE id = $2
+        SELECT nonce
+        FROM hot_wallets
+        WHERE address = $1 AND chain_id = $2
     `
 
-	result, err := db.db.ExecContext(ctx, query, nonce, walletID)
-	if err != nil {
-		return fmt.Errorf("error updating wallet nonce: %v", err)
+	var nonce int64
+	err := db.db.QueryRowContext(ctx, query, address, chainID).Scan(&nonce)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
 	}
-
-	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("error getting rows affected: %v", err)
+		return 0, false, fmt.Errorf("error getting wallet nonce: %v", err)
 	}
 
-	if rowsAffected == 0 {
-		return errors.New("wallet not found")
+	return uint64(nonce), true, nil
+}
+
+// UpdateWalletNonce records the next nonce of a wallet on a chain, adding
+// the wallet's row for the chain if it has none.
+func (db *Database) UpdateWalletNonce(ctx context.Context, address string, chainID int64, nonce uint64) error {
+	query := `
+        INSERT INTO hot_wallets (address, chain_id, nonce)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (address, chain_id) DO UPDATE
+        SET nonce = EXCLUDED.nonce, updated_at = NOW()
+    `
+
+	_, err := db.db.ExecContext(ctx, query, address, chainID, int64(nonce))
+	if err != nil {
+		return fmt.Errorf("error updating wallet nonce: %v", err)
 	}
 
 	return nil
 }
 
 // Chain config related functions
-func (db *Database) GetChainConfig(ctx context.Context, chainID int64) (*ChainConfig, error) {
-	query := `
-        SELECT 
+const chainConfigColumns = `
             id, chain_id, chain_type, rpc_url,
-            bridge_address, required_confirmations,
-            max_gas_price, is_active, created_at, updated_at
-        FROM chain_configs
-        WHERE chain_id = $1 AND is_active = true
-    `
+            bridge_address, required_confirmations, finality_mode,
+            max_gas_price, stuck_tx_timeout_seconds, auto_approve, start_block,
+            min_wallet_balance, target_wallet_balance, is_active, created_at, updated_at
+`
 
+func scanChainConfig(row rowScanner) (*ChainConfig, error) {
 	config := &ChainConfig{}
-	err := db.db.QueryRowContext(ctx, query, chainID).Scan(
+	err := row.Scan(
 		&config.ID,
 		&config.ChainID,
 		&config.ChainType,
 		&config.RPCUrl,
 		&config.BridgeAddress,
 		&config.RequiredConfirmations,
+		&config.FinalityMode,
 		&config.MaxGasPrice,
+		&config.StuckTxTimeoutSeconds,
+		&config.AutoApprove,
+		&config.StartBlock,
+		&config.MinWalletBalance,
+		&config.TargetWalletBalance,
 		&config.IsActive,
 		&config.CreatedAt,
 		&config.UpdatedAt,
 	)
+	return config, err
+}
+
+func (db *Database) GetChainConfig(ctx context.Context, chainID int64) (*ChainConfig, error) {
+	query := `
+        SELECT` + chainConfigColumns + `
+        FROM chain_configs
+        WHERE chain_id = $1 AND is_active = true
+    `
 
+	config, err := scanChainConfig(db.db.QueryRowContext(ctx, query, chainID))
 	if err == sql.ErrNoRows {
-		return nil, errors.New("chain config not found")
+		return nil, ErrChainConfigNotFound
 	}
 	if err != nil {
 		return nil, fmt.Errorf("error getting chain config: %v", err)
@@ -370,6 +1012,125 @@ func (db *Database) GetChainConfig(ctx context.Context, chainID int64) (*ChainCo
 	return config, nil
 }
 
+// GetChainConfigs returns the configs of every chain, active or not,
+// ordered by chain ID.
+func (db *Database) GetChainConfigs(ctx context.Context) ([]*ChainConfig, error) {
+	query := `
+        SELECT` + chainConfigColumns + `
+        FROM chain_configs
+        ORDER BY chain_id
+    `
+
+	rows, err := db.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("error getting chain configs: %v", err)
+	}
+	defer rows.Close()
+
+	var configs []*ChainConfig
+	for rows.Next() {
+		config, err := scanChainConfig(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning chain config: %v", err)
+		}
+		configs = append(configs, config)
+	}
+
+	return configs, rows.Err()
+}
+
+// GetTokenMappings returns the active token mappings whose tokens are both
+// listed in supported_tokens.
+func (db *Database) GetTokenMappings(ctx context.Context) ([]*TokenMapping, error) {
+	query := `
+        SELECT
+            m.id, m.source_chain_id, m.source_token, s.token_decimals,
+            m.target_chain_id, m.target_token, t.token_decimals
+        FROM token_mappings m
+        JOIN supported_tokens s
+            ON s.chain_id = m.source_chain_id AND LOWER(s.token_address) = LOWER(m.source_token)
+        JOIN supported_tokens t
+            ON t.chain_id = m.target_chain_id AND LOWER(t.token_address) = LOWER(m.target_token)
+        WHERE m.is_active = true
+        ORDER BY m.id
+    `
+
+	rows, err := db.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("error getting token mappings: %v", err)
+	}
+	defer rows.Close()
+
+	var mappings []*TokenMapping
+	for rows.Next() {
+		mapping := &TokenMapping{}
+		var sourceToken, targetToken string
+		err := rows.Scan(
+			&mapping.ID,
+			&mapping.SourceChainID,
+			&sourceToken,
+			&mapping.SourceDecimals,
+			&mapping.TargetChainID,
+			&targetToken,
+			&mapping.TargetDecimals,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning token mapping: %v", err)
+		}
+		mapping.SourceToken = common.HexToAddress(sourceToken)
+		mapping.TargetToken = common.HexToAddress(targetToken)
+		mappings = append(mappings, mapping)
+	}
+
+	return mappings, rows.Err()
+}
+
+// GetSupportedTokens returns every listed token, active or not.
+func (db *Database) GetSupportedTokens(ctx context.Context) ([]*SupportedToken, error) {
+	query := `
+        SELECT id, chain_id, token_address, token_symbol, token_decimals,
+            min_amount, max_amount, is_active
+        FROM supported_tokens
+        ORDER BY chain_id, id
+    `
+
+	rows, err := db.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("error getting supported tokens: %v", err)
+	}
+	defer rows.Close()
+
+	var tokens []*SupportedToken
+	for rows.Next() {
+		token := &SupportedToken{}
+		var address, minAmount, maxAmount string
+		err := rows.Scan(
+			&token.ID,
+			&token.ChainID,
+			&address,
+			&token.Symbol,
+			&token.Decimals,
+			&minAmount,
+			&maxAmount,
+			&token.IsActive,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning supported token: %v", err)
+		}
+		token.Address = common.HexToAddress(address)
+		var ok bool
+		if token.MinAmount, ok = new(big.Int).SetString(minAmount, 10); !ok {
+			return nil, fmt.Errorf("invalid min_amount %q for token %s", minAmount, address)
+		}
+		if token.MaxAmount, ok = new(big.Int).SetString(maxAmount, 10); !ok {
+			return nil, fmt.Errorf("invalid max_amount %q for token %s", maxAmount, address)
+		}
+		tokens = append(tokens, token)
+	}
+
+	return tokens, rows.Err()
+}
+
 // Statistics and monitoring
 func (db *Database) GetSwapStatistics(ctx context.Context, fromTime time.Time) (map[string]interface{}, error) {
 	query := `
@@ -383,18 +1144,23 @@ func (db *Database) GetSwapStatistics(ctx context.Context, fromTime time.Time) (
     `
 
 	var stats = make(map[string]interface{})
+	var totalSwaps, completedSwaps, failedSwaps int64
 	var avgProcessingTime *float64
 
 	err := db.db.QueryRowContext(ctx, query, fromTime).Scan(
-		&stats["total_swaps"],
-		&stats["completed_swaps"],
-		&stats["failed_swaps"],
+		&totalSwaps,
+		&completedSwaps,
+		&failedSwaps,
 		&avgProcessingTime,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("error getting swap statistics: %v", err)
 	}
 
+	stats["total_swaps"] = totalSwaps
+	stats["completed_swaps"] = completedSwaps
+	stats["failed_swaps"] = failedSwaps
+
 	if avgProcessingTime != nil {
 		stats["avg_processing_time_seconds"] = *avgProcessingTime
 	}
@@ -407,13 +1173,17 @@ func (db *Database) GetWalletPerformance(ctx context.Context) ([]map[string]inte
         SELECT 
             w.address,
             w.chain_id,
-            COUNT(DISTINCT b.id) as total_batches,
-            COUNT(DISTINCT bs.swap_id) as total_swaps,
+            COUNT(b.id) as total_batches,
+            COALESCE(SUM(bs.swaps), 0) as total_swaps,
             AVG(CAST(b.gas_price AS NUMERIC)) as avg_gas_price,
-            SUM(b.gas_used) as total_gas_used
+            SUM(b.gas_used) as total_gas_used,
+            SUM(b.execution_fee) as total_execution_fee,
+            SUM(b.l1_data_fee) as total_l1_data_fee
         FROM hot_wallets w
-        LEFT JOIN batches b ON w.address = b.wallet_address
-        LEFT JOIN batch_swaps bs ON b.id = bs.batch_id
+        LEFT JOIN batches b ON w.address = b.wallet_address AND w.chain_id = b.chain_id
+        LEFT JOIN (
+            SELECT batch_id, COUNT(*) as swaps FROM batch_swaps GROUP BY batch_id
+        ) bs ON b.id = bs.batch_id
         WHERE w.is_active = true
         GROUP BY w.address, w.chain_id
     `
@@ -427,26 +1197,42 @@ func (db *Database) GetWalletPerformance(ctx context.Context) ([]map[string]inte
 	var performance []map[string]interface{}
 	for rows.Next() {
 		var wallet = make(map[string]interface{})
+		var address string
+		var chainID, totalBatches, totalSwaps int64
 		var avgGasPrice, totalGasUsed *float64
+		var totalExecutionFee, totalL1DataFee *string
 
 		err := rows.Scan(
-			&wallet["address"],
-			&wallet["chain_id"],
-			&wallet["total_batches"],
-			&wallet["total_swaps"],
+			&address,
+			&chainID,
+			&totalBatches,
+			&totalSwaps,
 			&avgGasPrice,
 			&totalGasUsed,
+			&totalExecutionFee,
+			&totalL1DataFee,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("error scanning wallet performance: %v", err)
 		}
 
+		wallet["address"] = address
+		wallet["chain_id"] = chainID
+		wallet["total_batches"] = totalBatches
+		wallet["total_swaps"] = totalSwaps
+
 		if avgGasPrice != nil {
 			wallet["avg_gas_price"] = *avgGasPrice
 		}
 		if totalGasUsed != nil {
 			wallet["total_gas_used"] = *totalGasUsed
 		}
+		if totalExecutionFee != nil {
+			wallet["total_execution_fee"] = *totalExecutionFee
+		}
+		if totalL1DataFee != nil {
+			wallet["total_l1_data_fee"] = *totalL1DataFee
+		}
 
 		performance = append(performance, wallet)
 	}
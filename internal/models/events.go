@@ -0,0 +1,159 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+const (
+	EventBatchSwapInitiated = "BatchSwapInitiated"
+	EventBatchSwapCompleted = "BatchSwapCompleted"
+)
+
+// BridgeEvent is a decoded BatchBridge log. Payload holds the event's
+// decoded SwapRequest[] (initiated) or source and target chains (completed)
+// as JSON. SourceChainID is set for completed events, whose batch id is
+// only unique together with the chain the batch came from.
+type BridgeEvent struct {
+	ID             int64
+	ChainID        int64
+	EventName      string
+	OnchainBatchID string
+	BlockNumber    int64
+	BlockHash      string
+	TxHash         string
+	LogIndex       int
+	Payload        []byte
+	BatchID        *int64
+	SourceChainID  *int64
+	CreatedAt      time.Time
+}
+
+// Checkpoint is the range of blocks indexed for a chain. FirstBlock is nil
+// for ranges indexed before it was recorded.
+type Checkpoint struct {
+	FirstBlock *uint64
+	LastBlock  uint64
+}
+
+// GetCheckpoint returns the blocks indexed for a chain, or nil when the
+// chain has never been indexed.
+func (db *Database) GetCheckpoint(ctx context.Context, chainID int64) (*Checkpoint, error) {
+	query := `
+        SELECT first_block, last_block
+        FROM chain_checkpoints
+        WHERE chain_id = $1
+    `
+
+	var firstBlock sql.NullInt64
+	var lastBlock int64
+	err := db.db.QueryRowContext(ctx, query, chainID).Scan(&firstBlock, &lastBlock)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting checkpoint: %v", err)
+	}
+
+	checkpoint := &Checkpoint{LastBlock: uint64(lastBlock)}
+	if firstBlock.Valid {
+		first := uint64(firstBlock.Int64)
+		checkpoint.FirstBlock = &first
+	}
+	return checkpoint, nil
+}
+
+// MarkBackfilled records that a chain has been indexed from fromBlock up to
+// its checkpoint.
+func (db *Database) MarkBackfilled(ctx context.Context, chainID int64, fromBlock uint64) error {
+	query := `
+        UPDATE chain_checkpoints
+        SET first_block = LEAST(COALESCE(first_block, $2), $2), updated_at = NOW()
+        WHERE chain_id = $1
+    `
+
+	if _, err := db.db.ExecContext(ctx, query, chainID, int64(fromBlock)); err != nil {
+		return fmt.Errorf("error updating checkpoint: %v", err)
+	}
+	return nil
+}
+
+// SaveBridgeEvents stores the events of blocks fromBlock to lastBlock, links
+// them to their batches and advances the chain checkpoint to lastBlock in
+// one transaction, so a crash never leaves events recorded without the
+// checkpoint or the other way round. The first range saved for a chain
+// starts its checkpoint at fromBlock. Events already stored are skipped and
+// the checkpoint never moves back, which makes re-scanning a range
+// (backfill) safe.
+func (db *Database) SaveBridgeEvents(ctx context.Context, chainID int64, events []*BridgeEvent, fromBlock, lastBlock uint64) error {
+	tx, err := db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	for _, event := range events {
+		var batchID sql.NullInt64
+		switch event.EventName {
+		case EventBatchSwapInitiated:
+			err = tx.QueryRowContext(ctx, `
+                UPDATE batches
+                SET onchain_batch_id = $1, updated_at = NOW()
+                WHERE chain_id = $2 AND source_tx_hash = $3
+                RETURNING id
+            `, event.OnchainBatchID, chainID, event.TxHash).Scan(&batchID)
+		case EventBatchSwapCompleted:
+			err = tx.QueryRowContext(ctx, `
+                UPDATE batches
+                SET target_tx_hash = $1, updated_at = NOW()
+                WHERE onchain_batch_id = $2 AND target_chain_id = $3 AND chain_id = $4
+                RETURNING id
+            `, event.TxHash, event.OnchainBatchID, chainID, event.SourceChainID).Scan(&batchID)
+		default:
+			return fmt.Errorf("unknown bridge event %q", event.EventName)
+		}
+		if err != nil && err != sql.ErrNoRows {
+			return fmt.Errorf("error linking %s event: %v", event.EventName, err)
+		}
+		if batchID.Valid {
+			event.BatchID = &batchID.Int64
+		}
+
+		_, err = tx.ExecContext(ctx, `
+            INSERT INTO bridge_events (
+                chain_id, event_name, onchain_batch_id,
+                block_number, block_hash, tx_hash, log_index,
+                payload, batch_id
+            ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+            ON CONFLICT (chain_id, tx_hash, log_index) DO NOTHING
+        `,
+			chainID,
+			event.EventName,
+			event.OnchainBatchID,
+			event.BlockNumber,
+			event.BlockHash,
+			event.TxHash,
+			event.LogIndex,
+			event.Payload,
+			event.BatchID,
+		)
+		if err != nil {
+			return fmt.Errorf("error inserting bridge event: %v", err)
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        INSERT INTO chain_checkpoints (chain_id, first_block, last_block)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (chain_id) DO UPDATE
+        SET last_block = GREATEST(chain_checkpoints.last_block, EXCLUDED.last_block),
+            updated_at = NOW()
+    `, chainID, int64(fromBlock), int64(lastBlock))
+	if err != nil {
+		return fmt.Errorf("error updating checkpoint: %v", err)
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,169 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lib/pq"
+)
+
+// HotWalletState is where a hot wallet is in its lifecycle, across the
+// chains it has rows for in hot_wallets. A wallet is active while any of
+// its rows is. An active wallet with DrainTo set is being drained: it gets
+// no new work, and its balances are swept to DrainTo on each chain, which
+// is then listed in DrainedChains.
+type HotWalletState struct {
+	Address       common.Address  `json:"address"`
+	IsActive      bool            `json:"isActive"`
+	DrainTo       *common.Address `json:"drainTo,omitempty"`
+	DrainedChains []int64         `json:"drainedChains"`
+}
+
+// Drained reports whether the wallet has been drained on chainID.
+func (w *HotWalletState) Drained(chainID int64) bool {
+	for _, id := range w.DrainedChains {
+		if id == chainID {
+			return true
+		}
+	}
+	return false
+}
+
+// GetHotWalletStates returns the state of every wallet in hot_wallets,
+// ordered by address.
+func (db *Database) GetHotWalletStates(ctx context.Context) ([]*HotWalletState, error) {
+	query := `
+        SELECT MIN(address), BOOL_OR(is_active),
+               MAX(drain_to) FILTER (WHERE is_active),
+               COALESCE(ARRAY_AGG(chain_id ORDER BY chain_id) FILTER (WHERE is_active AND drained_at IS NOT NULL), '{}')
+        FROM hot_wallets
+        GROUP BY LOWER(address)
+        ORDER BY LOWER(address)
+    `
+
+	rows, err := db.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("error getting hot wallets: %v", err)
+	}
+	defer rows.Close()
+
+	var wallets []*HotWalletState
+	for rows.Next() {
+		var address string
+		var drainTo sql.NullString
+		var drained pq.Int64Array
+		wallet := &HotWalletState{}
+		if err := rows.Scan(&address, &wallet.IsActive, &drainTo, &drained); err != nil {
+			return nil, fmt.Errorf("error scanning hot wallet: %v", err)
+		}
+		wallet.Address = common.HexToAddress(address)
+		if drainTo.Valid {
+			to := common.HexToAddress(drainTo.String)
+			wallet.DrainTo = &to
+		}
+		wallet.DrainedChains = drained
+		wallets = append(wallets, wallet)
+	}
+
+	return wallets, rows.Err()
+}
+
+// insertHotWalletRows adds a row for address on each of chainIDs it has
+// none on.
+func insertHotWalletRows(ctx context.Context, tx *sql.Tx, address string, chainIDs []int64) error {
+	_, err := tx.ExecContext(ctx, `
+        INSERT INTO hot_wallets (address, chain_id)
+        SELECT $1, c.chain_id
+        FROM UNNEST($2::BIGINT[]) AS c(chain_id)
+        WHERE NOT EXISTS (
+            SELECT 1 FROM hot_wallets w
+            WHERE LOWER(w.address) = LOWER($1) AND w.chain_id = c.chain_id
+        )
+    `, address, pq.Array(chainIDs))
+	if err != nil {
+		return fmt.Errorf("error inserting hot wallet: %v", err)
+	}
+	return nil
+}
+
+// AddHotWallet makes address an active hot wallet, with a row on each of
+// chainIDs. A drained or retired wallet is put back to work.
+func (db *Database) AddHotWallet(ctx context.Context, address string, chainIDs []int64) error {
+	tx, err := db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	if err := insertHotWalletRows(ctx, tx, address, chainIDs); err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `
+        UPDATE hot_wallets
+        SET is_active = true, drain_to = NULL, drained_at = NULL, updated_at = NOW()
+        WHERE LOWER(address) = LOWER($1)
+    `, address)
+	if err != nil {
+		return fmt.Errorf("error activating hot wallet: %v", err)
+	}
+
+	return tx.Commit()
+}
+
+// DrainHotWallet starts draining address into to on each of chainIDs and
+// on the other chains the wallet is active on.
+func (db *Database) DrainHotWallet(ctx context.Context, address, to string, chainIDs []int64) error {
+	tx, err := db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	if err := insertHotWalletRows(ctx, tx, address, chainIDs); err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `
+        UPDATE hot_wallets
+        SET drain_to = $2, drained_at = NULL, updated_at = NOW()
+        WHERE LOWER(address) = LOWER($1) AND is_active = true
+    `, address, to)
+	if err != nil {
+		return fmt.Errorf("error draining hot wallet: %v", err)
+	}
+
+	return tx.Commit()
+}
+
+// MarkHotWalletDrained records that address has been swept on chainID.
+func (db *Database) MarkHotWalletDrained(ctx context.Context, address string, chainID int64) error {
+	query := `
+        UPDATE hot_wallets
+        SET drained_at = NOW(), updated_at = NOW()
+        WHERE LOWER(address) = LOWER($1) AND chain_id = $2
+    `
+
+	_, err := db.db.ExecContext(ctx, query, address, chainID)
+	if err != nil {
+		return fmt.Errorf("error marking hot wallet drained: %v", err)
+	}
+
+	return nil
+}
+
+// RetireHotWallet marks address inactive on every chain.
+func (db *Database) RetireHotWallet(ctx context.Context, address string) error {
+	query := `
+        UPDATE hot_wallets
+        SET is_active = false, updated_at = NOW()
+        WHERE LOWER(address) = LOWER($1)
+    `
+
+	_, err := db.db.ExecContext(ctx, query, address)
+	if err != nil {
+		return fmt.Errorf("error retiring hot wallet: %v", err)
+	}
+
+	return nil
+}
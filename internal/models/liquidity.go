@@ -0,0 +1,84 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Obligation is what the swaps still to be paid out on a chain owe in one
+// token.
+type Obligation struct {
+	ChainID int64
+	Token   common.Address
+	Amount  *big.Int
+}
+
+// BridgeLiquidity is a bridge's balance of a token next to what it owes in
+// that token.
+type BridgeLiquidity struct {
+	ChainID     int64
+	Token       common.Address
+	Balance     *big.Int
+	Obligations *big.Int
+	UpdatedAt   time.Time
+}
+
+// GetObligations sums the target amounts of the swaps to chainID that have
+// not been completed or given up on, per target token.
+func (db *Database) GetObligations(ctx context.Context, chainID int64) ([]*Obligation, error) {
+	query := `
+        SELECT LOWER(target_token_address), SUM(target_amount)
+        FROM swaps
+        WHERE to_chain_id = $1 AND status NOT IN ('completed', 'failed', 'reverted')
+        GROUP BY LOWER(target_token_address)
+    `
+
+	rows, err := db.db.QueryContext(ctx, query, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting obligations: %v", err)
+	}
+	defer rows.Close()
+
+	var obligations []*Obligation
+	for rows.Next() {
+		var token, amount string
+		if err := rows.Scan(&token, &amount); err != nil {
+			return nil, fmt.Errorf("error scanning obligation: %v", err)
+		}
+		sum, ok := new(big.Int).SetString(amount, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid obligation %q for token %s", amount, token)
+		}
+		obligations = append(obligations, &Obligation{ChainID: chainID, Token: common.HexToAddress(token), Amount: sum})
+	}
+
+	return obligations, rows.Err()
+}
+
+// SaveBridgeLiquidity records the latest balance and obligations of a
+// bridge in a token.
+func (db *Database) SaveBridgeLiquidity(ctx context.Context, liquidity *BridgeLiquidity) error {
+	query := `
+        INSERT INTO bridge_liquidity (chain_id, token_address, balance, obligations)
+        VALUES ($1, $2, $3, $4)
+        ON CONFLICT (chain_id, token_address) DO UPDATE
+        SET balance = EXCLUDED.balance, obligations = EXCLUDED.obligations, updated_at = NOW()
+    `
+
+	_, err := db.db.ExecContext(
+		ctx,
+		query,
+		liquidity.ChainID,
+		liquidity.Token.Hex(),
+		liquidity.Balance.String(),
+		liquidity.Obligations.String(),
+	)
+	if err != nil {
+		return fmt.Errorf("error saving bridge liquidity: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Statuses of a wallet top-up.
+const (
+	TopUpPending   = "pending"
+	TopUpConfirmed = "confirmed"
+	TopUpFailed    = "failed"
+)
+
+// WalletTopUp is a transfer of native coin from the treasury to a hot
+// wallet running low on gas.
+type WalletTopUp struct {
+	ID            int64          `json:"id"`
+	ChainID       int64          `json:"chainId"`
+	WalletAddress common.Address `json:"walletAddress"`
+	Treasury      common.Address `json:"treasury"`
+	Amount        string         `json:"amount"`
+	TxHash        string         `json:"txHash"`
+	Status        string         `json:"status"`
+	ErrorMessage  *string        `json:"errorMessage,omitempty"`
+	CreatedAt     time.Time      `json:"createdAt"`
+	UpdatedAt     time.Time      `json:"updatedAt"`
+}
+
+const topUpColumns = `
+            id, chain_id, wallet_address, treasury_address, amount, tx_hash,
+            status, error_message, created_at, updated_at
+`
+
+func scanTopUp(row rowScanner) (*WalletTopUp, error) {
+	topUp := &WalletTopUp{}
+	var wallet, treasury string
+	err := row.Scan(
+		&topUp.ID,
+		&topUp.ChainID,
+		&wallet,
+		&treasury,
+		&topUp.Amount,
+		&topUp.TxHash,
+		&topUp.Status,
+		&topUp.ErrorMessage,
+		&topUp.CreatedAt,
+		&topUp.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	topUp.WalletAddress = common.HexToAddress(wallet)
+	topUp.Treasury = common.HexToAddress(treasury)
+	return topUp, nil
+}
+
+// CreateWalletTopUp records a top-up transaction that has been sent.
+func (db *Database) CreateWalletTopUp(ctx context.Context, topUp *WalletTopUp) error {
+	query := `
+        INSERT INTO wallet_topups (chain_id, wallet_address, treasury_address, amount, tx_hash, status)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        RETURNING id, created_at, updated_at
+    `
+
+	err := db.db.QueryRowContext(
+		ctx,
+		query,
+		topUp.ChainID,
+		topUp.WalletAddress.Hex(),
+		topUp.Treasury.Hex(),
+		topUp.Amount,
+		topUp.TxHash,
+		topUp.Status,
+	).Scan(&topUp.ID, &topUp.CreatedAt, &topUp.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("error creating wallet top-up: %v", err)
+	}
+	return nil
+}
+
+// GetPendingWalletTopUps returns the top-ups on chainID whose transaction
+// has not been mined yet.
+func (db *Database) GetPendingWalletTopUps(ctx context.Context, chainID int64) ([]*WalletTopUp, error) {
+	query := `
+        SELECT` + topUpColumns + `
+        FROM wallet_topups
+        WHERE chain_id = $1 AND status = 'pending'
+        ORDER BY id
+    `
+	return db.queryTopUps(ctx, query, chainID)
+}
+
+// GetWalletTopUps returns the most recent top-ups on every chain, newest
+// first.
+func (db *Database) GetWalletTopUps(ctx context.Context, limit int) ([]*WalletTopUp, error) {
+	query := `
+        SELECT` + topUpColumns + `
+        FROM wallet_topups
+        ORDER BY id DESC
+        LIMIT $1
+    `
+	return db.queryTopUps(ctx, query, limit)
+}
+
+func (db *Database) queryTopUps(ctx context.Context, query string, args ...interface{}) ([]*WalletTopUp, error) {
+	rows, err := db.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting wallet top-ups: %v", err)
+	}
+	defer rows.Close()
+
+	var topUps []*WalletTopUp
+	for rows.Next() {
+		topUp, err := scanTopUp(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning wallet top-up: %v", err)
+		}
+		topUps = append(topUps, topUp)
+	}
+
+	return topUps, rows.Err()
+}
+
+// UpdateWalletTopUpStatus settles a pending top-up.
+func (db *Database) UpdateWalletTopUpStatus(ctx context.Context, id int64, status string, errorMessage *string) error {
+	query := `
+        UPDATE wallet_topups
+        SET status = $1, error_message = $2
+        WHERE id = $3
+    `
+
+	_, err := db.db.ExecContext(ctx, query, status, errorMessage, id)
+	if err != nil {
+		return fmt.Errorf("error updating wallet top-up: %v", err)
+	}
+	return nil
+}
+
+// UpdateWalletBalance records the native balance of a wallet on a chain,
+// adding the wallet's row for the chain if it has none.
+func (db *Database) UpdateWalletBalance(ctx context.Context, address string, chainID int64, balance *big.Int) error {
+	query := `
+        INSERT INTO hot_wallets (address, chain_id, native_balance, balance_updated_at)
+        VALUES ($1, $2, $3, NOW())
+        ON CONFLICT (address, chain_id) DO UPDATE
+        SET native_balance = EXCLUDED.native_balance, balance_updated_at = NOW()
+    `
+
+	_, err := db.db.ExecContext(ctx, query, address, chainID, balance.String())
+	if err != nil {
+		return fmt.Errorf("error updating wallet balance: %v", err)
+	}
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 )
 
 type SwapRequest struct {
+	ID           int64          `json:"-"`
 	RequestID    string         `json:"requestId"`
 	FromChainID  int64          `json:"fromChainId"`
 	ToChainID    int64          `json:"toChainId"`
@@ -18,14 +19,19 @@ type SwapRequest struct {
 }
 
 type SwapStatus struct {
-	RequestID    string    `json:"requestId"`
-	Status       string    `json:"status"`
-	FromChainID  int64     `json:"fromChainId"`
-	ToChainID    int64     `json:"toChainId"`
-	SourceTxHash string    `json:"sourceTxHash,omitempty"`
-	TargetTxHash string    `json:"targetTxHash,omitempty"`
-	CreatedAt    time.Time `json:"createdAt"`
-	UpdatedAt    time.Time `json:"updatedAt"`
+	RequestID   string `json:"requestId"`
+	Status      string `json:"status"`
+	FromChainID int64  `json:"fromChainId"`
+	ToChainID   int64  `json:"toChainId"`
+	// TargetTokenAddress and TargetAmount are paid out on the destination
+	// chain; Dust is what the conversion between decimals left behind.
+	TargetTokenAddress string    `json:"targetTokenAddress"`
+	TargetAmount       string    `json:"targetAmount"`
+	Dust               string    `json:"dust"`
+	SourceTxHash       string    `json:"sourceTxHash,omitempty"`
+	TargetTxHash       string    `json:"targetTxHash,omitempty"`
+	CreatedAt          time.Time `json:"createdAt"`
+	UpdatedAt          time.Time `json:"updatedAt"`
 }
 
 type QueueStatus struct {
@@ -33,3 +39,33 @@ type QueueStatus struct {
 	MaxSize       int `json:"maxSize"`
 	ActiveBatches int `json:"activeBatches"`
 }
+
+// Rules a swap request can fail validation on.
+const (
+	RuleUnknownChain     = "unknown_chain"
+	RuleSameChain        = "same_chain"
+	RuleInvalidAddress   = "invalid_address"
+	RuleInvalidAmount    = "invalid_amount"
+	RuleUnsupportedToken = "unsupported_token"
+	RuleInactiveToken    = "inactive_token"
+	RuleBelowMinAmount   = "below_min_amount"
+	RuleAboveMaxAmount   = "above_max_amount"
+	RuleNoTokenMapping   = "no_token_mapping"
+	RuleAmountTooSmall   = "amount_too_small"
+	// RuleInsufficientLiquidity is failed when the destination bridge cannot
+	// cover the swap on top of what it already owes.
+	RuleInsufficientLiquidity = "insufficient_liquidity"
+)
+
+// ValidationError is returned for a swap request that breaks one of the
+// validation rules. ChainID is set when the rule concerns one chain of the
+// swap.
+type ValidationError struct {
+	Rule    string `json:"rule"`
+	Message string `json:"error"`
+	ChainID int64  `json:"chainId,omitempty"`
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
@@ -0,0 +1,480 @@
+// Package multirpc spreads the RPC traffic of a chain over several
+// endpoints, failing over from endpoints that error or fall behind and
+// optionally requiring a quorum of them to agree on critical reads.
+package multirpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"net"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	DefaultHealthInterval = 15 * time.Second
+	DefaultMaxLag         = 3
+)
+
+// ErrNoQuorum is returned by a quorum read the endpoints disagree on.
+var ErrNoQuorum = errors.New("rpc endpoints did not reach quorum")
+
+// Config tunes how a Client uses its endpoints.
+type Config struct {
+	// Quorum is how many endpoints must return the same receipt, block
+	// header or logs for the read to succeed. Zero or one reads from a
+	// single endpoint.
+	Quorum int
+	// MaxLag is how many blocks an endpoint's head may trail the highest
+	// head among the endpoints before it is taken out of rotation.
+	MaxLag uint64
+	// HealthInterval is how often the endpoints' heads are checked.
+	HealthInterval time.Duration
+}
+
+// endpoint is one RPC URL of the chain and its last known health.
+type endpoint struct {
+	url     string
+	client  *ethclient.Client
+	healthy bool
+}
+
+// Client is an RPC client for one chain backed by several endpoints. It
+// satisfies processor.ChainClient. Calls go to the first healthy endpoint,
+// in the order the endpoints were given, and move on to the next one when
+// an endpoint fails to answer; errors the node answers with, such as a
+// reverted call or a missing receipt, are returned as they are.
+type Client struct {
+	endpoints []*endpoint
+	config    Config
+	mutex     sync.RWMutex
+}
+
+// Dial connects to every URL and checks their heads once. With more than one
+// endpoint the heads are checked again every config.HealthInterval until ctx
+// is cancelled.
+func Dial(ctx context.Context, urls []string, config Config) (*Client, error) {
+	if len(urls) == 0 {
+		return nil, errors.New("no rpc endpoints")
+	}
+	if config.MaxLag == 0 {
+		config.MaxLag = DefaultMaxLag
+	}
+	if config.HealthInterval == 0 {
+		config.HealthInterval = DefaultHealthInterval
+	}
+	if config.Quorum > len(urls) {
+		return nil, fmt.Errorf("quorum of %d needs at least as many endpoints, got %d", config.Quorum, len(urls))
+	}
+
+	c := &Client{config: config}
+	for _, url := range urls {
+		client, err := ethclient.DialContext(ctx, url)
+		if err != nil {
+			c.Close()
+			return nil, fmt.Errorf("error connecting to %s: %v", url, err)
+		}
+		c.endpoints = append(c.endpoints, &endpoint{url: url, client: client, healthy: true})
+	}
+
+	c.checkHealth(ctx)
+	if len(c.endpoints) > 1 {
+		go c.monitor(ctx)
+	}
+	return c, nil
+}
+
+// Close closes the connections to every endpoint.
+func (c *Client) Close() {
+	for _, ep := range c.endpoints {
+		ep.client.Close()
+	}
+}
+
+func (c *Client) monitor(ctx context.Context) {
+	ticker := time.NewTicker(c.config.HealthInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.checkHealth(ctx)
+		}
+	}
+}
+
+// checkHealth reads every endpoint's head. Endpoints that fail to answer or
+// trail the highest head by more than MaxLag blocks are unhealthy until a
+// later check finds them caught up.
+func (c *Client) checkHealth(ctx context.Context) {
+	heads := make([]uint64, len(c.endpoints))
+	errs := make([]error, len(c.endpoints))
+
+	var wg sync.WaitGroup
+	for i, ep := range c.endpoints {
+		wg.Add(1)
+		go func(i int, ep *endpoint) {
+			defer wg.Done()
+			heads[i], errs[i] = ep.client.BlockNumber(ctx)
+		}(i, ep)
+	}
+	wg.Wait()
+
+	var best uint64
+	for i := range c.endpoints {
+		if errs[i] == nil && heads[i] > best {
+			best = heads[i]
+		}
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for i, ep := range c.endpoints {
+		err := errs[i]
+		if err == nil && best-heads[i] > c.config.MaxLag {
+			err = fmt.Errorf("head %d is %d blocks behind", heads[i], best-heads[i])
+		}
+		c.setHealth(ep, err)
+	}
+}
+
+// markDown takes an endpoint out of rotation after a failed call.
+func (c *Client) markDown(ep *endpoint, err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.setHealth(ep, err)
+}
+
+// setHealth records the outcome of talking to ep. Callers must hold mutex.
+func (c *Client) setHealth(ep *endpoint, err error) {
+	healthy := err == nil
+	if healthy != ep.healthy {
+		if healthy {
+			log.Printf("multirpc: %s is back in rotation", ep.url)
+		} else {
+			log.Printf("multirpc: %s taken out of rotation: %v", ep.url, err)
+		}
+	}
+	ep.healthy = healthy
+}
+
+// ordered returns the healthy endpoints followed by the unhealthy ones,
+// which are only tried when no healthy endpoint answers.
+func (c *Client) ordered() []*endpoint {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	endpoints := make([]*endpoint, 0, len(c.endpoints))
+	for _, ep := range c.endpoints {
+		if ep.healthy {
+			endpoints = append(endpoints, ep)
+		}
+	}
+	for _, ep := range c.endpoints {
+		if !ep.healthy {
+			endpoints = append(endpoints, ep)
+		}
+	}
+	return endpoints
+}
+
+func (c *Client) healthy() []*endpoint {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	var endpoints []*endpoint
+	for _, ep := range c.endpoints {
+		if ep.healthy {
+			endpoints = append(endpoints, ep)
+		}
+	}
+	return endpoints
+}
+
+// isAnswer reports whether err is the node's answer to a call rather than a
+// failure to reach it, so that trying another endpoint would not help.
+func isAnswer(err error) bool {
+	if errors.Is(err, ethereum.NotFound) || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var rpcErr rpc.Error
+	return errors.As(err, &rpcErr)
+}
+
+// isKnown reports whether err is an endpoint rejecting a transaction it
+// already has.
+func isKnown(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "already known") || strings.Contains(msg, "known transaction") || strings.Contains(msg, "alreadyknown")
+}
+
+func isNonceTooLow(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "nonce too low")
+}
+
+// mayHaveReached reports whether a request that failed may still have
+// reached the endpoint: it timed out, or the connection dropped before the
+// answer came back.
+func mayHaveReached(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET)
+}
+
+// failover runs call against each endpoint in turn until one answers.
+func failover[T any](c *Client, call func(*ethclient.Client) (T, error)) (T, error) {
+	var zero T
+	var lastErr error
+	for _, ep := range c.ordered() {
+		value, err := call(ep.client)
+		if err == nil || isAnswer(err) {
+			return value, err
+		}
+		c.markDown(ep, err)
+		lastErr = fmt.Errorf("%s: %v", ep.url, err)
+	}
+	return zero, fmt.Errorf("no rpc endpoint answered: %v", lastErr)
+}
+
+// quorum runs call against every healthy endpoint at once and returns the
+// answer at least Quorum of them agree on. Without a quorum configured it
+// is failover.
+func quorum[T any](c *Client, call func(*ethclient.Client) (T, error)) (T, error) {
+	var zero T
+	if c.config.Quorum <= 1 {
+		return failover(c, call)
+	}
+
+	endpoints := c.healthy()
+	if len(endpoints) < c.config.Quorum {
+		return zero, fmt.Errorf("%w: %d healthy endpoints, quorum is %d", ErrNoQuorum, len(endpoints), c.config.Quorum)
+	}
+
+	type answer struct {
+		value T
+		err   error
+	}
+	answers := make([]answer, len(endpoints))
+	var wg sync.WaitGroup
+	for i, ep := range endpoints {
+		wg.Add(1)
+		go func(i int, ep *endpoint) {
+			defer wg.Done()
+			answers[i].value, answers[i].err = call(ep.client)
+		}(i, ep)
+	}
+	wg.Wait()
+
+	votes := make(map[string]int)
+	for i, a := range answers {
+		if a.err != nil && !isAnswer(a.err) {
+			c.markDown(endpoints[i], a.err)
+			continue
+		}
+		key, err := answerKey(a.value, a.err)
+		if err != nil {
+			return zero, err
+		}
+		votes[key]++
+		if votes[key] >= c.config.Quorum {
+			return a.value, a.err
+		}
+	}
+	return zero, fmt.Errorf("%w: %d endpoints gave %d different answers", ErrNoQuorum, len(endpoints), len(votes))
+}
+
+// answerKey identifies an answer so that identical ones can be counted.
+func answerKey(value any, err error) (string, error) {
+	if err != nil {
+		return "error: " + err.Error(), nil
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("error encoding rpc answer: %v", err)
+	}
+	return string(encoded), nil
+}
+
+// isExact reports whether number names a fixed block rather than a tag such
+// as latest, whose block differs between endpoints that are a block apart.
+func isExact(number *big.Int) bool {
+	return number != nil && number.Sign() >= 0
+}
+
+// TransactionReceipt is a quorum read.
+func (c *Client) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return quorum(c, func(client *ethclient.Client) (*types.Receipt, error) {
+		return client.TransactionReceipt(ctx, txHash)
+	})
+}
+
+// HeaderByNumber is a quorum read for a fixed block number. The latest and
+// other tagged blocks are read from a single endpoint.
+func (c *Client) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	call := func(client *ethclient.Client) (*types.Header, error) {
+		return client.HeaderByNumber(ctx, number)
+	}
+	if !isExact(number) {
+		return failover(c, call)
+	}
+	return quorum(c, call)
+}
+
+// FilterLogs is a quorum read when the range ends at a fixed block or the
+// query is for a single block hash.
+func (c *Client) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	call := func(client *ethclient.Client) ([]types.Log, error) {
+		return client.FilterLogs(ctx, query)
+	}
+	if query.BlockHash == nil && !isExact(query.ToBlock) {
+		return failover(c, call)
+	}
+	return quorum(c, call)
+}
+
+func (c *Client) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return failover(c, func(client *ethclient.Client) (ethereum.Subscription, error) {
+		return client.SubscribeFilterLogs(ctx, query, ch)
+	})
+}
+
+func (c *Client) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	type result struct {
+		tx      *types.Transaction
+		pending bool
+	}
+	r, err := failover(c, func(client *ethclient.Client) (result, error) {
+		tx, pending, err := client.TransactionByHash(ctx, hash)
+		return result{tx, pending}, err
+	})
+	return r.tx, r.pending, err
+}
+
+// SendTransaction broadcasts tx through the first endpoint that takes it.
+// An endpoint that answers it already has tx, or rejects its nonce as too
+// low while some endpoint has tx, counts as having taken it. A request that
+// times out or loses its connection may still have been broadcast, so it is
+// not sent on to the next endpoint: tx is treated as sent, and a
+// transaction that never arrives is replaced like any other stuck one.
+func (c *Client) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	var lastErr error
+	for _, ep := range c.ordered() {
+		err := ep.client.SendTransaction(ctx, tx)
+		switch {
+		case err == nil || isKnown(err):
+			return nil
+		case isNonceTooLow(err):
+			if c.hasTransaction(ctx, tx.Hash()) {
+				return nil
+			}
+			return err
+		case isAnswer(err):
+			return err
+		case mayHaveReached(err):
+			c.markDown(ep, err)
+			if !c.hasTransaction(ctx, tx.Hash()) {
+				log.Printf("multirpc: %s: sending %s: %v; treating it as sent", ep.url, tx.Hash().Hex(), err)
+			}
+			return nil
+		}
+		c.markDown(ep, err)
+		lastErr = fmt.Errorf("%s: %v", ep.url, err)
+	}
+	return fmt.Errorf("no rpc endpoint answered: %v", lastErr)
+}
+
+// hasTransaction reports whether any endpoint has the transaction hash,
+// pending or mined.
+func (c *Client) hasTransaction(ctx context.Context, hash common.Hash) bool {
+	for _, ep := range c.ordered() {
+		if tx, _, err := ep.client.TransactionByHash(ctx, hash); err == nil && tx != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Client) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return failover(c, func(client *ethclient.Client) ([]byte, error) {
+		return client.CallContract(ctx, call, blockNumber)
+	})
+}
+
+func (c *Client) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return failover(c, func(client *ethclient.Client) ([]byte, error) {
+		return client.CodeAt(ctx, account, blockNumber)
+	})
+}
+
+func (c *Client) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	return failover(c, func(client *ethclient.Client) ([]byte, error) {
+		return client.PendingCodeAt(ctx, account)
+	})
+}
+
+func (c *Client) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return failover(c, func(client *ethclient.Client) (uint64, error) {
+		return client.PendingNonceAt(ctx, account)
+	})
+}
+
+func (c *Client) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return failover(c, func(client *ethclient.Client) (uint64, error) {
+		return client.NonceAt(ctx, account, blockNumber)
+	})
+}
+
+func (c *Client) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	return failover(c, func(client *ethclient.Client) (*big.Int, error) {
+		return client.BalanceAt(ctx, account, blockNumber)
+	})
+}
+
+func (c *Client) StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	return failover(c, func(client *ethclient.Client) ([]byte, error) {
+		return client.StorageAt(ctx, account, key, blockNumber)
+	})
+}
+
+func (c *Client) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return failover(c, func(client *ethclient.Client) (*big.Int, error) {
+		return client.SuggestGasPrice(ctx)
+	})
+}
+
+func (c *Client) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return failover(c, func(client *ethclient.Client) (*big.Int, error) {
+		return client.SuggestGasTipCap(ctx)
+	})
+}
+
+func (c *Client) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	return failover(c, func(client *ethclient.Client) (uint64, error) {
+		return client.EstimateGas(ctx, call)
+	})
+}
+
+func (c *Client) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	return failover(c, func(client *ethclient.Client) (*ethereum.FeeHistory, error) {
+		return client.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
+	})
+}
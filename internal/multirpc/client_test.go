@@ -0,0 +1,273 @@
+package multirpc
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/processor"
+	"github.com/stretchr/testify/require"
+)
+
+var _ processor.ChainClient = (*Client)(nil)
+
+// stub is a JSON-RPC endpoint answering the few methods the tests use.
+type stub struct {
+	server *httptest.Server
+
+	mu       sync.Mutex
+	head     uint64
+	gasPrice int64
+	receipt  *types.Receipt
+	// down makes every request fail with an HTTP error.
+	down bool
+	// rpcErr makes eth_gasPrice fail with a JSON-RPC error.
+	rpcErr bool
+	// sendErr is what eth_sendRawTransaction fails with, and drop makes it
+	// close the connection without answering.
+	sendErr string
+	drop    bool
+	// tx is returned by eth_getTransactionByHash.
+	tx    *types.Transaction
+	calls map[string]int
+}
+
+func newStub(t *testing.T, head uint64, gasPrice int64) *stub {
+	s := &stub{head: head, gasPrice: gasPrice, calls: make(map[string]int)}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.server.Close)
+	return s
+}
+
+func (s *stub) serve(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls[req.Method]++
+	if s.down {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		return
+	}
+
+	resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+	switch req.Method {
+	case "eth_blockNumber":
+		resp["result"] = hexutil.Uint64(s.head)
+	case "eth_gasPrice":
+		if s.rpcErr {
+			resp["error"] = map[string]interface{}{"code": -32000, "message": "gas price unavailable"}
+		} else {
+			resp["result"] = (*hexutil.Big)(big.NewInt(s.gasPrice))
+		}
+	case "eth_getTransactionReceipt":
+		resp["result"] = s.receipt
+	case "eth_sendRawTransaction":
+		if s.drop {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err == nil {
+				conn.Close()
+			}
+			return
+		}
+		if s.sendErr != "" {
+			resp["error"] = map[string]interface{}{"code": -32000, "message": s.sendErr}
+		} else {
+			resp["result"] = common.Hash{}
+		}
+	case "eth_getTransactionByHash":
+		resp["result"] = s.tx
+	default:
+		resp["error"] = map[string]interface{}{"code": -32601, "message": "method not found"}
+	}
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (s *stub) set(f func(s *stub)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f(s)
+}
+
+func (s *stub) count(method string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[method]
+}
+
+func dial(t *testing.T, config Config, stubs ...*stub) *Client {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	urls := make([]string, len(stubs))
+	for i, s := range stubs {
+		urls[i] = s.server.URL
+	}
+	client, err := Dial(ctx, urls, config)
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+	return client
+}
+
+func receipt(gasUsed uint64) *types.Receipt {
+	return &types.Receipt{
+		Status:      types.ReceiptStatusSuccessful,
+		TxHash:      common.HexToHash("0x01"),
+		BlockHash:   common.HexToHash("0x02"),
+		BlockNumber: big.NewInt(100),
+		GasUsed:     gasUsed,
+		Logs:        []*types.Log{},
+	}
+}
+
+func TestFailsOverFromFailingEndpoint(t *testing.T) {
+	first, second := newStub(t, 100, 1), newStub(t, 100, 2)
+	client := dial(t, Config{}, first, second)
+	ctx := context.Background()
+
+	gasPrice, err := client.SuggestGasPrice(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), gasPrice.Int64())
+
+	first.set(func(s *stub) { s.down = true })
+	gasPrice, err = client.SuggestGasPrice(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), gasPrice.Int64())
+
+	// The failed endpoint is out of rotation until a health check.
+	_, err = client.SuggestGasPrice(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, first.count("eth_gasPrice"))
+
+	first.set(func(s *stub) { s.down = false })
+	client.checkHealth(ctx)
+	gasPrice, err = client.SuggestGasPrice(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), gasPrice.Int64())
+
+	// With every endpoint down the last error is reported.
+	first.set(func(s *stub) { s.down = true })
+	second.set(func(s *stub) { s.down = true })
+	_, err = client.SuggestGasPrice(ctx)
+	require.ErrorContains(t, err, "no rpc endpoint answered")
+}
+
+func TestLaggingEndpointLeavesRotation(t *testing.T) {
+	lagging, ahead := newStub(t, 90, 1), newStub(t, 100, 2)
+	client := dial(t, Config{MaxLag: 3}, lagging, ahead)
+	ctx := context.Background()
+
+	gasPrice, err := client.SuggestGasPrice(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), gasPrice.Int64())
+	require.Zero(t, lagging.count("eth_gasPrice"))
+
+	lagging.set(func(s *stub) { s.head = 98 })
+	client.checkHealth(ctx)
+	gasPrice, err = client.SuggestGasPrice(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), gasPrice.Int64())
+}
+
+func TestNodeErrorsAreNotRetried(t *testing.T) {
+	first, second := newStub(t, 100, 1), newStub(t, 100, 2)
+	client := dial(t, Config{}, first, second)
+	ctx := context.Background()
+
+	first.set(func(s *stub) { s.rpcErr = true })
+	_, err := client.SuggestGasPrice(ctx)
+	require.ErrorContains(t, err, "gas price unavailable")
+	require.Zero(t, second.count("eth_gasPrice"))
+
+	_, err = client.TransactionReceipt(ctx, common.HexToHash("0x01"))
+	require.ErrorIs(t, err, ethereum.NotFound)
+	require.Zero(t, second.count("eth_getTransactionReceipt"))
+}
+
+func TestSendTransactionIsNotSentTwice(t *testing.T) {
+	first, second := newStub(t, 100, 1), newStub(t, 100, 2)
+	client := dial(t, Config{}, first, second)
+	ctx := context.Background()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	to := common.HexToAddress("0x01")
+	tx, err := types.SignTx(types.NewTx(&types.LegacyTx{Gas: 21000, GasPrice: big.NewInt(1), To: &to}), types.HomesteadSigner{}, key)
+	require.NoError(t, err)
+
+	// An endpoint that already has the transaction took it.
+	first.set(func(s *stub) { s.sendErr = "already known" })
+	require.NoError(t, client.SendTransaction(ctx, tx))
+
+	// A nonce too low is only success when the transaction is out there.
+	first.set(func(s *stub) { s.sendErr = "nonce too low" })
+	require.ErrorContains(t, client.SendTransaction(ctx, tx), "nonce too low")
+	second.set(func(s *stub) { s.tx = tx })
+	require.NoError(t, client.SendTransaction(ctx, tx))
+	require.Zero(t, second.count("eth_sendRawTransaction"))
+
+	// A dropped connection may have broadcast it: it is not sent elsewhere.
+	first.set(func(s *stub) { s.sendErr, s.drop = "", true })
+	require.NoError(t, client.SendTransaction(ctx, tx))
+	require.Zero(t, second.count("eth_sendRawTransaction"))
+
+	// An endpoint that is down never got it, so the next one is tried.
+	first.set(func(s *stub) { s.drop, s.down = false, true })
+	require.NoError(t, client.SendTransaction(ctx, tx))
+	require.Equal(t, 1, second.count("eth_sendRawTransaction"))
+}
+
+func TestQuorumReceipt(t *testing.T) {
+	stubs := []*stub{newStub(t, 100, 1), newStub(t, 100, 1), newStub(t, 100, 1)}
+	client := dial(t, Config{Quorum: 2}, stubs...)
+	ctx := context.Background()
+	txHash := common.HexToHash("0x01")
+
+	// Two endpoints agree, the third one is wrong.
+	stubs[0].set(func(s *stub) { s.receipt = receipt(21000) })
+	stubs[1].set(func(s *stub) { s.receipt = receipt(50000) })
+	stubs[2].set(func(s *stub) { s.receipt = receipt(50000) })
+	got, err := client.TransactionReceipt(ctx, txHash)
+	require.NoError(t, err)
+	require.Equal(t, uint64(50000), got.GasUsed)
+	for _, s := range stubs {
+		require.Equal(t, 1, s.count("eth_getTransactionReceipt"))
+	}
+
+	// Agreeing that there is no receipt is an answer too.
+	stubs[0].set(func(s *stub) { s.receipt = nil })
+	stubs[1].set(func(s *stub) { s.receipt = nil })
+	_, err = client.TransactionReceipt(ctx, txHash)
+	require.ErrorIs(t, err, ethereum.NotFound)
+
+	// No two endpoints agree.
+	stubs[0].set(func(s *stub) { s.receipt = receipt(21000) })
+	_, err = client.TransactionReceipt(ctx, txHash)
+	require.ErrorIs(t, err, ErrNoQuorum)
+
+	// An endpoint that cannot be reached does not count.
+	stubs[1].set(func(s *stub) { s.down = true; s.receipt = receipt(21000) })
+	_, err = client.TransactionReceipt(ctx, txHash)
+	require.ErrorIs(t, err, ErrNoQuorum)
+	stubs[2].set(func(s *stub) { s.down = true })
+	client.checkHealth(ctx)
+	_, err = client.TransactionReceipt(ctx, txHash)
+	require.ErrorContains(t, err, "1 healthy endpoints, quorum is 2")
+}
@@ -1,134 +1,583 @@
 package processor
 
 import (
-    "context"
-    "sync"
-    "time"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"sync"
+	"time"
 
-    "github.com/yourusername/bridge-swap/internal/models"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/gas"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/models"
 )
 
 const (
-    BATCH_SIZE    = 50
-    BATCH_TIMEOUT = 30 * time.Second
+	BATCH_SIZE    = 50
+	BATCH_TIMEOUT = 30 * time.Second
 )
 
+// BatchStore is the part of models.Database the batch processor uses.
+type BatchStore interface {
+	GetChainConfig(ctx context.Context, chainID int64) (*models.ChainConfig, error)
+	CreateBatch(ctx context.Context, batch *models.Batch) error
+	AddSwapsToBatch(ctx context.Context, batchID int64, swapIDs []int64) error
+	GetBatchesByStatus(ctx context.Context, chainID int64, status string) ([]*models.Batch, error)
+	GetBatchSwaps(ctx context.Context, batchID int64) ([]*models.Swap, error)
+	GetBatchTransactions(ctx context.Context, batchID int64) ([]*models.BatchTransaction, error)
+	AddBatchTransaction(ctx context.Context, batchTx *models.BatchTransaction) error
+	MarkBatchSubmitted(ctx context.Context, batchID int64, walletAddress string, txHash string, gasPrice *string) error
+	UpdateBatchCost(ctx context.Context, batchID int64, cost *models.BatchCost) error
+	UpdateBatchState(ctx context.Context, batchID int64, status string, errorMsg *string) error
+	UpdateSwapStatus(ctx context.Context, requestID string, status string, errorMsg *string) error
+}
+
+// route is a source and destination chain pair.
+type route struct {
+	from, to int64
+}
+
 type BatchProcessor struct {
-    currentBatch  []*models.SwapRequest
-    batchMutex    sync.Mutex
-    batchTimer    *time.Timer
-    walletPool    *WalletPool
-    db            *models.Database
-    processChan   chan struct{}
+	currentBatch []*models.SwapRequest
+	batchMutex   sync.Mutex
+	batchTimer   *time.Timer
+	walletPool   *WalletPool
+	chains       ChainSet
+	oracle       *gas.Oracle
+	db           BatchStore
+	processChan  chan struct{}
+	activeCount  int
+	activeMutex  sync.Mutex
 }
 
-func NewBatchProcessor(walletPool *WalletPool, db *models.Database) *BatchProcessor {
-    bp := &BatchProcessor{
-        walletPool:  walletPool,
-        db:         db,
-        processChan: make(chan struct{}, 1),
-    }
-    bp.startNewBatch()
-    go bp.processLoop()
-    return bp
+func NewBatchProcessor(walletPool *WalletPool, chains ChainSet, db BatchStore) *BatchProcessor {
+	bp := &BatchProcessor{
+		walletPool:  walletPool,
+		chains:      chains,
+		oracle:      gas.NewOracle(),
+		db:          db,
+		processChan: make(chan struct{}, 1),
+	}
+	bp.batchMutex.Lock()
+	bp.startNewBatch()
+	bp.batchMutex.Unlock()
+	go bp.processLoop()
+	go bp.resubmitLoop()
+	return bp
 }
 
+// startNewBatch resets the pending batch. Callers must hold batchMutex.
 func (bp *BatchProcessor) startNewBatch() {
-    bp.batchMutex.Lock()
-    defer bp.batchMutex.Unlock()
+	bp.currentBatch = make([]*models.SwapRequest, 0, BATCH_SIZE)
+	bp.batchTimer = time.AfterFunc(BATCH_TIMEOUT, bp.triggerProcess)
+}
 
-    bp.currentBatch = make([]*models.SwapRequest, 0, BATCH_SIZE)
-    bp.batchTimer = time.NewTimer(BATCH_TIMEOUT)
+func (bp *BatchProcessor) AddRequest(req *models.SwapRequest) {
+	bp.batchMutex.Lock()
+	defer bp.batchMutex.Unlock()
+
+	bp.currentBatch = append(bp.currentBatch, req)
 
-    go func() {
-        <-bp.batchTimer.C
-        bp.triggerProcess()
-    }()
+	if len(bp.currentBatch) >= BATCH_SIZE {
+		bp.batchTimer.Stop()
+		bp.triggerProcess()
+	}
 }
 
-func (bp *BatchProcessor) AddRequest(req *models.SwapRequest) {
-    bp.batchMutex.Lock()
-    defer bp.batchMutex.Unlock()
+func (bp *BatchProcessor) GetCurrentBatchSize() int {
+	bp.batchMutex.Lock()
+	defer bp.batchMutex.Unlock()
+
+	return len(bp.currentBatch)
+}
 
-    bp.currentBatch = append(bp.currentBatch, req)
+func (bp *BatchProcessor) GetActiveBatchCount() int {
+	bp.activeMutex.Lock()
+	defer bp.activeMutex.Unlock()
 
-    if len(bp.currentBatch) >= BATCH_SIZE {
-        bp.batchTimer.Stop()
-        bp.triggerProcess()
-    }
+	return bp.activeCount
 }
 
 func (bp *BatchProcessor) triggerProcess() {
-    select {
-    case bp.processChan <- struct{}{}:
-    default:
-    }
+	select {
+	case bp.processChan <- struct{}{}:
+	default:
+	}
 }
 
 func (bp *BatchProcessor) processLoop() {
-    for range bp.processChan {
-        bp.processBatch()
-    }
+	for range bp.processChan {
+		bp.processBatch()
+	}
 }
 
 func (bp *BatchProcessor) processBatch() {
-    bp.batchMutex.Lock()
-    if len(bp.currentBatch) == 0 {
-        bp.startNewBatch()
-        bp.batchMutex.Unlock()
-        return
-    }
-
-    batch := bp.currentBatch
-    bp.startNewBatch()
-    bp.batchMutex.Unlock()
-
-    // Group by chain
-    chainBatches := make(map[int64][]*models.SwapRequest)
-    for _, req := range batch {
-        chainBatches[req.FromChainID] = append(chainBatches[req.FromChainID], req)
-    }
-
-    // Process each chain's batch
-    var wg sync.WaitGroup
-    for chainID, chainBatch := range chainBatches {
-        wg.Add(1)
-        go func(cid int64, cbatch []*models.SwapRequest) {
-            defer wg.Done()
-            
-            wallet := bp.walletPool.getAvailableWallet()
-            if wallet == nil {
-                return
-            }
-            defer bp.walletPool.releaseWallet(wallet)
-
-            if err := bp.processChainBatch(cid, cbatch, wallet); err != nil {
-                // Handle error
-            }
-        }(chainID, chainBatch)
-    }
-    wg.Wait()
-}
-
-func (bp *BatchProcessor) processChainBatch(chainID int64, batch []*models.SwapRequest, wallet *Wallet) error {
-    ctx := context.Background()
-    
-    // Create batch record
-    batchRecord, err := bp.db.CreateBatch(ctx, wallet.Address.Hex(), chainID)
-    if err != nil {
-        return err
-    }
-
-    // Add swaps to batch
-    swapIDs := make([]int64, len(batch))
-    for i, swap := range batch {
-        swapIDs[i] = swap.ID
-    }
-    
-    if err := bp.db.AddSwapsToBatch(ctx, batchRecord.ID, swapIDs); err != nil {
-        return err
-    }
-
-    // Process on chain
-    return wallet.ProcessBatch(chainID, batch, batchRecord.ID)
-}
\ No newline at end of file
+	bp.batchMutex.Lock()
+	bp.batchTimer.Stop()
+	batch := bp.currentBatch
+	bp.startNewBatch()
+	bp.batchMutex.Unlock()
+
+	if len(batch) == 0 {
+		return
+	}
+
+	// Group by route, so that each batch completes on a single
+	// destination chain
+	routeBatches := make(map[route][]*models.SwapRequest)
+	for _, req := range batch {
+		r := route{from: req.FromChainID, to: req.ToChainID}
+		routeBatches[r] = append(routeBatches[r], req)
+	}
+
+	// Process each route's batch
+	var wg sync.WaitGroup
+	for r, routeBatch := range routeBatches {
+		wg.Add(1)
+		go func(r route, rbatch []*models.SwapRequest) {
+			defer wg.Done()
+
+			bp.activeMutex.Lock()
+			bp.activeCount++
+			bp.activeMutex.Unlock()
+			defer func() {
+				bp.activeMutex.Lock()
+				bp.activeCount--
+				bp.activeMutex.Unlock()
+			}()
+
+			if err := bp.processRoute(r, rbatch); err != nil {
+				log.Printf("failed to process batch on chain %d: %v", r.from, err)
+			}
+		}(r, routeBatch)
+	}
+	wg.Wait()
+}
+
+// processRoute sends the swaps of one route from as few hot wallets as their
+// token funds allow. Each wallet takes the swaps it can pay for and the
+// next one is tried with the rest; swaps no wallet can pay for go back to
+// the queue for the next batch, as do the ones of a batch that could not be
+// recorded.
+func (bp *BatchProcessor) processRoute(r route, batch []*models.SwapRequest) error {
+	ctx := context.Background()
+
+	// Swaps on a route with a disabled chain wait in the queue until it is
+	// enabled again
+	chain := bp.chains.Chain(r.from)
+	if chain == nil || bp.chains.Chain(r.to) == nil {
+		bp.requeue(batch)
+		return fmt.Errorf("route from chain %d to chain %d is not active", r.from, r.to)
+	}
+
+	// Wallets stay reserved until the route is done, so that each one is
+	// tried once
+	var reserved []*Wallet
+	defer func() {
+		for _, wallet := range reserved {
+			bp.walletPool.ReleaseWallet(wallet)
+		}
+	}()
+
+	for len(batch) > 0 {
+		wallet := bp.walletPool.GetAvailableWallet(chain.ID)
+		if wallet == nil {
+			break
+		}
+		reserved = append(reserved, wallet)
+
+		covered, rest, err := bp.fund(ctx, chain, wallet, batch)
+		if err != nil {
+			log.Printf("wallet %s cannot fund batch on chain %d: %v", wallet.Address.Hex(), r.from, err)
+			continue
+		}
+		if len(covered) == 0 {
+			continue
+		}
+
+		if err := bp.processChainBatch(ctx, chain, r, covered, wallet); err != nil {
+			log.Printf("failed to process batch on chain %d: %v", r.from, err)
+		}
+		batch = rest
+	}
+
+	if len(batch) > 0 {
+		log.Printf("no wallet available to fund %d swaps on chain %d, queued for the next batch", len(batch), r.from)
+		bp.requeue(batch)
+	}
+	return nil
+}
+
+// requeue puts swaps back into the pending batch without triggering it, so
+// they are retried when the batch times out.
+func (bp *BatchProcessor) requeue(swaps []*models.SwapRequest) {
+	bp.batchMutex.Lock()
+	defer bp.batchMutex.Unlock()
+
+	bp.currentBatch = append(bp.currentBatch, swaps...)
+}
+
+// processChainBatch records the swaps of batch that pass the preflight as a
+// batch and sends it from wallet. Until the batch and its swaps are
+// recorded the swaps are only held in memory, so they go back to the queue
+// when that fails.
+func (bp *BatchProcessor) processChainBatch(ctx context.Context, chain *Chain, r route, batch []*models.SwapRequest, wallet *Wallet) error {
+	// Leave out the swaps that would make the whole batch revert
+	batch = bp.preflight(ctx, chain, wallet, batch)
+	if len(batch) == 0 {
+		return nil
+	}
+
+	// Create batch record
+	batchRecord := &models.Batch{
+		WalletAddress: wallet.Address.Hex(),
+		ChainID:       r.from,
+		TargetChainID: r.to,
+		Status:        "pending",
+	}
+	if err := bp.db.CreateBatch(ctx, batchRecord); err != nil {
+		bp.requeue(batch)
+		return err
+	}
+
+	// Add swaps to batch
+	swapIDs := make([]int64, len(batch))
+	for i, swap := range batch {
+		swapIDs[i] = swap.ID
+	}
+
+	if err := bp.db.AddSwapsToBatch(ctx, batchRecord.ID, swapIDs); err != nil {
+		// The batch is left without swaps and never sent
+		errMsg := err.Error()
+		if dbErr := bp.db.UpdateBatchState(ctx, batchRecord.ID, "failed", &errMsg); dbErr != nil {
+			log.Printf("failed to mark batch %s as failed: %v", batchRecord.BatchID, dbErr)
+		}
+		bp.requeue(batch)
+		return err
+	}
+
+	return bp.submitBatch(ctx, chain, batchRecord, batch, wallet)
+}
+
+// submitBatch sends the batch transaction and records it with the price it
+// pays. The batch is held while gas, with the L1 data fee on rollups, is
+// above the chain's max_gas_price, and when it cannot be sent otherwise
+// sendFailed decides whether it fails.
+func (bp *BatchProcessor) submitBatch(ctx context.Context, chain *Chain, batchRecord *models.Batch, batch []*models.SwapRequest, wallet *Wallet) error {
+	fees, err := bp.fees(ctx, chain)
+	if err == nil {
+		err = bp.checkL1Fee(ctx, chain, wallet, batch, fees)
+	}
+	if errors.Is(err, gas.ErrGasPriceTooHigh) {
+		errMsg := err.Error()
+		return bp.db.UpdateBatchState(ctx, batchRecord.ID, "held", &errMsg)
+	}
+
+	var tx *types.Transaction
+	if err == nil {
+		tx, err = wallet.ProcessBatch(ctx, chain, batch, fees)
+	}
+	if err != nil {
+		return bp.sendFailed(ctx, batchRecord, err)
+	}
+	return bp.recordSent(ctx, chain, batchRecord, wallet, tx, fees)
+}
+
+// sendFailed records that the batch could not be sent because of err, and
+// returns err. Only a batch the bridge reverts for its swaps is failed with
+// them. One that reverts as a whole, for example while the bridge is
+// paused, or that failed for a reason that passes, such as an RPC error, is
+// held and sent again on the next pass.
+func (bp *BatchProcessor) sendFailed(ctx context.Context, batchRecord *models.Batch, err error) error {
+	status := "held"
+	if reason, ok := revertReason(err); ok && !isBatchRevert(reason) {
+		status = "failed"
+	}
+	errMsg := err.Error()
+	if dbErr := bp.db.UpdateBatchState(ctx, batchRecord.ID, status, &errMsg); dbErr != nil {
+		log.Printf("failed to mark batch %s as %s: %v", batchRecord.BatchID, status, dbErr)
+	}
+	return err
+}
+
+// recordSent records tx, sent by wallet for the batch at fees, as the
+// batch's transaction, and puts the batch in processing.
+func (bp *BatchProcessor) recordSent(ctx context.Context, chain *Chain, batchRecord *models.Batch, wallet *Wallet, tx *types.Transaction, fees *gas.Fees) error {
+	batchTx := BatchTransaction(batchRecord.ID, wallet, tx, fees)
+	if err := bp.db.AddBatchTransaction(ctx, batchTx); err != nil {
+		return err
+	}
+	if err := bp.db.MarkBatchSubmitted(ctx, batchRecord.ID, wallet.Address.Hex(), batchTx.TxHash, &batchTx.GasPrice); err != nil {
+		return err
+	}
+
+	// The estimate is only informational until the receipt replaces it
+	if err := bp.recordCost(ctx, chain, batchRecord.ID, tx, fees); err != nil {
+		log.Printf("failed to estimate cost of batch %s: %v", batchRecord.BatchID, err)
+	}
+	return nil
+}
+
+// recordCost estimates what a batch transaction sent at fees will pay,
+// taking its whole gas limit, and records it on the batch. On rollups this
+// includes the L1 data fee, which grows with the batch's calldata.
+func (bp *BatchProcessor) recordCost(ctx context.Context, chain *Chain, batchID int64, tx *types.Transaction, fees *gas.Fees) error {
+	config, err := bp.db.GetChainConfig(ctx, chain.ID)
+	if err != nil {
+		return err
+	}
+	cost, err := gas.EstimateCost(ctx, chain.Client, config.ChainType, tx, tx.Gas(), fees.Effective(), nil)
+	if err != nil {
+		return err
+	}
+	return bp.db.UpdateBatchCost(ctx, batchID, BatchCost(cost))
+}
+
+// checkL1Fee returns gas.ErrGasPriceTooHigh when, on a rollup with a
+// max_gas_price, the L1 data fee of sending batch from wallet at fees brings
+// what it pays per gas above that maximum. The fees themselves are already
+// within it.
+func (bp *BatchProcessor) checkL1Fee(ctx context.Context, chain *Chain, wallet *Wallet, batch []*models.SwapRequest, fees *gas.Fees) error {
+	config, err := bp.db.GetChainConfig(ctx, chain.ID)
+	if err != nil {
+		return err
+	}
+	if !gas.IsRollup(config.ChainType) || config.MaxGasPrice == nil {
+		return nil
+	}
+
+	tx, err := wallet.EstimateBatch(ctx, chain, batch, fees)
+	if err != nil {
+		return err
+	}
+	cost, err := gas.EstimateCost(ctx, chain.Client, config.ChainType, tx, tx.Gas(), fees.Effective(), nil)
+	if err != nil {
+		return err
+	}
+	return gas.CheckCost(config, cost, fees.Effective())
+}
+
+func (bp *BatchProcessor) fees(ctx context.Context, chain *Chain) (*gas.Fees, error) {
+	config, err := bp.db.GetChainConfig(ctx, chain.ID)
+	if err != nil {
+		return nil, err
+	}
+	return bp.oracle.Fees(ctx, chain.Client, config)
+}
+
+func (bp *BatchProcessor) resubmitLoop() {
+	ticker := time.NewTicker(BATCH_TIMEOUT)
+	defer ticker.Stop()
+
+	// Nonces are reconciled once per chain, when the chain is first seen
+	reconciled := make(map[int64]bool)
+	for {
+		for _, chain := range bp.chains.Chains() {
+			if reconciled[chain.ID] {
+				continue
+			}
+			if err := bp.syncNonces(context.Background(), chain, bp.walletPool.ReconcileNonces); err != nil {
+				log.Printf("failed to reconcile nonces on chain %d: %v", chain.ID, err)
+				continue
+			}
+			reconciled[chain.ID] = true
+		}
+
+		<-ticker.C
+		for _, chain := range bp.chains.Chains() {
+			for _, status := range []string{"reorged", "held"} {
+				if err := bp.resubmit(context.Background(), chain, status); err != nil {
+					log.Printf("failed to resubmit %s batches on chain %d: %v", status, chain.ID, err)
+				}
+			}
+			if err := bp.syncNonces(context.Background(), chain, bp.walletPool.FillNonceGaps); err != nil {
+				log.Printf("failed to fill nonce gaps on chain %d: %v", chain.ID, err)
+			}
+		}
+	}
+}
+
+// syncNonces runs a wallet pool nonce check on chain with the current fees,
+// which pay for the transactions filling nonce gaps.
+func (bp *BatchProcessor) syncNonces(ctx context.Context, chain *Chain, check func(context.Context, *Chain, *gas.Fees) error) error {
+	fees, err := bp.fees(ctx, chain)
+	if err != nil {
+		return err
+	}
+	return check(ctx, chain, fees)
+}
+
+// resubmit sends again the batches in status: the ones a chain
+// reorganization rolled back, or the ones held for gas prices.
+func (bp *BatchProcessor) resubmit(ctx context.Context, chain *Chain, status string) error {
+	batches, err := bp.db.GetBatchesByStatus(ctx, chain.ID, status)
+	if err != nil {
+		return err
+	}
+
+	for _, batchRecord := range batches {
+		if err := bp.resubmitBatch(ctx, chain, batchRecord); err != nil {
+			log.Printf("failed to resubmit batch %s: %v", batchRecord.BatchID, err)
+		}
+	}
+	return nil
+}
+
+// resubmitBatch sends a batch again, so that its swaps are never bridged
+// twice. A batch with a transaction mined in the meantime is put back to
+// processing instead. A batch sent before is replaced from the same wallet
+// with the same nonce while that nonce is unused, because its transaction
+// can still be mined from a transaction pool: only one of the two can be.
+// Only once another transaction has used the nonce is the batch sent with
+// a new one, by resendBatch.
+func (bp *BatchProcessor) resubmitBatch(ctx context.Context, chain *Chain, batchRecord *models.Batch) error {
+	batchTxs, err := bp.db.GetBatchTransactions(ctx, batchRecord.ID)
+	if err != nil {
+		return err
+	}
+
+	// The nonce is read before the receipts, so that a batch transaction
+	// mined in between is found by its receipt rather than taken for
+	// another transaction using the nonce.
+	var latest *models.BatchTransaction
+	if len(batchTxs) > 0 {
+		last := batchTxs[len(batchTxs)-1]
+		nonce, err := chain.Client.NonceAt(ctx, common.HexToAddress(last.WalletAddress), nil)
+		if err != nil {
+			return fmt.Errorf("error getting nonce of wallet %s: %v", last.WalletAddress, err)
+		}
+		if nonce <= last.Nonce {
+			latest = last
+		}
+	}
+	for _, batchTx := range batchTxs {
+		_, err := chain.Client.TransactionReceipt(ctx, common.HexToHash(batchTx.TxHash))
+		if err == nil {
+			return bp.db.MarkBatchSubmitted(ctx, batchRecord.ID, batchTx.WalletAddress, batchTx.TxHash, &batchTx.GasPrice)
+		}
+		if !errors.Is(err, ethereum.NotFound) {
+			return fmt.Errorf("error getting receipt of %s: %v", batchTx.TxHash, err)
+		}
+	}
+
+	swaps, err := bp.db.GetBatchSwaps(ctx, batchRecord.ID)
+	if err != nil {
+		return err
+	}
+	batch, err := SwapRequests(swaps)
+	if err != nil {
+		return err
+	}
+
+	if latest != nil {
+		return bp.replaceBatch(ctx, chain, batchRecord, batch, latest)
+	}
+
+	return bp.resendBatch(ctx, chain, batchRecord, batch)
+}
+
+// resendBatch sends a recorded batch with a new nonce, funded and simulated
+// like a new one. It is sent from the first available wallet that can pay
+// for all of its swaps, since they can neither be split over wallets nor
+// left out; when none can, the batch waits for the next pass. A batch whose
+// simulation reverts is not sent, and sendFailed decides what becomes of it.
+func (bp *BatchProcessor) resendBatch(ctx context.Context, chain *Chain, batchRecord *models.Batch, batch []*models.SwapRequest) error {
+	// Wallets stay reserved until the batch is sent, so that each one is
+	// tried once
+	var reserved []*Wallet
+	defer func() {
+		for _, wallet := range reserved {
+			bp.walletPool.ReleaseWallet(wallet)
+		}
+	}()
+
+	for {
+		wallet := bp.walletPool.GetAvailableWallet(chain.ID)
+		if wallet == nil {
+			return fmt.Errorf("no wallet available to fund the batch")
+		}
+		reserved = append(reserved, wallet)
+
+		_, rest, err := bp.fund(ctx, chain, wallet, batch)
+		if err != nil {
+			log.Printf("wallet %s cannot fund batch %s on chain %d: %v", wallet.Address.Hex(), batchRecord.BatchID, chain.ID, err)
+			continue
+		}
+		if len(rest) > 0 {
+			continue
+		}
+
+		err = wallet.SimulateBatch(ctx, chain, batch)
+		var revert *RevertError
+		if errors.As(err, &revert) {
+			return bp.sendFailed(ctx, batchRecord, err)
+		}
+		if err != nil {
+			log.Printf("failed to simulate batch %s on chain %d: %v", batchRecord.BatchID, chain.ID, err)
+		}
+		return bp.submitBatch(ctx, chain, batchRecord, batch, wallet)
+	}
+}
+
+// replaceBatch sends batch from the wallet and with the nonce of latest, its
+// last transaction, at fees bumped over the ones latest pays. The batch is
+// held while those, with the L1 data fee on rollups, are above the chain's
+// max_gas_price.
+func (bp *BatchProcessor) replaceBatch(ctx context.Context, chain *Chain, batchRecord *models.Batch, batch []*models.SwapRequest, latest *models.BatchTransaction) error {
+	wallet := bp.walletPool.Wallet(common.HexToAddress(latest.WalletAddress))
+	if wallet == nil {
+		return fmt.Errorf("wallet %s is not in the pool", latest.WalletAddress)
+	}
+
+	config, err := bp.db.GetChainConfig(ctx, chain.ID)
+	if err != nil {
+		return err
+	}
+	pending, err := PendingFees(latest)
+	if err != nil {
+		return err
+	}
+	fees, err := bp.oracle.Replacement(ctx, chain.Client, config, pending)
+	if err == nil {
+		err = bp.checkL1Fee(ctx, chain, wallet, batch, fees)
+	}
+	if errors.Is(err, gas.ErrGasPriceTooHigh) {
+		errMsg := err.Error()
+		return bp.db.UpdateBatchState(ctx, batchRecord.ID, "held", &errMsg)
+	}
+	if err != nil {
+		return err
+	}
+
+	tx, err := wallet.ReplaceBatch(ctx, chain, batch, latest.Nonce, fees)
+	if err != nil {
+		return err
+	}
+	return bp.recordSent(ctx, chain, batchRecord, wallet, tx, fees)
+}
+
+// SwapRequests converts stored swaps back into the requests a batch carries.
+func SwapRequests(swaps []*models.Swap) ([]*models.SwapRequest, error) {
+	requests := make([]*models.SwapRequest, len(swaps))
+	for i, swap := range swaps {
+		amount, ok := new(big.Int).SetString(swap.Amount, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid amount %q for swap %s", swap.Amount, swap.RequestID)
+		}
+		requests[i] = &models.SwapRequest{
+			ID:           swap.ID,
+			RequestID:    swap.RequestID,
+			FromChainID:  swap.FromChainID,
+			ToChainID:    swap.ToChainID,
+			TokenAddress: swap.TokenAddress,
+			Amount:       amount,
+			Recipient:    swap.Recipient,
+			Timestamp:    swap.CreatedAt,
+		}
+	}
+	return requests, nil
+}
@@ -0,0 +1,366 @@
+package processor
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/gas"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/models"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+type memBatchStore struct {
+	mu       sync.Mutex
+	config   *models.ChainConfig
+	batches  map[int64]*models.Batch
+	swaps    map[int64][]*models.Swap
+	batchTxs map[int64][]*models.BatchTransaction
+	// createErr, when set, is returned by CreateBatch
+	createErr error
+}
+
+func (s *memBatchStore) GetChainConfig(ctx context.Context, chainID int64) (*models.ChainConfig, error) {
+	return s.config, nil
+}
+
+func (s *memBatchStore) CreateBatch(ctx context.Context, batch *models.Batch) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.createErr != nil {
+		return s.createErr
+	}
+	batch.ID = int64(len(s.batches) + 1)
+	s.batches[batch.ID] = batch
+	return nil
+}
+
+func (s *memBatchStore) AddSwapsToBatch(ctx context.Context, batchID int64, swapIDs []int64) error {
+	return nil
+}
+
+func (s *memBatchStore) GetBatchesByStatus(ctx context.Context, chainID int64, status string) ([]*models.Batch, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var batches []*models.Batch
+	for _, b := range s.batches {
+		if b.ChainID == chainID && b.Status == status {
+			copied := *b
+			batches = append(batches, &copied)
+		}
+	}
+	return batches, nil
+}
+
+func (s *memBatchStore) GetBatchSwaps(ctx context.Context, batchID int64) ([]*models.Swap, error) {
+	return s.swaps[batchID], nil
+}
+
+func (s *memBatchStore) GetBatchTransactions(ctx context.Context, batchID int64) ([]*models.BatchTransaction, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.batchTxs[batchID], nil
+}
+
+func (s *memBatchStore) AddBatchTransaction(ctx context.Context, batchTx *models.BatchTransaction) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.batchTxs[batchTx.BatchID] = append(s.batchTxs[batchTx.BatchID], batchTx)
+	return nil
+}
+
+func (s *memBatchStore) MarkBatchSubmitted(ctx context.Context, batchID int64, walletAddress string, txHash string, gasPrice *string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b := s.batches[batchID]
+	b.Status, b.WalletAddress, b.SourceTxHash = "processing", walletAddress, &txHash
+	return nil
+}
+
+func (s *memBatchStore) UpdateBatchCost(ctx context.Context, batchID int64, cost *models.BatchCost) error {
+	return nil
+}
+
+func (s *memBatchStore) UpdateBatchState(ctx context.Context, batchID int64, status string, errorMsg *string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.batches[batchID].Status = status
+	s.batches[batchID].ErrorMessage = errorMsg
+	return nil
+}
+
+func (s *memBatchStore) UpdateSwapStatus(ctx context.Context, requestID string, status string, errorMsg *string) error {
+	return nil
+}
+
+func (s *memBatchStore) batch(id int64) models.Batch {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return *s.batches[id]
+}
+
+type reorgFixture struct {
+	chain  *testutil.Chain
+	pc     *Chain
+	store  *memBatchStore
+	bp     *BatchProcessor
+	tx     *types.Transaction
+	parent common.Hash
+}
+
+// newReorgFixture mines a one-swap batch from the bridge owner, then forks
+// the chain from the block before it. The batch is recorded as rolled back
+// by the reorganization.
+func newReorgFixture(t *testing.T) *reorgFixture {
+	ctx := context.Background()
+	chain := testutil.NewChain(t)
+	pool, err := NewWalletPool([]string{hex.EncodeToString(crypto.FromECDSA(chain.Owner))}, nil)
+	require.NoError(t, err)
+	pc := &Chain{ID: chain.ID, Client: chain.Client, Bridge: chain.BridgeAddr}
+	wallet := pool.Wallet(chain.OwnerAddr)
+
+	swap := &models.Swap{
+		ID:           1,
+		RequestID:    "swap-1",
+		FromChainID:  chain.ID,
+		ToChainID:    56,
+		TokenAddress: chain.TokenAddr,
+		Amount:       testutil.Ether(1).String(),
+		Recipient:    common.HexToAddress("0xbeef"),
+	}
+	requests, err := SwapRequests([]*models.Swap{swap})
+	require.NoError(t, err)
+	fees, err := gas.NewOracle().Fees(ctx, chain.Client, &models.ChainConfig{ChainID: chain.ID})
+	require.NoError(t, err)
+	tx, err := wallet.ProcessBatch(ctx, pc, requests, fees)
+	require.NoError(t, err)
+	receipt := chain.Mine(t, tx)
+
+	parent, err := chain.Client.HeaderByNumber(ctx, new(big.Int).Sub(receipt.BlockNumber, big.NewInt(1)))
+	require.NoError(t, err)
+	require.NoError(t, chain.Backend.Fork(parent.Hash()))
+
+	txHash := tx.Hash().Hex()
+	store := &memBatchStore{
+		config: &models.ChainConfig{ChainID: chain.ID},
+		batches: map[int64]*models.Batch{1: {
+			ID:            1,
+			BatchID:       "batch-1",
+			WalletAddress: wallet.Address.Hex(),
+			ChainID:       chain.ID,
+			TargetChainID: 56,
+			SourceTxHash:  &txHash,
+			Status:        "reorged",
+		}},
+		swaps:    map[int64][]*models.Swap{1: {swap}},
+		batchTxs: map[int64][]*models.BatchTransaction{1: {BatchTransaction(1, wallet, tx, fees)}},
+	}
+	return &reorgFixture{
+		chain: chain,
+		pc:    pc,
+		store: store,
+		bp: &BatchProcessor{
+			walletPool: pool,
+			chains:     StaticChains{pc},
+			oracle:     gas.NewOracle(),
+			db:         store,
+		},
+		tx:     tx,
+		parent: parent.Hash(),
+	}
+}
+
+// send submits tx on the new branch, retrying while the transaction pool is
+// still catching up with the fork.
+func (f *reorgFixture) send(t *testing.T, tx *types.Transaction) {
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		err := f.chain.Client.SendTransaction(context.Background(), tx)
+		if err == nil || strings.Contains(err.Error(), "already known") {
+			return
+		}
+		if !strings.Contains(err.Error(), "nonce too low") || time.Now().After(deadline) {
+			t.Fatalf("send %s: %v", tx.Hash().Hex(), err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// initiated counts the BatchSwapInitiated events on the canonical chain.
+func (f *reorgFixture) initiated(t *testing.T) int {
+	it, err := f.chain.Bridge.FilterBatchSwapInitiated(&bind.FilterOpts{Start: 0}, nil)
+	require.NoError(t, err)
+	defer it.Close()
+
+	n := 0
+	for it.Next() {
+		n++
+	}
+	require.NoError(t, it.Error())
+	return n
+}
+
+func TestResubmitReplacesReorgedBatchWithSameNonce(t *testing.T) {
+	f := newReorgFixture(t)
+	ctx := context.Background()
+
+	// The original transaction is back in the transaction pool of the new
+	// branch, and will be mined there unless it is replaced.
+	f.send(t, f.tx)
+	require.NoError(t, f.bp.resubmit(ctx, f.pc, "reorged"))
+
+	batch := f.store.batch(1)
+	require.Equal(t, "processing", batch.Status)
+	require.Len(t, f.store.batchTxs[1], 2)
+	replacement := f.store.batchTxs[1][1]
+	require.Equal(t, f.tx.Nonce(), replacement.Nonce)
+	require.Equal(t, replacement.TxHash, *batch.SourceTxHash)
+
+	// Both transactions are offered to the new branch, which outgrows the
+	// old one. Only one of them can be mined.
+	f.chain.Client.SendTransaction(ctx, f.tx)
+	f.chain.Backend.Commit()
+	f.chain.Backend.Commit()
+	require.Equal(t, 1, f.initiated(t))
+	_, err := f.chain.Client.TransactionReceipt(ctx, common.HexToHash(replacement.TxHash))
+	require.NoError(t, err)
+}
+
+func TestResubmitSendsReorgedBatchOnceNonceIsTaken(t *testing.T) {
+	f := newReorgFixture(t)
+	ctx := context.Background()
+
+	// Another transaction from the wallet takes the nonce on the new branch.
+	other, err := types.SignTx(
+		types.NewTx(&types.DynamicFeeTx{
+			ChainID:   big.NewInt(f.chain.ID),
+			Nonce:     f.tx.Nonce(),
+			GasTipCap: new(big.Int).Mul(f.tx.GasTipCap(), big.NewInt(2)),
+			GasFeeCap: new(big.Int).Mul(f.tx.GasFeeCap(), big.NewInt(2)),
+			Gas:       21000,
+			To:        &f.chain.OwnerAddr,
+		}),
+		types.LatestSignerForChainID(big.NewInt(f.chain.ID)),
+		f.chain.Owner,
+	)
+	require.NoError(t, err)
+	f.send(t, other)
+	f.chain.Mine(t, other)
+	f.chain.Backend.Commit()
+	require.Zero(t, f.initiated(t))
+
+	require.NoError(t, f.bp.resubmit(ctx, f.pc, "reorged"))
+	require.Len(t, f.store.batchTxs[1], 2)
+	resent := f.store.batchTxs[1][1]
+	require.Equal(t, f.tx.Nonce()+1, resent.Nonce)
+
+	f.chain.Backend.Commit()
+	require.Equal(t, 1, f.initiated(t))
+	require.Equal(t, "processing", f.store.batch(1).Status)
+}
+
+func TestResubmitSendsHeldBatchFromWalletThatCanFundIt(t *testing.T) {
+	ctx := context.Background()
+	chain := testutil.NewChain(t)
+	// The first wallet the pool hands out has gas but none of the token
+	empty := chain.NewFundedKey(t, testutil.Ether(1))
+	pool, err := NewWalletPool([]string{
+		hex.EncodeToString(crypto.FromECDSA(empty)),
+		hex.EncodeToString(crypto.FromECDSA(chain.Owner)),
+	}, nil)
+	require.NoError(t, err)
+	pc := &Chain{ID: chain.ID, Client: chain.Client, Bridge: chain.BridgeAddr}
+
+	store := &memBatchStore{
+		config: &models.ChainConfig{ChainID: chain.ID},
+		batches: map[int64]*models.Batch{1: {
+			ID:            1,
+			BatchID:       "batch-1",
+			WalletAddress: crypto.PubkeyToAddress(empty.PublicKey).Hex(),
+			ChainID:       chain.ID,
+			TargetChainID: 56,
+			Status:        "held",
+		}},
+		swaps: map[int64][]*models.Swap{1: {{
+			ID:           1,
+			RequestID:    "swap-1",
+			FromChainID:  chain.ID,
+			ToChainID:    56,
+			TokenAddress: chain.TokenAddr,
+			Amount:       testutil.Ether(1).String(),
+			Recipient:    common.HexToAddress("0xbeef"),
+		}}},
+		batchTxs: make(map[int64][]*models.BatchTransaction),
+	}
+	bp := &BatchProcessor{walletPool: pool, chains: StaticChains{pc}, oracle: gas.NewOracle(), db: store}
+
+	require.NoError(t, bp.resubmit(ctx, pc, "held"))
+	batch := store.batch(1)
+	require.Equal(t, "processing", batch.Status)
+	require.Equal(t, chain.OwnerAddr.Hex(), batch.WalletAddress)
+	for _, wallet := range pool.Wallets() {
+		require.False(t, wallet.Processing())
+	}
+}
+
+func TestSendFailedOnlyFailsBatchRevertedForItsSwaps(t *testing.T) {
+	for _, tc := range []struct {
+		err    error
+		status string
+	}{
+		{&RevertError{Reason: "Insufficient token balance"}, "failed"},
+		{&RevertError{Reason: "Pausable: paused"}, "held"},
+		{errors.New("error sending batch transaction: connection refused"), "held"},
+	} {
+		store := &memBatchStore{batches: map[int64]*models.Batch{1: {ID: 1, Status: "pending"}}}
+		bp := &BatchProcessor{db: store}
+
+		err := bp.sendFailed(context.Background(), store.batches[1], tc.err)
+		require.Equal(t, tc.err, err)
+		require.Equal(t, tc.status, store.batch(1).Status, tc.err.Error())
+	}
+}
+
+func TestProcessRouteRequeuesSwapsOfUnrecordedBatch(t *testing.T) {
+	chain := testutil.NewChain(t)
+	pool, err := NewWalletPool([]string{hex.EncodeToString(crypto.FromECDSA(chain.Owner))}, nil)
+	require.NoError(t, err)
+	pc := &Chain{ID: chain.ID, Client: chain.Client, Bridge: chain.BridgeAddr}
+	dest := &Chain{ID: 56, Client: chain.Client, Bridge: chain.BridgeAddr}
+
+	store := &memBatchStore{
+		config:    &models.ChainConfig{ChainID: chain.ID},
+		batches:   make(map[int64]*models.Batch),
+		createErr: errors.New("connection refused"),
+	}
+	bp := &BatchProcessor{walletPool: pool, chains: StaticChains{pc, dest}, oracle: gas.NewOracle(), db: store}
+
+	swap := &models.SwapRequest{
+		ID:           1,
+		RequestID:    "swap-1",
+		FromChainID:  chain.ID,
+		ToChainID:    56,
+		TokenAddress: chain.TokenAddr,
+		Amount:       testutil.Ether(1),
+		Recipient:    common.HexToAddress("0xbeef"),
+	}
+	require.NoError(t, bp.processRoute(route{from: chain.ID, to: 56}, []*models.SwapRequest{swap}))
+	require.Equal(t, []*models.SwapRequest{swap}, bp.currentBatch)
+}
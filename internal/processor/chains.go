@@ -0,0 +1,78 @@
+package processor
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// FollowInterval is how often Follow looks for chains that were enabled or
+// disabled.
+const FollowInterval = 10 * time.Second
+
+// ChainSet is the set of chains the bridge serves. The chain registry
+// changes it at runtime as chains are enabled and disabled.
+type ChainSet interface {
+	// Chains returns the chains in the set, ordered by chain ID.
+	Chains() []*Chain
+	// Chain returns the chain with id, or nil when it is not in the set.
+	Chain(id int64) *Chain
+}
+
+// StaticChains is a ChainSet that never changes.
+type StaticChains []*Chain
+
+func (s StaticChains) Chains() []*Chain {
+	chains := append([]*Chain(nil), s...)
+	sort.Slice(chains, func(i, j int) bool { return chains[i].ID < chains[j].ID })
+	return chains
+}
+
+func (s StaticChains) Chain(id int64) *Chain {
+	for _, chain := range s {
+		if chain.ID == id {
+			return chain
+		}
+	}
+	return nil
+}
+
+// Follow runs loop for every chain of chains, each in its own goroutine,
+// until ctx is cancelled. A chain that joins the set later gets a loop of
+// its own; the loop of a chain that leaves the set, or is replaced by one
+// with a different client or bridge, is cancelled.
+func Follow(ctx context.Context, chains ChainSet, loop func(context.Context, *Chain)) {
+	running := make(map[*Chain]context.CancelFunc)
+	defer func() {
+		for _, cancel := range running {
+			cancel()
+		}
+	}()
+
+	ticker := time.NewTicker(FollowInterval)
+	defer ticker.Stop()
+
+	for {
+		current := make(map[*Chain]bool)
+		for _, chain := range chains.Chains() {
+			current[chain] = true
+			if _, ok := running[chain]; !ok {
+				chainCtx, cancel := context.WithCancel(ctx)
+				running[chain] = cancel
+				go loop(chainCtx, chain)
+			}
+		}
+		for chain, cancel := range running {
+			if !current[chain] {
+				cancel()
+				delete(running, chain)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
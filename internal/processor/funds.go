@@ -0,0 +1,239 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/contracts"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/gas"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/models"
+)
+
+// ApprovalTimeout is how long the processor waits for an approve
+// transaction to be mined before giving up on the wallet.
+const ApprovalTimeout = 2 * time.Minute
+
+// TokenFunds is what the bridge can pull from a wallet for one token: the
+// wallet's balance and its allowance to the bridge.
+type TokenFunds struct {
+	Balance   *big.Int
+	Allowance *big.Int
+}
+
+// TokenFunds reads the wallet's balance of token and its allowance to the
+// chain's bridge, as of the latest block. The native coin has NativeFunds
+// instead.
+func (w *Wallet) TokenFunds(ctx context.Context, chain *Chain, token common.Address) (*TokenFunds, error) {
+	erc20, err := contracts.NewERC20Caller(token, chain.Client)
+	if err != nil {
+		return nil, fmt.Errorf("error binding token contract: %v", err)
+	}
+
+	opts := &bind.CallOpts{Context: ctx}
+	balance, err := erc20.BalanceOf(opts, w.Address)
+	if err != nil {
+		return nil, fmt.Errorf("error getting balance of token %s: %v", token.Hex(), err)
+	}
+	allowance, err := erc20.Allowance(opts, w.Address, chain.Bridge)
+	if err != nil {
+		return nil, fmt.Errorf("error getting allowance of token %s: %v", token.Hex(), err)
+	}
+
+	return &TokenFunds{Balance: balance, Allowance: allowance}, nil
+}
+
+// Approve lets the chain's bridge pull amount of token from the wallet. Like
+// ProcessBatch, it only broadcasts the transaction.
+func (w *Wallet) Approve(ctx context.Context, chain *Chain, token common.Address, amount *big.Int, fees *gas.Fees) (*types.Transaction, error) {
+	erc20, err := contracts.NewERC20Transactor(token, chain.Client)
+	if err != nil {
+		return nil, fmt.Errorf("error binding token contract: %v", err)
+	}
+
+	tx, err := w.transact(ctx, chain, fees, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return erc20.Approve(opts, chain.Bridge, amount)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error sending approve transaction: %v", err)
+	}
+
+	return tx, nil
+}
+
+// TokenAmounts sums the amounts of the swaps in batch per token.
+func TokenAmounts(batch []*models.SwapRequest) map[common.Address]*big.Int {
+	amounts := make(map[common.Address]*big.Int)
+	for _, req := range batch {
+		total, ok := amounts[req.TokenAddress]
+		if !ok {
+			total = new(big.Int)
+			amounts[req.TokenAddress] = total
+		}
+		total.Add(total, req.Amount)
+	}
+	return amounts
+}
+
+// CoverBatch splits batch, in order, into the swaps funds can pay for and
+// the rest. Without approve, the allowance limits what a wallet can pay as
+// much as its balance; with it, a short allowance is topped up before the
+// batch is sent and only the balance counts.
+func CoverBatch(batch []*models.SwapRequest, funds map[common.Address]*TokenFunds, approve bool) (covered, rest []*models.SwapRequest) {
+	available := make(map[common.Address]*big.Int)
+	for token, f := range funds {
+		available[token] = new(big.Int).Set(f.Balance)
+		if !approve && f.Allowance.Cmp(f.Balance) < 0 {
+			available[token].Set(f.Allowance)
+		}
+	}
+
+	for _, req := range batch {
+		left, ok := available[req.TokenAddress]
+		if !ok || left.Cmp(req.Amount) < 0 {
+			rest = append(rest, req)
+			continue
+		}
+		left.Sub(left, req.Amount)
+		covered = append(covered, req)
+	}
+	return covered, rest
+}
+
+// fund works out which swaps of batch wallet can pay for on chain and, when
+// the chain allows it, approves the bridge for the tokens whose allowance
+// falls short, waiting for the approvals to be mined so the batch can be
+// simulated against them.
+func (bp *BatchProcessor) fund(ctx context.Context, chain *Chain, wallet *Wallet, batch []*models.SwapRequest) (covered, rest []*models.SwapRequest, err error) {
+	config, err := bp.db.GetChainConfig(ctx, chain.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	funds := make(map[common.Address]*TokenFunds)
+	for token := range TokenAmounts(batch) {
+		var f *TokenFunds
+		if IsNative(token) {
+			f, err = bp.nativeFunds(ctx, chain, config, wallet)
+		} else {
+			f, err = wallet.TokenFunds(ctx, chain, token)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		funds[token] = f
+	}
+
+	covered, rest = CoverBatch(batch, funds, config.AutoApprove)
+	if !config.AutoApprove {
+		return covered, rest, nil
+	}
+
+	for token, amount := range TokenAmounts(covered) {
+		if funds[token].Allowance.Cmp(amount) >= 0 {
+			continue
+		}
+		if err := bp.approve(ctx, chain, config, wallet, token); err != nil {
+			return nil, nil, err
+		}
+	}
+	return covered, rest, nil
+}
+
+// nativeFunds is what wallet can spare of its native coin on chain after
+// the NativeReserve at the current fees. While those are above the chain's
+// max_gas_price the reserve cannot be priced, and native swaps wait.
+func (bp *BatchProcessor) nativeFunds(ctx context.Context, chain *Chain, config *models.ChainConfig, wallet *Wallet) (*TokenFunds, error) {
+	fees, err := bp.oracle.Fees(ctx, chain.Client, config)
+	if err != nil {
+		return nil, err
+	}
+	reserve, err := NativeReserve(config, fees)
+	if err != nil {
+		return nil, err
+	}
+	return wallet.NativeFunds(ctx, chain, reserve)
+}
+
+// approve gives the bridge an unlimited allowance for token from wallet,
+// so that it is not needed again for every batch.
+func (bp *BatchProcessor) approve(ctx context.Context, chain *Chain, config *models.ChainConfig, wallet *Wallet, token common.Address) error {
+	fees, err := bp.oracle.Fees(ctx, chain.Client, config)
+	if err != nil {
+		return err
+	}
+
+	tx, err := wallet.Approve(ctx, chain, token, math.MaxBig256, fees)
+	if err != nil {
+		return err
+	}
+	log.Printf("wallet %s approving bridge for token %s on chain %d: %s", wallet.Address.Hex(), token.Hex(), chain.ID, tx.Hash().Hex())
+
+	waitCtx, cancel := context.WithTimeout(ctx, ApprovalTimeout)
+	defer cancel()
+
+	receipt, err := bind.WaitMined(waitCtx, chain.Client, tx)
+	if err != nil {
+		return fmt.Errorf("error waiting for approve transaction %s: %v", tx.Hash().Hex(), err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return fmt.Errorf("approve transaction %s reverted", tx.Hash().Hex())
+	}
+	return nil
+}
+
+// TransferGas estimates the gas a native transfer from the wallet to to
+// uses, which must be an account without code. It is more than the 21000
+// of a plain transfer on chains that charge extra gas, such as Arbitrum for
+// posting the transaction to L1, and does not depend on the amount.
+func (w *Wallet) TransferGas(ctx context.Context, chain *Chain, to common.Address) (uint64, error) {
+	gasLimit, err := chain.Client.EstimateGas(ctx, ethereum.CallMsg{From: w.Address, To: &to})
+	if err != nil {
+		return 0, fmt.Errorf("error estimating transfer gas: %v", err)
+	}
+	return gasLimit, nil
+}
+
+// Transfer sends amount of the chain's native coin from the wallet to to,
+// which must be an account without code, with the gas limit TransferGas
+// estimated. Like ProcessBatch, it only broadcasts the transaction.
+func (w *Wallet) Transfer(ctx context.Context, chain *Chain, to common.Address, amount *big.Int, gasLimit uint64, fees *gas.Fees) (*types.Transaction, error) {
+	recipient := bind.NewBoundContract(to, abi.ABI{}, chain.Client, chain.Client, chain.Client)
+
+	tx, err := w.transact(ctx, chain, fees, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		opts.Value = amount
+		opts.GasLimit = gasLimit
+		return recipient.Transfer(opts)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error sending transfer transaction: %v", err)
+	}
+
+	return tx, nil
+}
+
+// TransferToken sends amount of token from the wallet to to. Like
+// ProcessBatch, it only broadcasts the transaction.
+func (w *Wallet) TransferToken(ctx context.Context, chain *Chain, token, to common.Address, amount *big.Int, fees *gas.Fees) (*types.Transaction, error) {
+	erc20, err := contracts.NewERC20Transactor(token, chain.Client)
+	if err != nil {
+		return nil, fmt.Errorf("error binding token contract: %v", err)
+	}
+
+	tx, err := w.transact(ctx, chain, fees, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return erc20.Transfer(opts, to, amount)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error sending token transfer transaction: %v", err)
+	}
+
+	return tx, nil
+}
@@ -0,0 +1,89 @@
+package processor
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/models"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoverBatch(t *testing.T) {
+	tokenA := common.HexToAddress("0xa")
+	tokenB := common.HexToAddress("0xb")
+	swap := func(id string, token common.Address, amount int64) *models.SwapRequest {
+		return &models.SwapRequest{RequestID: id, TokenAddress: token, Amount: big.NewInt(amount)}
+	}
+	batch := []*models.SwapRequest{
+		swap("a-1", tokenA, 40),
+		swap("b-1", tokenB, 10),
+		swap("a-2", tokenA, 50),
+		swap("a-3", tokenA, 10),
+		swap("c-1", common.HexToAddress("0xc"), 1),
+	}
+	funds := map[common.Address]*TokenFunds{
+		tokenA: {Balance: big.NewInt(60), Allowance: big.NewInt(45)},
+		tokenB: {Balance: big.NewInt(10), Allowance: big.NewInt(0)},
+	}
+	ids := func(swaps []*models.SwapRequest) []string {
+		var ids []string
+		for _, s := range swaps {
+			ids = append(ids, s.RequestID)
+		}
+		return ids
+	}
+
+	// Allowances will be approved: balances decide.
+	covered, rest := CoverBatch(batch, funds, true)
+	require.Equal(t, []string{"a-1", "b-1", "a-3"}, ids(covered))
+	require.Equal(t, []string{"a-2", "c-1"}, ids(rest))
+
+	// Allowances are spent as they are.
+	covered, rest = CoverBatch(batch, funds, false)
+	require.Equal(t, []string{"a-1"}, ids(covered))
+	require.Equal(t, []string{"b-1", "a-2", "a-3", "c-1"}, ids(rest))
+
+	// The funds passed in are left alone.
+	require.Equal(t, big.NewInt(60), funds[tokenA].Balance)
+}
+
+func TestWalletTokenFundsAndApprove(t *testing.T) {
+	chain := testutil.NewChain(t)
+	ctx := context.Background()
+
+	// Batches are sent by the bridge owner; take back its allowance.
+	tx, err := chain.Token.Approve(chain.Transactor(t, chain.Owner), chain.BridgeAddr, new(big.Int))
+	require.NoError(t, err)
+	chain.Mine(t, tx)
+
+	pool, err := NewWalletPool([]string{hex.EncodeToString(crypto.FromECDSA(chain.Owner))}, nil)
+	require.NoError(t, err)
+	wallet := pool.Wallet(chain.OwnerAddr)
+	pc := &Chain{ID: chain.ID, Client: chain.Client, Bridge: chain.BridgeAddr}
+
+	funds, err := wallet.TokenFunds(ctx, pc, chain.TokenAddr)
+	require.NoError(t, err)
+	require.Equal(t, testutil.Ether(1000), funds.Balance)
+	require.Zero(t, funds.Allowance.Sign())
+
+	batch := []*models.SwapRequest{
+		{FromChainID: chain.ID, ToChainID: 56, TokenAddress: chain.TokenAddr, Amount: testutil.Ether(2), Recipient: common.HexToAddress("0xbeef")},
+	}
+	var revert *RevertError
+	require.ErrorAs(t, wallet.SimulateBatch(ctx, pc, batch), &revert)
+
+	tx, err = wallet.Approve(ctx, pc, chain.TokenAddr, math.MaxBig256, nil)
+	require.NoError(t, err)
+	chain.Mine(t, tx)
+
+	funds, err = wallet.TokenFunds(ctx, pc, chain.TokenAddr)
+	require.NoError(t, err)
+	require.Equal(t, math.MaxBig256, funds.Allowance)
+	require.NoError(t, wallet.SimulateBatch(ctx, pc, batch))
+}
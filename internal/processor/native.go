@@ -0,0 +1,75 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/gas"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/models"
+)
+
+// NativeGasReserve is how much gas, at the chain's fees, a wallet keeps of
+// its native balance for fees instead of bridging it.
+const NativeGasReserve = 1_000_000
+
+// NativeToken is the token address swaps of a chain's native coin (ETH,
+// BNB) use, the bridge contract's NATIVE_TOKEN.
+var NativeToken = common.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE")
+
+// IsNative reports whether token is the native coin sentinel.
+func IsNative(token common.Address) bool {
+	return token == NativeToken
+}
+
+// NativeValue sums the native coin amounts of batch: the value a
+// batchInitiateSwap call for it must carry.
+func NativeValue(batch []*models.SwapRequest) *big.Int {
+	value := new(big.Int)
+	for _, req := range batch {
+		if IsNative(req.TokenAddress) {
+			value.Add(value, req.Amount)
+		}
+	}
+	return value
+}
+
+// NativeReserve is what a wallet keeps of its native balance on a chain
+// instead of bridging it: NativeGasReserve gas at the most fees lets a
+// transaction pay, or the chain's min_wallet_balance, below which the
+// wallet would get no more work there, whichever is more.
+func NativeReserve(config *models.ChainConfig, fees *gas.Fees) (*big.Int, error) {
+	price := fees.GasFeeCap
+	if fees.Legacy() {
+		price = fees.GasPrice
+	}
+	reserve := new(big.Int).Mul(price, big.NewInt(NativeGasReserve))
+
+	if config.MinWalletBalance != nil {
+		minBalance, ok := new(big.Int).SetString(*config.MinWalletBalance, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid min_wallet_balance %q", *config.MinWalletBalance)
+		}
+		if minBalance.Cmp(reserve) > 0 {
+			reserve = minBalance
+		}
+	}
+	return reserve, nil
+}
+
+// NativeFunds is what the bridge can take of the wallet's native coin: its
+// balance less reserve, from NativeReserve. Native coin is sent with the
+// call, so it never needs an allowance.
+func (w *Wallet) NativeFunds(ctx context.Context, chain *Chain, reserve *big.Int) (*TokenFunds, error) {
+	balance, err := chain.Client.BalanceAt(ctx, w.Address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting native balance: %v", err)
+	}
+
+	balance.Sub(balance, reserve)
+	if balance.Sign() < 0 {
+		balance.SetInt64(0)
+	}
+	return &TokenFunds{Balance: balance, Allowance: new(big.Int).Set(balance)}, nil
+}
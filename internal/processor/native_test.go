@@ -0,0 +1,81 @@
+package processor
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/gas"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/models"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalletProcessBatchWithNativeCoin(t *testing.T) {
+	chain := testutil.NewChain(t)
+	ctx := context.Background()
+
+	tx, err := chain.Bridge.AddSupportedToken(chain.Transactor(t, chain.Owner), NativeToken)
+	require.NoError(t, err)
+	chain.Mine(t, tx)
+
+	pool, err := NewWalletPool([]string{hex.EncodeToString(crypto.FromECDSA(chain.Owner))}, nil)
+	require.NoError(t, err)
+	wallet := pool.Wallet(chain.OwnerAddr)
+	pc := &Chain{ID: chain.ID, Client: chain.Client, Bridge: chain.BridgeAddr}
+
+	// Some of the native balance is kept for fees.
+	fees, err := gas.NewOracle().Fees(ctx, chain.Client, &models.ChainConfig{ChainID: chain.ID})
+	require.NoError(t, err)
+	reserve, err := NativeReserve(&models.ChainConfig{ChainID: chain.ID}, fees)
+	require.NoError(t, err)
+	funds, err := wallet.NativeFunds(ctx, pc, reserve)
+	require.NoError(t, err)
+	balance, err := chain.Client.BalanceAt(ctx, chain.OwnerAddr, nil)
+	require.NoError(t, err)
+	require.Positive(t, funds.Balance.Sign())
+	require.Negative(t, funds.Balance.Cmp(balance))
+	require.Equal(t, funds.Balance, funds.Allowance)
+
+	batch := []*models.SwapRequest{
+		{FromChainID: chain.ID, ToChainID: 56, TokenAddress: chain.TokenAddr, Amount: testutil.Ether(1), Recipient: common.HexToAddress("0x01")},
+		{FromChainID: chain.ID, ToChainID: 56, TokenAddress: NativeToken, Amount: testutil.Ether(2), Recipient: common.HexToAddress("0x02")},
+		{FromChainID: chain.ID, ToChainID: 56, TokenAddress: NativeToken, Amount: testutil.Ether(3), Recipient: common.HexToAddress("0x03")},
+	}
+	require.Equal(t, testutil.Ether(5), NativeValue(batch))
+	require.NoError(t, wallet.SimulateBatch(ctx, pc, batch))
+
+	tx, err = wallet.ProcessBatch(ctx, pc, batch, nil)
+	require.NoError(t, err)
+	require.Equal(t, testutil.Ether(5), tx.Value())
+	chain.Mine(t, tx)
+
+	native, err := chain.Client.BalanceAt(ctx, chain.BridgeAddr, nil)
+	require.NoError(t, err)
+	require.Equal(t, testutil.Ether(5), native)
+	tokens, err := chain.Token.BalanceOf(&bind.CallOpts{}, chain.BridgeAddr)
+	require.NoError(t, err)
+	require.Equal(t, testutil.Ether(1), tokens)
+}
+
+func TestNativeReserveKeepsMinWalletBalance(t *testing.T) {
+	fees := &gas.Fees{BaseFee: big.NewInt(10), GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(21)}
+
+	// The gas reserve is priced at the fee cap
+	reserve, err := NativeReserve(&models.ChainConfig{}, fees)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(21*NativeGasReserve), reserve)
+
+	minBalance := "50000000"
+	reserve, err = NativeReserve(&models.ChainConfig{MinWalletBalance: &minBalance}, fees)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(50_000_000), reserve)
+
+	reserve, err = NativeReserve(&models.ChainConfig{MinWalletBalance: &minBalance}, &gas.Fees{GasPrice: big.NewInt(100)})
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(100*NativeGasReserve), reserve)
+}
@@ -0,0 +1,282 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/gas"
+)
+
+// NonceStore is the part of models.Database the nonce manager uses.
+type NonceStore interface {
+	GetWalletNonce(ctx context.Context, address string, chainID int64) (uint64, bool, error)
+	UpdateWalletNonce(ctx context.Context, address string, chainID int64, nonce uint64) error
+	GetUnminedBatchNonces(ctx context.Context, address string, chainID int64) ([]uint64, error)
+}
+
+type nonceKey struct {
+	address common.Address
+	chainID int64
+}
+
+// nonceState is the nonce bookkeeping of one wallet on one chain.
+type nonceState struct {
+	mutex  sync.Mutex
+	loaded bool
+	next   uint64
+	// released holds nonces below next that were taken but never sent,
+	// lowest first.
+	released []uint64
+}
+
+// NonceManager hands out the nonces of the pool's wallets, one (wallet,
+// chain) pair at a time, and persists the next nonce of each pair in
+// hot_wallets.nonce. Nonces of sends that fail are released and reused by
+// the next send or filled with a no-op transaction, so a failed send never
+// leaves a gap that blocks the wallet's later transactions.
+type NonceManager struct {
+	db     NonceStore
+	states map[nonceKey]*nonceState
+	mutex  sync.Mutex
+}
+
+// NewNonceManager returns a nonce manager persisting to db. With a nil db
+// nonces are only kept in memory and start from the node's pending nonce.
+func NewNonceManager(db NonceStore) *NonceManager {
+	return &NonceManager{
+		db:     db,
+		states: make(map[nonceKey]*nonceState),
+	}
+}
+
+func (m *NonceManager) state(address common.Address, chainID int64) *nonceState {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	key := nonceKey{address: address, chainID: chainID}
+	state, ok := m.states[key]
+	if !ok {
+		state = &nonceState{}
+		m.states[key] = state
+	}
+	return state
+}
+
+// Next reserves the next nonce of address on chain. It must be handed back
+// with Release if the transaction using it is not sent.
+func (m *NonceManager) Next(ctx context.Context, chain *Chain, address common.Address) (uint64, error) {
+	state := m.state(address, chain.ID)
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+
+	if !state.loaded {
+		if err := m.load(ctx, chain, address, state); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(state.released) > 0 {
+		nonce := state.released[0]
+		state.released = state.released[1:]
+		return nonce, nil
+	}
+
+	nonce := state.next
+	if err := m.save(ctx, chain.ID, address, nonce+1); err != nil {
+		return 0, err
+	}
+	state.next = nonce + 1
+	return nonce, nil
+}
+
+// Release hands back a nonce reserved with Next whose transaction was not
+// sent.
+func (m *NonceManager) Release(ctx context.Context, chainID int64, address common.Address, nonce uint64) {
+	state := m.state(address, chainID)
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+
+	if nonce+1 == state.next {
+		// Nothing was sent after it: just step back.
+		if err := m.save(ctx, chainID, address, nonce); err == nil {
+			state.next = nonce
+			return
+		}
+	}
+	state.released = append(state.released, nonce)
+	sort.Slice(state.released, func(i, j int) bool { return state.released[i] < state.released[j] })
+}
+
+// load starts from the recorded nonce, or the node's pending nonce when the
+// node is ahead of it. Callers must hold state.mutex.
+func (m *NonceManager) load(ctx context.Context, chain *Chain, address common.Address, state *nonceState) error {
+	pending, err := chain.Client.PendingNonceAt(ctx, address)
+	if err != nil {
+		return fmt.Errorf("error getting pending nonce: %v", err)
+	}
+	stored, _, err := m.stored(ctx, chain.ID, address)
+	if err != nil {
+		return err
+	}
+
+	state.next = max(pending, stored, state.next)
+	state.loaded = true
+	return nil
+}
+
+// Reconcile checks the recorded nonce of wallet on chain against the node,
+// typically at startup. A recorded nonce behind the chain is moved forward.
+// Nonces between the node's pending nonce and the recorded one were taken
+// but never made it to the node, for example because the service crashed
+// before sending, and would block every later transaction: they are filled
+// with no-op transactions paying fees. Nonces of batch transactions that
+// are still unmined are left to the watchdog, which sends them again.
+func (m *NonceManager) Reconcile(ctx context.Context, chain *Chain, wallet *Wallet, fees *gas.Fees) error {
+	state := m.state(wallet.Address, chain.ID)
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+
+	mined, err := chain.Client.NonceAt(ctx, wallet.Address, nil)
+	if err != nil {
+		return fmt.Errorf("error getting nonce: %v", err)
+	}
+	pending, err := chain.Client.PendingNonceAt(ctx, wallet.Address)
+	if err != nil {
+		return fmt.Errorf("error getting pending nonce: %v", err)
+	}
+	stored, found, err := m.stored(ctx, chain.ID, wallet.Address)
+	if err != nil {
+		return err
+	}
+
+	next := max(pending, stored, state.next)
+	if found && stored < mined {
+		log.Printf("nonce manager: wallet %s chain %d: recorded nonce %d behind chain nonce %d", wallet.Address.Hex(), chain.ID, stored, mined)
+	}
+
+	var unmined []uint64
+	if m.db != nil {
+		unmined, err = m.db.GetUnminedBatchNonces(ctx, wallet.Address.Hex(), chain.ID)
+		if err != nil {
+			return err
+		}
+	}
+	skip := make(map[uint64]bool)
+	for _, nonce := range unmined {
+		skip[nonce] = true
+	}
+
+	var gaps []uint64
+	for nonce := pending; nonce < next; nonce++ {
+		if !skip[nonce] {
+			gaps = append(gaps, nonce)
+		}
+	}
+	// Released nonces are part of the gaps.
+	state.released = nil
+
+	if err := m.save(ctx, chain.ID, wallet.Address, next); err != nil {
+		return err
+	}
+	state.next = next
+	state.loaded = true
+
+	return m.fill(ctx, chain, wallet, gaps, fees)
+}
+
+// FillGaps sends no-op transactions for the nonces of wallet on chain that
+// were released and not reused.
+func (m *NonceManager) FillGaps(ctx context.Context, chain *Chain, wallet *Wallet, fees *gas.Fees) error {
+	state := m.state(wallet.Address, chain.ID)
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+
+	gaps := state.released
+	state.released = nil
+	return m.fill(ctx, chain, wallet, gaps, fees)
+}
+
+// fill sends a no-op transaction for each nonce. Callers must hold the
+// state mutex of the wallet on chain.
+func (m *NonceManager) fill(ctx context.Context, chain *Chain, wallet *Wallet, nonces []uint64, fees *gas.Fees) error {
+	for _, nonce := range nonces {
+		tx, err := wallet.fillNonce(ctx, chain, nonce, fees)
+		if err != nil {
+			if isNonceTaken(err) {
+				// Something is already pending or mined with this nonce.
+				continue
+			}
+			return fmt.Errorf("error filling nonce %d: %v", nonce, err)
+		}
+		log.Printf("nonce manager: wallet %s chain %d: filled nonce gap %d with %s", wallet.Address.Hex(), chain.ID, nonce, tx.Hash().Hex())
+	}
+	return nil
+}
+
+func (m *NonceManager) stored(ctx context.Context, chainID int64, address common.Address) (uint64, bool, error) {
+	if m.db == nil {
+		return 0, false, nil
+	}
+	return m.db.GetWalletNonce(ctx, address.Hex(), chainID)
+}
+
+func (m *NonceManager) save(ctx context.Context, chainID int64, address common.Address, next uint64) error {
+	if m.db == nil {
+		return nil
+	}
+	return m.db.UpdateWalletNonce(ctx, address.Hex(), chainID, next)
+}
+
+// fillNonce sends a zero-value transfer to the wallet itself with nonce.
+func (w *Wallet) fillNonce(ctx context.Context, chain *Chain, nonce uint64, fees *gas.Fees) (*types.Transaction, error) {
+	gasLimit, err := w.TransferGas(ctx, chain, w.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	var data types.TxData
+	if fees.Legacy() {
+		data = &types.LegacyTx{
+			Nonce:    nonce,
+			GasPrice: fees.GasPrice,
+			Gas:      gasLimit,
+			To:       &w.Address,
+			Value:    new(big.Int),
+		}
+	} else {
+		data = &types.DynamicFeeTx{
+			ChainID:   big.NewInt(chain.ID),
+			Nonce:     nonce,
+			GasTipCap: fees.GasTipCap,
+			GasFeeCap: fees.GasFeeCap,
+			Gas:       gasLimit,
+			To:        &w.Address,
+			Value:     new(big.Int),
+		}
+	}
+
+	tx, err := w.Signer.SignTx(ctx, types.NewTx(data), big.NewInt(chain.ID))
+	if err != nil {
+		return nil, err
+	}
+	if err := chain.Client.SendTransaction(ctx, tx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// isNonceTaken reports whether a send failed because the nonce is already
+// used by a mined or pending transaction.
+func isNonceTaken(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "nonce too low") ||
+		strings.Contains(msg, "already known") ||
+		strings.Contains(msg, "replacement transaction underpriced")
+}
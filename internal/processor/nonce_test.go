@@ -0,0 +1,218 @@
+package processor
+
+import (
+	"context"
+	"encoding/hex"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/gas"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/models"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+type memNonceStore struct {
+	mu      sync.Mutex
+	nonces  map[string]uint64
+	unmined []uint64
+}
+
+func (s *memNonceStore) GetWalletNonce(ctx context.Context, address string, chainID int64) (uint64, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	nonce, ok := s.nonces[address]
+	return nonce, ok, nil
+}
+
+func (s *memNonceStore) UpdateWalletNonce(ctx context.Context, address string, chainID int64, nonce uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nonces[address] = nonce
+	return nil
+}
+
+func (s *memNonceStore) GetUnminedBatchNonces(ctx context.Context, address string, chainID int64) ([]uint64, error) {
+	return s.unmined, nil
+}
+
+type nonceFixture struct {
+	chain  *testutil.Chain
+	pc     *Chain
+	store  *memNonceStore
+	pool   *WalletPool
+	wallet *Wallet
+	fees   *gas.Fees
+}
+
+func newNonceFixture(t *testing.T) *nonceFixture {
+	chain := testutil.NewChain(t)
+	store := &memNonceStore{nonces: make(map[string]uint64)}
+	pool, err := NewWalletPool([]string{hex.EncodeToString(crypto.FromECDSA(chain.Owner))}, store)
+	require.NoError(t, err)
+
+	fees, err := gas.NewOracle().Fees(context.Background(), chain.Client, &models.ChainConfig{ChainID: chain.ID})
+	require.NoError(t, err)
+
+	return &nonceFixture{
+		chain:  chain,
+		pc:     &Chain{ID: chain.ID, Client: chain.Client, Bridge: chain.BridgeAddr},
+		store:  store,
+		pool:   pool,
+		wallet: pool.Wallet(chain.OwnerAddr),
+		fees:   fees,
+	}
+}
+
+func (f *nonceFixture) send(t *testing.T, token common.Address) (uint64, error) {
+	tx, err := f.wallet.ProcessBatch(context.Background(), f.pc, []*models.SwapRequest{
+		{FromChainID: f.chain.ID, ToChainID: 56, TokenAddress: token, Amount: testutil.Ether(1), Recipient: common.HexToAddress("0xbeef")},
+	}, f.fees)
+	if err != nil {
+		return 0, err
+	}
+	return tx.Nonce(), nil
+}
+
+func (f *nonceFixture) stored() uint64 {
+	f.store.mu.Lock()
+	defer f.store.mu.Unlock()
+
+	return f.store.nonces[f.wallet.Address.Hex()]
+}
+
+func (f *nonceFixture) chainNonce(t *testing.T) uint64 {
+	nonce, err := f.chain.Client.NonceAt(context.Background(), f.wallet.Address, nil)
+	require.NoError(t, err)
+	return nonce
+}
+
+func (f *nonceFixture) pendingNonce(t *testing.T) uint64 {
+	nonce, err := f.chain.Client.PendingNonceAt(context.Background(), f.wallet.Address)
+	require.NoError(t, err)
+	return nonce
+}
+
+func TestNonceManagerPersistsNextNonce(t *testing.T) {
+	f := newNonceFixture(t)
+	start := f.pendingNonce(t)
+
+	first, err := f.send(t, f.chain.TokenAddr)
+	require.NoError(t, err)
+	second, err := f.send(t, f.chain.TokenAddr)
+	require.NoError(t, err)
+
+	require.Equal(t, start, first)
+	require.Equal(t, start+1, second)
+	require.Equal(t, start+2, f.stored())
+}
+
+func TestNonceManagerResumesFromStoredNonce(t *testing.T) {
+	f := newNonceFixture(t)
+	start := f.pendingNonce(t)
+
+	// A previous run took a nonce the node never saw.
+	f.store.nonces[f.wallet.Address.Hex()] = start + 1
+
+	nonce, err := f.send(t, f.chain.TokenAddr)
+	require.NoError(t, err)
+	require.Equal(t, start+1, nonce)
+}
+
+func TestNonceManagerReusesNonceOfFailedSend(t *testing.T) {
+	f := newNonceFixture(t)
+	ctx := context.Background()
+	start := f.pendingNonce(t)
+
+	// The last nonce taken steps back when its send fails.
+	_, err := f.send(t, common.HexToAddress("0xdead"))
+	require.ErrorContains(t, err, "Unsupported token")
+	require.Equal(t, start, f.stored())
+
+	// An earlier one is reused by the next send.
+	reserved, err := f.pool.nonces.Next(ctx, f.pc, f.wallet.Address)
+	require.NoError(t, err)
+	later, err := f.send(t, f.chain.TokenAddr)
+	require.NoError(t, err)
+	f.pool.nonces.Release(ctx, f.chain.ID, f.wallet.Address, reserved)
+
+	nonce, err := f.send(t, f.chain.TokenAddr)
+	require.NoError(t, err)
+	require.Equal(t, reserved, nonce)
+	require.Equal(t, start+1, later)
+	require.Equal(t, start+2, f.stored())
+}
+
+func TestFillNonceGaps(t *testing.T) {
+	f := newNonceFixture(t)
+	ctx := context.Background()
+	start := f.pendingNonce(t)
+
+	reserved, err := f.pool.nonces.Next(ctx, f.pc, f.wallet.Address)
+	require.NoError(t, err)
+	_, err = f.send(t, f.chain.TokenAddr)
+	require.NoError(t, err)
+	f.pool.nonces.Release(ctx, f.chain.ID, f.wallet.Address, reserved)
+
+	// The send after the gap cannot be mined until the gap is filled.
+	f.chain.Backend.Commit()
+	require.Equal(t, start, f.chainNonce(t))
+
+	require.NoError(t, f.pool.FillNonceGaps(ctx, f.pc, f.fees))
+	f.chain.Backend.Commit()
+	require.Equal(t, start+2, f.chainNonce(t))
+}
+
+func TestReconcileFillsGapsLeftByCrash(t *testing.T) {
+	f := newNonceFixture(t)
+	ctx := context.Background()
+	start := f.pendingNonce(t)
+
+	// Three nonces were taken before a crash. The second one belongs to a
+	// batch transaction the watchdog will send again; the others were never
+	// sent.
+	f.store.nonces[f.wallet.Address.Hex()] = start + 3
+	f.store.unmined = []uint64{start + 1}
+
+	require.NoError(t, f.pool.ReconcileNonces(ctx, f.pc, f.fees))
+	f.chain.Backend.Commit()
+	require.Equal(t, start+1, f.chainNonce(t))
+	require.Equal(t, start+3, f.stored())
+
+	// Batch ids derive from the block time, sender and batch size, so this
+	// batch differs in size from the one sent next.
+	swaps := []*models.SwapRequest{
+		{FromChainID: f.chain.ID, ToChainID: 56, TokenAddress: f.chain.TokenAddr, Amount: testutil.Ether(1), Recipient: common.HexToAddress("0xbeef")},
+		{FromChainID: f.chain.ID, ToChainID: 56, TokenAddress: f.chain.TokenAddr, Amount: testutil.Ether(1), Recipient: common.HexToAddress("0xbeef")},
+	}
+	_, err := f.wallet.ReplaceBatch(ctx, f.pc, swaps, start+1, f.fees)
+	require.NoError(t, err)
+	f.chain.Backend.Commit()
+	require.Equal(t, start+3, f.chainNonce(t))
+
+	nonce, err := f.send(t, f.chain.TokenAddr)
+	require.NoError(t, err)
+	require.Equal(t, start+3, nonce)
+}
+
+func TestReconcileMovesStaleNonceForward(t *testing.T) {
+	f := newNonceFixture(t)
+	ctx := context.Background()
+
+	f.store.nonces[f.wallet.Address.Hex()] = 0
+	f.chain.Mine(t, f.chain.SendValue(t, f.chain.Owner, common.HexToAddress("0xbeef"), testutil.Ether(1)))
+	start := f.chainNonce(t)
+	require.Positive(t, start)
+
+	require.NoError(t, f.pool.ReconcileNonces(ctx, f.pc, f.fees))
+	require.Equal(t, start, f.stored())
+	require.Equal(t, start, f.pendingNonce(t))
+
+	nonce, err := f.send(t, f.chain.TokenAddr)
+	require.NoError(t, err)
+	require.Equal(t, start, nonce)
+}
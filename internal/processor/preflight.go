@@ -0,0 +1,146 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/models"
+)
+
+// ErrBatchReverts is returned by Isolate when a batch reverts as a whole,
+// for example because the bridge is paused or the wallet may not send
+// batches, rather than because of some of its swaps.
+var ErrBatchReverts = errors.New("batch reverts as a whole")
+
+// batchReverts are the revert reasons of checks on the bridge or on the
+// sender of a batch, which every part of the batch fails alike.
+var batchReverts = []string{
+	"Pausable: paused",
+	"Caller is not an operator",
+	"ReentrancyGuard: reentrant call",
+	"Incorrect native amount",
+}
+
+// isBatchRevert reports whether reason is one of batchReverts.
+func isBatchRevert(reason string) bool {
+	for _, r := range batchReverts {
+		if reason == r {
+			return true
+		}
+	}
+	return false
+}
+
+// RejectedSwap is a swap that makes its batch revert on its own.
+type RejectedSwap struct {
+	Swap   *models.SwapRequest
+	Reason string
+}
+
+// Isolate finds the swaps of batch that make it revert by bisection.
+// simulate runs a batch and returns a *RevertError when it reverts; other
+// errors abort the search. The swaps that pass are returned in their
+// original order.
+//
+// A swap is only rejected when it reverts on its own and the rest of the
+// batch passes without it. When the revert reason is a check on the whole
+// batch, or no part of the batch passes, the fault is not with the swaps:
+// an error wrapping ErrBatchReverts is returned and nothing is rejected.
+// So it is when swaps only fail together, for example because their sum
+// exceeds an allowance: every half of the search passes, yet the batch
+// reverts.
+func Isolate(batch []*models.SwapRequest, simulate func([]*models.SwapRequest) error) ([]*models.SwapRequest, []RejectedSwap, error) {
+	err := simulate(batch)
+	if err == nil {
+		return batch, nil, nil
+	}
+	var revert *RevertError
+	if !errors.As(err, &revert) {
+		return nil, nil, err
+	}
+
+	passed, rejected, err := split(batch, revert, simulate)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(rejected) == 0 {
+		return nil, nil, fmt.Errorf("%w: %s", ErrBatchReverts, revert.Reason)
+	}
+	if len(passed) == 0 {
+		return nil, nil, fmt.Errorf("%w: %s", ErrBatchReverts, rejected[0].Reason)
+	}
+
+	err = simulate(passed)
+	if errors.As(err, &revert) {
+		return nil, nil, fmt.Errorf("%w: %s", ErrBatchReverts, revert.Reason)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return passed, rejected, nil
+}
+
+func bisect(batch []*models.SwapRequest, simulate func([]*models.SwapRequest) error) ([]*models.SwapRequest, []RejectedSwap, error) {
+	err := simulate(batch)
+	if err == nil {
+		return batch, nil, nil
+	}
+	var revert *RevertError
+	if !errors.As(err, &revert) {
+		return nil, nil, err
+	}
+	return split(batch, revert, simulate)
+}
+
+// split searches the halves of batch, whose simulation reverted with revert.
+func split(batch []*models.SwapRequest, revert *RevertError, simulate func([]*models.SwapRequest) error) ([]*models.SwapRequest, []RejectedSwap, error) {
+	if isBatchRevert(revert.Reason) {
+		return nil, nil, fmt.Errorf("%w: %s", ErrBatchReverts, revert.Reason)
+	}
+	if len(batch) == 1 {
+		return nil, []RejectedSwap{{Swap: batch[0], Reason: revert.Reason}}, nil
+	}
+
+	mid := len(batch) / 2
+	passed, rejected, err := bisect(batch[:mid], simulate)
+	if err != nil {
+		return nil, nil, err
+	}
+	passedRight, rejectedRight, err := bisect(batch[mid:], simulate)
+	if err != nil {
+		return nil, nil, err
+	}
+	return append(passed, passedRight...), append(rejected, rejectedRight...), nil
+}
+
+// preflight simulates the batch before it is recorded. Swaps that make it
+// revert are marked failed with the revert reason and left out of the
+// returned batch. A batch that reverts as a whole goes back to the queue
+// with none of its swaps failed, and nothing is returned. When the
+// simulation itself cannot run, the batch is returned as is and sending it
+// decides.
+func (bp *BatchProcessor) preflight(ctx context.Context, chain *Chain, wallet *Wallet, batch []*models.SwapRequest) []*models.SwapRequest {
+	passed, rejected, err := Isolate(batch, func(b []*models.SwapRequest) error {
+		return wallet.SimulateBatch(ctx, chain, b)
+	})
+	if errors.Is(err, ErrBatchReverts) {
+		log.Printf("batch of %d swaps from wallet %s on chain %d queued for the next batch: %v", len(batch), wallet.Address.Hex(), chain.ID, err)
+		bp.requeue(batch)
+		return nil
+	}
+	if err != nil {
+		log.Printf("failed to simulate batch on chain %d: %v", chain.ID, err)
+		return batch
+	}
+
+	for _, r := range rejected {
+		reason := r.Reason
+		log.Printf("swap %s rejected by batch simulation: %s", r.Swap.RequestID, reason)
+		if err := bp.db.UpdateSwapStatus(ctx, r.Swap.RequestID, "failed", &reason); err != nil {
+			log.Printf("failed to mark swap %s as failed: %v", r.Swap.RequestID, err)
+		}
+	}
+	return passed
+}
@@ -0,0 +1,162 @@
+package processor
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/models"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsolateRejectsRevertingSwaps(t *testing.T) {
+	chain := testutil.NewChain(t)
+	pool, err := NewWalletPool([]string{hex.EncodeToString(crypto.FromECDSA(chain.Owner))}, nil)
+	require.NoError(t, err)
+	wallet := pool.Wallet(chain.OwnerAddr)
+	pc := &Chain{ID: chain.ID, Client: chain.Client, Bridge: chain.BridgeAddr}
+
+	// Only 3 tokens may be pulled from the wallet.
+	tx, err := chain.Token.Approve(chain.Transactor(t, chain.Owner), chain.BridgeAddr, testutil.Ether(3))
+	require.NoError(t, err)
+	chain.Mine(t, tx)
+
+	swap := func(id string, token common.Address, amount int64, recipient common.Address) *models.SwapRequest {
+		return &models.SwapRequest{
+			RequestID:    id,
+			FromChainID:  chain.ID,
+			ToChainID:    56,
+			TokenAddress: token,
+			Amount:       testutil.Ether(amount),
+			Recipient:    recipient,
+		}
+	}
+	recipient := common.HexToAddress("0xbeef")
+	batch := []*models.SwapRequest{
+		swap("ok-1", chain.TokenAddr, 1, recipient),
+		swap("bad-token", common.HexToAddress("0xdead"), 1, recipient),
+		swap("ok-2", chain.TokenAddr, 1, recipient),
+		swap("bad-recipient", chain.TokenAddr, 1, common.Address{}),
+		swap("too-much", chain.TokenAddr, 5, recipient),
+	}
+
+	simulate := func(b []*models.SwapRequest) error {
+		return wallet.SimulateBatch(context.Background(), pc, b)
+	}
+	passed, rejected, err := Isolate(batch, simulate)
+	require.NoError(t, err)
+
+	require.Equal(t, []*models.SwapRequest{batch[0], batch[2]}, passed)
+	reasons := make(map[string]string)
+	for _, r := range rejected {
+		reasons[r.Swap.RequestID] = r.Reason
+	}
+	require.Equal(t, map[string]string{
+		"bad-token":     "Unsupported token",
+		"bad-recipient": "Invalid recipient",
+		"too-much":      "ERC20: insufficient allowance",
+	}, reasons)
+
+	require.NoError(t, simulate(passed))
+}
+
+func TestIsolateStopsOnSimulationError(t *testing.T) {
+	batch := []*models.SwapRequest{{RequestID: "a"}, {RequestID: "b"}}
+	failure := errors.New("connection refused")
+
+	calls := 0
+	_, _, err := Isolate(batch, func(b []*models.SwapRequest) error {
+		calls++
+		if len(b) == 2 {
+			return &RevertError{Reason: "Unsupported token"}
+		}
+		return failure
+	})
+	require.ErrorIs(t, err, failure)
+	require.Equal(t, 2, calls)
+}
+
+func TestIsolateDoesNotRejectSwapsOfRevertingBatch(t *testing.T) {
+	chain := testutil.NewChain(t)
+	pool, err := NewWalletPool([]string{hex.EncodeToString(crypto.FromECDSA(chain.Owner))}, nil)
+	require.NoError(t, err)
+	wallet := pool.Wallet(chain.OwnerAddr)
+	pc := &Chain{ID: chain.ID, Client: chain.Client, Bridge: chain.BridgeAddr}
+	simulate := func(b []*models.SwapRequest) error {
+		return wallet.SimulateBatch(context.Background(), pc, b)
+	}
+
+	batch := make([]*models.SwapRequest, 3)
+	for i := range batch {
+		batch[i] = &models.SwapRequest{
+			FromChainID:  chain.ID,
+			ToChainID:    56,
+			TokenAddress: chain.TokenAddr,
+			Amount:       testutil.Ether(1),
+			Recipient:    common.HexToAddress("0xbeef"),
+		}
+	}
+
+	// The wallet may not pull any tokens: every part of the batch reverts.
+	tx, err := chain.Token.Approve(chain.Transactor(t, chain.Owner), chain.BridgeAddr, common.Big0)
+	require.NoError(t, err)
+	chain.Mine(t, tx)
+	passed, rejected, err := Isolate(batch, simulate)
+	require.ErrorIs(t, err, ErrBatchReverts)
+	require.ErrorContains(t, err, "ERC20: insufficient allowance")
+	require.Empty(t, passed)
+	require.Empty(t, rejected)
+
+	// A paused bridge is known to revert every batch.
+	tx, err = chain.Token.Approve(chain.Transactor(t, chain.Owner), chain.BridgeAddr, testutil.Ether(10))
+	require.NoError(t, err)
+	chain.Mine(t, tx)
+	tx, err = chain.Bridge.Pause(chain.Transactor(t, chain.Owner))
+	require.NoError(t, err)
+	chain.Mine(t, tx)
+
+	calls := 0
+	passed, rejected, err = Isolate(batch, func(b []*models.SwapRequest) error {
+		calls++
+		return simulate(b)
+	})
+	require.ErrorIs(t, err, ErrBatchReverts)
+	require.ErrorContains(t, err, "Pausable: paused")
+	require.Empty(t, passed)
+	require.Empty(t, rejected)
+	require.Equal(t, 1, calls)
+}
+
+func TestIsolateKeepsSwapsWhenRestStillReverts(t *testing.T) {
+	batch := []*models.SwapRequest{{RequestID: "a"}, {RequestID: "b"}, {RequestID: "c"}}
+
+	// "a" reverts alone, but "b" and "c" also revert together.
+	_, rejected, err := Isolate(batch, func(b []*models.SwapRequest) error {
+		if len(b) == 1 && b[0].RequestID != "a" {
+			return nil
+		}
+		return &RevertError{Reason: "Unsupported token"}
+	})
+	require.ErrorIs(t, err, ErrBatchReverts)
+	require.Empty(t, rejected)
+}
+
+func TestIsolateDoesNotSendBatchWhoseSwapsOnlyFailTogether(t *testing.T) {
+	batch := []*models.SwapRequest{{RequestID: "a"}, {RequestID: "b"}, {RequestID: "c"}, {RequestID: "d"}}
+
+	// Any two swaps fit the allowance, all four do not.
+	passed, rejected, err := Isolate(batch, func(b []*models.SwapRequest) error {
+		if len(b) > 2 {
+			return &RevertError{Reason: "ERC20: insufficient allowance"}
+		}
+		return nil
+	})
+	require.ErrorIs(t, err, ErrBatchReverts)
+	require.ErrorContains(t, err, "ERC20: insufficient allowance")
+	require.Empty(t, passed)
+	require.Empty(t, rejected)
+}
@@ -0,0 +1,88 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// RevertError is a call the contract reverted, with its decoded reason.
+type RevertError struct {
+	Reason string
+}
+
+func (e *RevertError) Error() string {
+	return "execution reverted: " + e.Reason
+}
+
+// revertReason decodes the reason of a reverted call from the error the
+// node returned for it. The boolean is false when err is not a revert.
+func revertReason(err error) (string, bool) {
+	if err == nil {
+		return "", false
+	}
+
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if data, ok := dataErr.ErrorData().(string); ok {
+			if raw, decodeErr := hexutil.Decode(data); decodeErr == nil {
+				if reason, unpackErr := abi.UnpackRevert(raw); unpackErr == nil {
+					return reason, true
+				}
+			}
+		}
+	}
+
+	// Reverts without reason data, such as a failed require with no
+	// message, only carry the message.
+	msg := err.Error()
+	if i := strings.Index(msg, "execution reverted"); i >= 0 {
+		reason := strings.TrimPrefix(strings.TrimPrefix(msg[i:], "execution reverted"), ": ")
+		if reason == "" {
+			reason = "execution reverted"
+		}
+		return reason, true
+	}
+	return "", false
+}
+
+// ReplayRevert runs the transaction of a reverted receipt again as a call
+// against the state of the block before it, and returns the reason the
+// contract reverted with. The reason is empty when the replay does not
+// revert, which happens when the transaction only failed because of the
+// ones mined before it in its block.
+func ReplayRevert(ctx context.Context, chain *Chain, receipt *types.Receipt) (string, error) {
+	tx, _, err := chain.Client.TransactionByHash(ctx, receipt.TxHash)
+	if err != nil {
+		return "", fmt.Errorf("error getting transaction: %v", err)
+	}
+	from, err := types.Sender(types.LatestSignerForChainID(big.NewInt(chain.ID)), tx)
+	if err != nil {
+		return "", fmt.Errorf("error recovering transaction sender: %v", err)
+	}
+
+	msg := ethereum.CallMsg{
+		From:  from,
+		To:    tx.To(),
+		Gas:   tx.Gas(),
+		Value: tx.Value(),
+		Data:  tx.Data(),
+	}
+	parent := new(big.Int).Sub(receipt.BlockNumber, big.NewInt(1))
+	_, err = chain.Client.CallContract(ctx, msg, parent)
+	if reason, ok := revertReason(err); ok {
+		return reason, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("error replaying transaction: %v", err)
+	}
+	return "", nil
+}
@@ -1,84 +1,563 @@
 package processor
 
 import (
-    "context"
-    "crypto/ecdsa"
-    "sync"
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
 
-    "github.com/ethereum/go-ethereum/common"
-    "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/attestation"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/contracts"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/gas"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/models"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/signer"
 )
 
+// ChainClient is the part of an ethclient.Client the processor relies on.
+// The go-ethereum simulated backend satisfies it as well.
+type ChainClient interface {
+	bind.ContractBackend
+	bind.DeployBackend
+	ethereum.ChainStateReader
+	ethereum.FeeHistoryReader
+	ethereum.TransactionReader
+}
+
+// Chain describes a bridge deployment that batches can be submitted to.
+type Chain struct {
+	ID     int64
+	Client ChainClient
+	Bridge common.Address
+}
+
 type Wallet struct {
-    PrivateKey   *ecdsa.PrivateKey
-    Address      common.Address
-    NonceMap     map[int64]uint64
-    IsProcessing bool
-    LastUsed     time.Time
-    mutex        sync.Mutex
+	// Signer signs every transaction the wallet sends.
+	Signer       signer.Signer
+	Address      common.Address
+	IsProcessing bool
+	LastUsed     time.Time
+	nonces       *NonceManager
+	// lowBalance holds the chains the wallet's native balance is too low
+	// on to pay for gas.
+	lowBalance map[int64]bool
+	// draining is set while the wallet is being emptied for retirement.
+	draining bool
+	mutex    sync.Mutex
 }
 
 type WalletPool struct {
-    wallets []*Wallet
-    mutex   sync.RWMutex
+	wallets []*Wallet
+	nonces  *NonceManager
+	mutex   sync.RWMutex
+}
+
+// NewWalletPool loads the wallets for privateKeys, signing with the keys
+// in memory. Their nonces are persisted to nonceStore, or only kept in
+// memory when it is nil.
+func NewWalletPool(privateKeys []string, nonceStore NonceStore) (*WalletPool, error) {
+	signers := make([]signer.Signer, len(privateKeys))
+	for i, privKey := range privateKeys {
+		local, err := signer.LocalFromHex(privKey)
+		if err != nil {
+			return nil, err
+		}
+		signers[i] = local
+	}
+	return NewWalletPoolFromSigners(signers, nonceStore), nil
+}
+
+// NewWalletPoolFromSigners is NewWalletPool for wallets whose transactions
+// signers sign, such as a remote signer holding their keys.
+func NewWalletPoolFromSigners(signers []signer.Signer, nonceStore NonceStore) *WalletPool {
+	pool := &WalletPool{
+		wallets: make([]*Wallet, len(signers)),
+		nonces:  NewNonceManager(nonceStore),
+	}
+
+	for i, s := range signers {
+		pool.wallets[i] = pool.newWallet(s)
+	}
+
+	return pool
+}
+
+func (wp *WalletPool) newWallet(s signer.Signer) *Wallet {
+	return &Wallet{
+		Signer:   s,
+		Address:  s.Address(),
+		LastUsed: time.Now(),
+		nonces:   wp.nonces,
+	}
+}
+
+// Add puts a wallet signed for by s in the pool and returns it. When the
+// pool already has the wallet, it is returned unchanged.
+func (wp *WalletPool) Add(s signer.Signer) *Wallet {
+	wp.mutex.Lock()
+	defer wp.mutex.Unlock()
+
+	for _, wallet := range wp.wallets {
+		if wallet.Address == s.Address() {
+			return wallet
+		}
+	}
+	wallet := wp.newWallet(s)
+	wp.wallets = append(wp.wallets, wallet)
+	return wallet
 }
 
-func NewWalletPool(privateKeys []string) (*WalletPool, error) {
-    pool := &WalletPool{
-        wallets: make([]*Wallet, len(privateKeys)),
-    }
+// Remove takes the wallet for address out of the pool and returns it, or
+// returns nil when the pool has no such wallet. A wallet reserved with
+// GetAvailableWallet cannot be removed until it is released.
+func (wp *WalletPool) Remove(address common.Address) (*Wallet, error) {
+	wp.mutex.Lock()
+	defer wp.mutex.Unlock()
 
-    for i, privKey := range privateKeys {
-        key, err := crypto.HexToECDSA(privKey)
-        if err != nil {
-            return nil, err
-        }
+	for i, wallet := range wp.wallets {
+		if wallet.Address != address {
+			continue
+		}
+		if wallet.Processing() {
+			return nil, fmt.Errorf("wallet %s is processing", address.Hex())
+		}
+		wp.wallets = append(wp.wallets[:i:i], wp.wallets[i+1:]...)
+		return wallet, nil
+	}
+	return nil, nil
+}
 
-        wallet := &Wallet{
-            PrivateKey: key,
-            Address:    crypto.PubkeyToAddress(key.PublicKey),
-            NonceMap:   make(map[int64]uint64),
-            LastUsed:   time.Now(),
-        }
-        pool.wallets[i] = wallet
-    }
+// Wallets returns the wallets in the pool.
+func (wp *WalletPool) Wallets() []*Wallet {
+	wp.mutex.RLock()
+	defer wp.mutex.RUnlock()
 
-    return pool, nil
+	wallets := make([]*Wallet, len(wp.wallets))
+	copy(wallets, wp.wallets)
+	return wallets
 }
 
-func (wp *WalletPool) getAvailableWallet() *Wallet {
-    wp.mutex.Lock()
-    defer wp.mutex.Unlock()
+// GetAvailableWallet reserves the least recently used idle wallet that can
+// pay for gas on chainID and is not draining, or returns nil when there is
+// none. Reserved wallets must be handed back with ReleaseWallet.
+func (wp *WalletPool) GetAvailableWallet(chainID int64) *Wallet {
+	wp.mutex.Lock()
+	defer wp.mutex.Unlock()
 
-    var selectedWallet *Wallet
-    oldestLastUsed := time.Now()
+	var selectedWallet *Wallet
+	oldestLastUsed := time.Now()
 
-    for _, wallet := range wp.wallets {
-        wallet.mutex.Lock()
-        if !wallet.IsProcessing && wallet.LastUsed.Before(oldestLastUsed) {
-            selectedWallet = wallet
-            oldestLastUsed = wallet.LastUsed
-        }
-        wallet.mutex.Unlock()
-    }
+	for _, wallet := range wp.wallets {
+		wallet.mutex.Lock()
+		if !wallet.IsProcessing && !wallet.draining && !wallet.lowBalance[chainID] && wallet.LastUsed.Before(oldestLastUsed) {
+			selectedWallet = wallet
+			oldestLastUsed = wallet.LastUsed
+		}
+		wallet.mutex.Unlock()
+	}
 
-    if selectedWallet != nil {
-        selectedWallet.mutex.Lock()
-        selectedWallet.IsProcessing = true
-        selectedWallet.LastUsed = time.Now()
-        selectedWallet.mutex.Unlock()
-    }
+	if selectedWallet != nil {
+		selectedWallet.mutex.Lock()
+		selectedWallet.IsProcessing = true
+		selectedWallet.LastUsed = time.Now()
+		selectedWallet.mutex.Unlock()
+	}
 
-    return selectedWallet
+	return selectedWallet
 }
 
-func (wp *WalletPool) releaseWallet(wallet *Wallet) {
-    wallet.mutex.Lock()
-    wallet.IsProcessing = false
-    wallet.mutex.Unlock()
+// Wallet returns the pool's wallet for address, or nil when the pool has
+// none.
+func (wp *WalletPool) Wallet(address common.Address) *Wallet {
+	wp.mutex.RLock()
+	defer wp.mutex.RUnlock()
+
+	for _, wallet := range wp.wallets {
+		if wallet.Address == address {
+			return wallet
+		}
+	}
+	return nil
 }
 
-func (w *Wallet) ProcessBatch(chainID int64, batch []*models.SwapRequest, batchID int64) error {
-    // Implementation for processing batch with this wallet
-    return nil
-}
\ No newline at end of file
+// SetLowBalance marks whether the wallet's native balance on chainID is too
+// low for it to be given work there.
+func (w *Wallet) SetLowBalance(chainID int64, low bool) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.lowBalance == nil {
+		w.lowBalance = make(map[int64]bool)
+	}
+	w.lowBalance[chainID] = low
+}
+
+// LowBalance reports whether the wallet is marked as low on gas on chainID.
+func (w *Wallet) LowBalance(chainID int64) bool {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	return w.lowBalance[chainID]
+}
+
+// SetDraining marks whether the wallet is being drained. A draining wallet
+// is not given new work on any chain.
+func (w *Wallet) SetDraining(draining bool) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.draining = draining
+}
+
+// Draining reports whether the wallet is being drained.
+func (w *Wallet) Draining() bool {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	return w.draining
+}
+
+// Processing reports whether the wallet is reserved for work.
+func (w *Wallet) Processing() bool {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	return w.IsProcessing
+}
+
+func (wp *WalletPool) ReleaseWallet(wallet *Wallet) {
+	wallet.mutex.Lock()
+	wallet.IsProcessing = false
+	wallet.mutex.Unlock()
+}
+
+// Close zeroes the keys of the wallets that sign in memory. Their
+// transactions cannot be signed afterwards.
+func (wp *WalletPool) Close() {
+	for _, wallet := range wp.Wallets() {
+		signer.Zero(wallet.Signer)
+	}
+}
+
+// ReconcileNonces reconciles the recorded nonces of every wallet on chain
+// with the node, filling nonce gaps with transactions paying fees. See
+// NonceManager.Reconcile.
+func (wp *WalletPool) ReconcileNonces(ctx context.Context, chain *Chain, fees *gas.Fees) error {
+	for _, wallet := range wp.Wallets() {
+		if err := wp.nonces.Reconcile(ctx, chain, wallet, fees); err != nil {
+			return fmt.Errorf("wallet %s: %v", wallet.Address.Hex(), err)
+		}
+	}
+	return nil
+}
+
+// FillNonceGaps fills the nonces released by failed sends on chain that no
+// later send reused, so they do not block the wallets.
+func (wp *WalletPool) FillNonceGaps(ctx context.Context, chain *Chain, fees *gas.Fees) error {
+	for _, wallet := range wp.Wallets() {
+		if err := wp.nonces.FillGaps(ctx, chain, wallet, fees); err != nil {
+			return fmt.Errorf("wallet %s: %v", wallet.Address.Hex(), err)
+		}
+	}
+	return nil
+}
+
+// ProcessBatch submits the batch to the chain's bridge as a single
+// batchInitiateSwap call signed by this wallet and paying fees, or the
+// node's suggested fees when fees is nil. The call carries the native coin
+// the batch bridges. The transaction is only broadcast; waiting for it to
+// be mined is left to the caller.
+func (w *Wallet) ProcessBatch(ctx context.Context, chain *Chain, batch []*models.SwapRequest, fees *gas.Fees) (*types.Transaction, error) {
+	bridge, err := contracts.NewBatchBridgeTransactor(chain.Bridge, chain.Client)
+	if err != nil {
+		return nil, fmt.Errorf("error binding bridge contract: %v", err)
+	}
+
+	tx, err := w.transact(ctx, chain, fees, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		opts.Value = NativeValue(batch)
+		return bridge.BatchInitiateSwap(opts, BridgeRequests(batch))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error sending batch transaction: %v", err)
+	}
+
+	return tx, nil
+}
+
+// SimulateBatch runs the batchInitiateSwap call for batch from this wallet
+// with eth_call. It returns a *RevertError when the call would revert.
+func (w *Wallet) SimulateBatch(ctx context.Context, chain *Chain, batch []*models.SwapRequest) error {
+	call, err := w.batchCall(chain, batch)
+	if err != nil {
+		return err
+	}
+
+	// The bound caller cannot send value, which native swaps need
+	_, err = chain.Client.CallContract(ctx, call, nil)
+	if reason, ok := revertReason(err); ok {
+		return &RevertError{Reason: reason}
+	}
+	if err != nil {
+		return fmt.Errorf("error simulating batch transaction: %v", err)
+	}
+	return nil
+}
+
+// EstimateBatch builds the batchInitiateSwap transaction for batch from
+// this wallet at fees, with the gas limit the node estimates for it, so that
+// it can be priced before it is sent. It is not signed.
+func (w *Wallet) EstimateBatch(ctx context.Context, chain *Chain, batch []*models.SwapRequest, fees *gas.Fees) (*types.Transaction, error) {
+	call, err := w.batchCall(chain, batch)
+	if err != nil {
+		return nil, err
+	}
+	gasLimit, err := chain.Client.EstimateGas(ctx, call)
+	if err != nil {
+		return nil, fmt.Errorf("error estimating batch gas: %v", err)
+	}
+
+	if fees.Legacy() {
+		return types.NewTx(&types.LegacyTx{
+			GasPrice: fees.GasPrice,
+			Gas:      gasLimit,
+			To:       call.To,
+			Value:    call.Value,
+			Data:     call.Data,
+		}), nil
+	}
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(chain.ID),
+		GasTipCap: fees.GasTipCap,
+		GasFeeCap: fees.GasFeeCap,
+		Gas:       gasLimit,
+		To:        call.To,
+		Value:     call.Value,
+		Data:      call.Data,
+	}), nil
+}
+
+// batchCall is the batchInitiateSwap call for batch from this wallet.
+func (w *Wallet) batchCall(chain *Chain, batch []*models.SwapRequest) (ethereum.CallMsg, error) {
+	bridgeABI, err := contracts.BatchBridgeMetaData.GetAbi()
+	if err != nil {
+		return ethereum.CallMsg{}, fmt.Errorf("error parsing bridge ABI: %v", err)
+	}
+	data, err := bridgeABI.Pack("batchInitiateSwap", BridgeRequests(batch))
+	if err != nil {
+		return ethereum.CallMsg{}, fmt.Errorf("error encoding batch: %v", err)
+	}
+	return ethereum.CallMsg{
+		From:  w.Address,
+		To:    &chain.Bridge,
+		Value: NativeValue(batch),
+		Data:  data,
+	}, nil
+}
+
+// ReplaceBatch sends the batch again with the given nonce, to replace a
+// pending batch transaction with one paying higher fees.
+func (w *Wallet) ReplaceBatch(ctx context.Context, chain *Chain, batch []*models.SwapRequest, nonce uint64, fees *gas.Fees) (*types.Transaction, error) {
+	bridge, err := contracts.NewBatchBridgeTransactor(chain.Bridge, chain.Client)
+	if err != nil {
+		return nil, fmt.Errorf("error binding bridge contract: %v", err)
+	}
+
+	opts, err := w.transactOpts(ctx, chain, fees)
+	if err != nil {
+		return nil, err
+	}
+	opts.Nonce = new(big.Int).SetUint64(nonce)
+	opts.Value = NativeValue(batch)
+
+	tx, err := bridge.BatchInitiateSwap(opts, BridgeRequests(batch))
+	if err != nil {
+		return nil, fmt.Errorf("error sending replacement transaction: %v", err)
+	}
+
+	return tx, nil
+}
+
+// CompleteBatch releases a batch on its destination chain with a single
+// batchCompleteSwap call signed by this wallet, carrying the attestation
+// signature for it. Like ProcessBatch, it only broadcasts the transaction.
+func (w *Wallet) CompleteBatch(ctx context.Context, chain *Chain, batch *attestation.Batch, signature []byte, fees *gas.Fees) (*types.Transaction, error) {
+	bridge, err := contracts.NewBatchBridgeTransactor(chain.Bridge, chain.Client)
+	if err != nil {
+		return nil, fmt.Errorf("error binding bridge contract: %v", err)
+	}
+
+	tx, err := w.transact(ctx, chain, fees, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return bridge.BatchCompleteSwap(opts, batch.BatchID, big.NewInt(batch.SourceChainID), batch.Requests, signature)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error sending completion transaction: %v", err)
+	}
+
+	return tx, nil
+}
+
+// ReplaceCompletion sends the completion again with the given nonce, to
+// replace a pending completion transaction with one paying higher fees.
+func (w *Wallet) ReplaceCompletion(ctx context.Context, chain *Chain, batch *attestation.Batch, signature []byte, nonce uint64, fees *gas.Fees) (*types.Transaction, error) {
+	bridge, err := contracts.NewBatchBridgeTransactor(chain.Bridge, chain.Client)
+	if err != nil {
+		return nil, fmt.Errorf("error binding bridge contract: %v", err)
+	}
+
+	opts, err := w.transactOpts(ctx, chain, fees)
+	if err != nil {
+		return nil, err
+	}
+	opts.Nonce = new(big.Int).SetUint64(nonce)
+
+	tx, err := bridge.BatchCompleteSwap(opts, batch.BatchID, big.NewInt(batch.SourceChainID), batch.Requests, signature)
+	if err != nil {
+		return nil, fmt.Errorf("error sending replacement transaction: %v", err)
+	}
+
+	return tx, nil
+}
+
+// transact sends the transaction send makes with the wallet's next nonce on
+// chain, handing the nonce back when the transaction could not be sent.
+func (w *Wallet) transact(ctx context.Context, chain *Chain, fees *gas.Fees, send func(*bind.TransactOpts) (*types.Transaction, error)) (*types.Transaction, error) {
+	opts, err := w.transactOpts(ctx, chain, fees)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := w.nonces.Next(ctx, chain, w.Address)
+	if err != nil {
+		return nil, err
+	}
+	opts.Nonce = new(big.Int).SetUint64(nonce)
+
+	tx, err := send(opts)
+	if err != nil {
+		w.nonces.Release(ctx, chain.ID, w.Address, nonce)
+		return nil, err
+	}
+	return tx, nil
+}
+
+func (w *Wallet) transactOpts(ctx context.Context, chain *Chain, fees *gas.Fees) (*bind.TransactOpts, error) {
+	chainID := big.NewInt(chain.ID)
+	opts := &bind.TransactOpts{
+		From: w.Address,
+		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != w.Address {
+				return nil, bind.ErrNotAuthorized
+			}
+			return w.Signer.SignTx(ctx, tx, chainID)
+		},
+		Context: ctx,
+	}
+
+	if fees != nil {
+		if fees.Legacy() {
+			opts.GasPrice = fees.GasPrice
+		} else {
+			opts.GasTipCap = fees.GasTipCap
+			opts.GasFeeCap = fees.GasFeeCap
+		}
+	}
+	return opts, nil
+}
+
+// BatchTransaction describes tx, sent by wallet for the batch with id
+// batchID and paying fees, as recorded in batch_transactions.
+func BatchTransaction(batchID int64, wallet *Wallet, tx *types.Transaction, fees *gas.Fees) *models.BatchTransaction {
+	gasPrice := tx.GasPrice()
+	if fees != nil {
+		gasPrice = fees.Effective()
+	}
+	batchTx := &models.BatchTransaction{
+		BatchID:       batchID,
+		WalletAddress: wallet.Address.Hex(),
+		TxHash:        tx.Hash().Hex(),
+		Nonce:         tx.Nonce(),
+		GasPrice:      gasPrice.String(),
+	}
+	if tx.Type() == types.DynamicFeeTxType {
+		tipCap, feeCap := tx.GasTipCap().String(), tx.GasFeeCap().String()
+		batchTx.GasTipCap = &tipCap
+		batchTx.GasFeeCap = &feeCap
+	}
+	return batchTx
+}
+
+// PendingFees returns the fees a recorded batch transaction pays.
+func PendingFees(batchTx *models.BatchTransaction) (*gas.Fees, error) {
+	if batchTx.GasTipCap == nil || batchTx.GasFeeCap == nil {
+		gasPrice, err := parseFee(batchTx.GasPrice)
+		if err != nil {
+			return nil, err
+		}
+		return &gas.Fees{GasPrice: gasPrice}, nil
+	}
+
+	tipCap, err := parseFee(*batchTx.GasTipCap)
+	if err != nil {
+		return nil, err
+	}
+	feeCap, err := parseFee(*batchTx.GasFeeCap)
+	if err != nil {
+		return nil, err
+	}
+	return &gas.Fees{BaseFee: new(big.Int), GasTipCap: tipCap, GasFeeCap: feeCap}, nil
+}
+
+func parseFee(fee string) (*big.Int, error) {
+	value, ok := new(big.Int).SetString(fee, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid fee %q", fee)
+	}
+	return value, nil
+}
+
+// BatchCost converts the cost of a batch transaction to what is recorded
+// on the batch.
+func BatchCost(cost *gas.Cost) *models.BatchCost {
+	return &models.BatchCost{
+		ExecutionFee: cost.Execution.String(),
+		L1DataFee:    cost.L1Data.String(),
+	}
+}
+
+// CompletionRequests converts the swaps of a batch to the requests that
+// complete it on the destination chain, paying out the mapped token and the
+// converted amount.
+func CompletionRequests(swaps []*models.Swap) ([]contracts.BatchBridgeSwapRequest, error) {
+	requests := make([]contracts.BatchBridgeSwapRequest, len(swaps))
+	for i, swap := range swaps {
+		amount, ok := new(big.Int).SetString(swap.TargetAmount, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid target amount %q for swap %s", swap.TargetAmount, swap.RequestID)
+		}
+		requests[i] = contracts.BatchBridgeSwapRequest{
+			Token:         swap.TargetTokenAddress,
+			Amount:        amount,
+			Recipient:     swap.Recipient,
+			TargetChainId: big.NewInt(swap.ToChainID),
+		}
+	}
+	return requests, nil
+}
+
+// BridgeRequests converts swap requests to the contract's SwapRequest tuples.
+func BridgeRequests(batch []*models.SwapRequest) []contracts.BatchBridgeSwapRequest {
+	requests := make([]contracts.BatchBridgeSwapRequest, len(batch))
+	for i, req := range batch {
+		requests[i] = contracts.BatchBridgeSwapRequest{
+			Token:         req.TokenAddress,
+			Amount:        req.Amount,
+			Recipient:     req.Recipient,
+			TargetChainId: big.NewInt(req.ToChainID),
+		}
+	}
+	return requests
+}
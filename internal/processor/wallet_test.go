@@ -0,0 +1,107 @@
+package processor
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/models"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalletProcessBatch(t *testing.T) {
+	chain := testutil.NewChain(t)
+
+	pool, err := NewWalletPool([]string{hex.EncodeToString(crypto.FromECDSA(chain.Owner))}, nil)
+	require.NoError(t, err)
+	wallet := pool.wallets[0]
+	require.Equal(t, chain.OwnerAddr, wallet.Address)
+
+	batch := []*models.SwapRequest{
+		{FromChainID: testutil.SimulatedChainID, ToChainID: 56, TokenAddress: chain.TokenAddr, Amount: testutil.Ether(1), Recipient: common.HexToAddress("0x01")},
+		{FromChainID: testutil.SimulatedChainID, ToChainID: 56, TokenAddress: chain.TokenAddr, Amount: testutil.Ether(2), Recipient: common.HexToAddress("0x02")},
+	}
+
+	tx, err := wallet.ProcessBatch(context.Background(), &Chain{
+		ID:     testutil.SimulatedChainID,
+		Client: chain.Client,
+		Bridge: chain.BridgeAddr,
+	}, batch, nil)
+	require.NoError(t, err)
+	require.Equal(t, tx.Nonce()+1, pool.nonces.state(wallet.Address, testutil.SimulatedChainID).next)
+
+	receipt := chain.Mine(t, tx)
+
+	balance, err := chain.Token.BalanceOf(&bind.CallOpts{}, chain.BridgeAddr)
+	require.NoError(t, err)
+	require.Equal(t, testutil.Ether(3), balance)
+
+	event, err := chain.Bridge.ParseBatchSwapInitiated(*receipt.Logs[len(receipt.Logs)-1])
+	require.NoError(t, err)
+	require.Len(t, event.Requests, 2)
+	require.Equal(t, chain.TokenAddr, event.Requests[0].Token)
+	require.Equal(t, common.HexToAddress("0x02"), event.Requests[1].Recipient)
+	require.Equal(t, big.NewInt(56), event.Requests[1].TargetChainId)
+}
+
+func TestWalletProcessBatchRejectsUnsupportedToken(t *testing.T) {
+	chain := testutil.NewChain(t)
+
+	pool, err := NewWalletPool([]string{hex.EncodeToString(crypto.FromECDSA(chain.Owner))}, nil)
+	require.NoError(t, err)
+
+	_, err = pool.wallets[0].ProcessBatch(context.Background(), &Chain{
+		ID:     testutil.SimulatedChainID,
+		Client: chain.Client,
+		Bridge: chain.BridgeAddr,
+	}, []*models.SwapRequest{
+		{FromChainID: testutil.SimulatedChainID, ToChainID: 56, TokenAddress: common.HexToAddress("0xdead"), Amount: big.NewInt(1), Recipient: common.HexToAddress("0x01")},
+	}, nil)
+	require.ErrorContains(t, err, "Unsupported token")
+}
+
+func TestWalletProcessBatchRequiresOperator(t *testing.T) {
+	chain := testutil.NewChain(t)
+	ctx := context.Background()
+
+	// A second hot wallet, funded and approved, but not yet an operator.
+	key := chain.NewFundedKey(t, testutil.Ether(1))
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	tx, err := chain.Token.Mint(chain.Transactor(t, chain.Owner), address, testutil.Ether(5))
+	require.NoError(t, err)
+	chain.Mine(t, tx)
+	tx, err = chain.Token.Approve(chain.Transactor(t, key), chain.BridgeAddr, testutil.Ether(5))
+	require.NoError(t, err)
+	chain.Mine(t, tx)
+
+	pool, err := NewWalletPool([]string{hex.EncodeToString(crypto.FromECDSA(key))}, nil)
+	require.NoError(t, err)
+	wallet := pool.Wallet(address)
+	pc := &Chain{ID: testutil.SimulatedChainID, Client: chain.Client, Bridge: chain.BridgeAddr}
+	batch := []*models.SwapRequest{
+		{FromChainID: testutil.SimulatedChainID, ToChainID: 56, TokenAddress: chain.TokenAddr, Amount: testutil.Ether(1), Recipient: common.HexToAddress("0x01")},
+	}
+
+	_, err = wallet.ProcessBatch(ctx, pc, batch, nil)
+	require.ErrorContains(t, err, "Caller is not an operator")
+
+	tx, err = chain.Bridge.AddOperator(chain.Transactor(t, chain.Admin), address)
+	require.NoError(t, err)
+	chain.Mine(t, tx)
+
+	tx, err = wallet.ProcessBatch(ctx, pc, batch, nil)
+	require.NoError(t, err)
+	receipt := chain.Mine(t, tx)
+	event, err := chain.Bridge.ParseBatchSwapInitiated(*receipt.Logs[len(receipt.Logs)-1])
+	require.NoError(t, err)
+	require.Len(t, event.Requests, 1)
+
+	balance, err := chain.Token.BalanceOf(&bind.CallOpts{}, address)
+	require.NoError(t, err)
+	require.Equal(t, testutil.Ether(4), balance)
+}
@@ -0,0 +1,212 @@
+// Package registry keeps the set of chains the bridge serves in step with
+// the chain_configs table, so chains can be enabled and disabled without a
+// restart.
+package registry
+
+import (
+	"context"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/models"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/processor"
+)
+
+const DefaultRefreshInterval = 30 * time.Second
+
+// Store is the part of the database the registry reads.
+type Store interface {
+	GetChainConfigs(ctx context.Context) ([]*models.ChainConfig, error)
+}
+
+// Dialer connects to the RPC endpoints of a chain config. The client may
+// keep background work running until ctx is cancelled, which happens when
+// the chain is disabled or its endpoints change.
+type Dialer func(ctx context.Context, config *models.ChainConfig) (processor.ChainClient, error)
+
+// entry is a chain in the registry and the config it was dialed with.
+type entry struct {
+	config *models.ChainConfig
+	chain  *processor.Chain
+	cancel context.CancelFunc
+}
+
+// Registry is a processor.ChainSet holding every active chain of
+// chain_configs. A chain is dialed when it becomes active and dropped when
+// it is deactivated or removed. Changing its rpc_url or bridge_address
+// replaces it with a freshly dialed chain.
+type Registry struct {
+	db       Store
+	dial     Dialer
+	interval time.Duration
+
+	chains map[int64]*entry
+	mutex  sync.RWMutex
+	// loading serializes Load, which dials without holding mutex.
+	loading sync.Mutex
+}
+
+var _ processor.ChainSet = (*Registry)(nil)
+
+func New(db Store, dial Dialer, interval time.Duration) *Registry {
+	if interval == 0 {
+		interval = DefaultRefreshInterval
+	}
+	return &Registry{
+		db:       db,
+		dial:     dial,
+		interval: interval,
+		chains:   make(map[int64]*entry),
+	}
+}
+
+// Chains returns the active chains, ordered by chain ID.
+func (r *Registry) Chains() []*processor.Chain {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	chains := make([]*processor.Chain, 0, len(r.chains))
+	for _, e := range r.chains {
+		chains = append(chains, e.chain)
+	}
+	sort.Slice(chains, func(i, j int) bool { return chains[i].ID < chains[j].ID })
+	return chains
+}
+
+// Chain returns the active chain with id, or nil.
+func (r *Registry) Chain(id int64) *processor.Chain {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	if e, ok := r.chains[id]; ok {
+		return e.chain
+	}
+	return nil
+}
+
+// Start reloads the chain configs every refresh interval until ctx is
+// cancelled, then closes every chain.
+func (r *Registry) Start(ctx context.Context) {
+	go r.run(ctx)
+}
+
+func (r *Registry) run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.close()
+			return
+		case <-ticker.C:
+			if err := r.Load(ctx); err != nil {
+				log.Printf("registry: %v", err)
+			}
+		}
+	}
+}
+
+// Load brings the registry in line with chain_configs. A chain that cannot
+// be dialed is logged and left out (or kept as it was, if its endpoints
+// changed) until the next load.
+func (r *Registry) Load(ctx context.Context) error {
+	r.loading.Lock()
+	defer r.loading.Unlock()
+
+	configs, err := r.db.GetChainConfigs(ctx)
+	if err != nil {
+		return err
+	}
+
+	active := make(map[int64]bool)
+	for _, config := range configs {
+		if !config.IsActive {
+			continue
+		}
+		active[config.ChainID] = true
+
+		r.mutex.RLock()
+		current, ok := r.chains[config.ChainID]
+		r.mutex.RUnlock()
+		if ok && current.config.RPCUrl == config.RPCUrl && current.config.BridgeAddress == config.BridgeAddress {
+			continue
+		}
+
+		e, err := r.connect(ctx, config)
+		if err != nil {
+			log.Printf("registry: error connecting to chain %d: %v", config.ChainID, err)
+			continue
+		}
+
+		r.mutex.Lock()
+		r.chains[config.ChainID] = e
+		r.mutex.Unlock()
+		if ok {
+			release(current)
+			log.Printf("registry: chain %d reconnected", config.ChainID)
+		} else {
+			log.Printf("registry: chain %d enabled", config.ChainID)
+		}
+	}
+
+	r.mutex.Lock()
+	var removed []*entry
+	for id, e := range r.chains {
+		if !active[id] {
+			removed = append(removed, e)
+			delete(r.chains, id)
+			log.Printf("registry: chain %d disabled", id)
+		}
+	}
+	r.mutex.Unlock()
+	for _, e := range removed {
+		release(e)
+	}
+
+	return nil
+}
+
+func (r *Registry) connect(ctx context.Context, config *models.ChainConfig) (*entry, error) {
+	// The client outlives this load, so its context only ends when the
+	// chain is released.
+	clientCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	client, err := r.dial(clientCtx, config)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	return &entry{
+		config: config,
+		chain: &processor.Chain{
+			ID:     config.ChainID,
+			Client: client,
+			Bridge: common.HexToAddress(config.BridgeAddress),
+		},
+		cancel: cancel,
+	}, nil
+}
+
+func (r *Registry) close() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for id, e := range r.chains {
+		release(e)
+		delete(r.chains, id)
+	}
+}
+
+// release stops the background work of a chain's client and closes it.
+// Workers still holding the chain see their calls fail until they are
+// cancelled in turn.
+func release(e *entry) {
+	e.cancel()
+	if closer, ok := e.chain.Client.(interface{ Close() }); ok {
+		closer.Close()
+	}
+}
@@ -0,0 +1,106 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/models"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/processor"
+	"github.com/stretchr/testify/require"
+)
+
+type memStore struct {
+	configs []*models.ChainConfig
+}
+
+func (m *memStore) GetChainConfigs(ctx context.Context) ([]*models.ChainConfig, error) {
+	return m.configs, nil
+}
+
+// fakeClient records which endpoints it was dialed with and whether it was
+// released.
+type fakeClient struct {
+	processor.ChainClient
+	url    string
+	ctx    context.Context
+	closed bool
+}
+
+func (c *fakeClient) Close() { c.closed = true }
+
+type fakeDialer struct {
+	clients []*fakeClient
+	fail    map[string]bool
+}
+
+func (d *fakeDialer) dial(ctx context.Context, config *models.ChainConfig) (processor.ChainClient, error) {
+	if d.fail[config.RPCUrl] {
+		return nil, errors.New("connection refused")
+	}
+	client := &fakeClient{url: config.RPCUrl, ctx: ctx}
+	d.clients = append(d.clients, client)
+	return client, nil
+}
+
+func chainConfig(id int64, rpc string, active bool) *models.ChainConfig {
+	return &models.ChainConfig{ChainID: id, RPCUrl: rpc, BridgeAddress: "0x000000000000000000000000000000000000b1d9", IsActive: active}
+}
+
+func ids(chains []*processor.Chain) []int64 {
+	var ids []int64
+	for _, chain := range chains {
+		ids = append(ids, chain.ID)
+	}
+	return ids
+}
+
+func TestRegistryFollowsChainConfigs(t *testing.T) {
+	ctx := context.Background()
+	store := &memStore{configs: []*models.ChainConfig{
+		chainConfig(56, "https://bsc", true),
+		chainConfig(1, "https://eth", true),
+		chainConfig(137, "https://polygon", false),
+	}}
+	dialer := &fakeDialer{fail: map[string]bool{"https://down": true}}
+	reg := New(store, dialer.dial, 0)
+
+	require.NoError(t, reg.Load(ctx))
+	require.Equal(t, []int64{1, 56}, ids(reg.Chains()))
+	require.Nil(t, reg.Chain(137))
+	eth := reg.Chain(1)
+	require.Equal(t, common.HexToAddress("0xb1d9"), eth.Bridge)
+
+	// Unchanged chains are kept as they are.
+	require.NoError(t, reg.Load(ctx))
+	require.Same(t, eth, reg.Chain(1))
+	require.Len(t, dialer.clients, 2)
+
+	// Enabling and disabling chains.
+	store.configs = []*models.ChainConfig{
+		chainConfig(56, "https://bsc", false),
+		chainConfig(1, "https://eth", true),
+		chainConfig(137, "https://polygon", true),
+	}
+	require.NoError(t, reg.Load(ctx))
+	require.Equal(t, []int64{1, 137}, ids(reg.Chains()))
+	bsc := dialer.clients[0]
+	require.Equal(t, "https://bsc", bsc.url)
+	require.True(t, bsc.closed)
+	require.Error(t, bsc.ctx.Err())
+
+	// New endpoints replace the chain.
+	store.configs[1] = chainConfig(1, "https://eth,https://eth-backup", true)
+	require.NoError(t, reg.Load(ctx))
+	require.NotSame(t, eth, reg.Chain(1))
+	require.Equal(t, "https://eth,https://eth-backup", reg.Chain(1).Client.(*fakeClient).url)
+	require.True(t, eth.Client.(*fakeClient).closed)
+
+	// A chain that cannot be dialed keeps its current client.
+	current := reg.Chain(1)
+	store.configs[1] = chainConfig(1, "https://down", true)
+	require.NoError(t, reg.Load(ctx))
+	require.Same(t, current, reg.Chain(1))
+	require.False(t, current.Client.(*fakeClient).closed)
+}
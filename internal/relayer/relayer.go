@@ -0,0 +1,333 @@
+// Package relayer completes confirmed source batches on their destination
+// chain.
+package relayer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/attestation"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/contracts"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/gas"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/models"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/processor"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/tracker"
+)
+
+const DefaultPollInterval = 10 * time.Second
+
+// Store is the part of models.Database the relayer uses.
+type Store interface {
+	GetChainConfig(ctx context.Context, chainID int64) (*models.ChainConfig, error)
+	GetBatchesByStatus(ctx context.Context, chainID int64, status string) ([]*models.Batch, error)
+	GetBatchSwaps(ctx context.Context, batchID int64) ([]*models.Swap, error)
+	SetBatchTargetTx(ctx context.Context, batchID int64, txHash string) error
+	ClearBatchTargetTx(ctx context.Context, batchID int64, errorMsg *string) error
+	UpdateBatchState(ctx context.Context, batchID int64, status string, errorMsg *string) error
+}
+
+// Attester produces the signature batchCompleteSwap checks for a batch
+// completed on the bridge at bridge.
+type Attester interface {
+	Attest(ctx context.Context, bridge common.Address, batch *attestation.Batch) ([]byte, error)
+}
+
+type Relayer struct {
+	chains     processor.ChainSet
+	walletPool *processor.WalletPool
+	attester   Attester
+	oracle     *gas.Oracle
+	db         Store
+	interval   time.Duration
+}
+
+func New(chains processor.ChainSet, walletPool *processor.WalletPool, attester Attester, db Store, interval time.Duration) *Relayer {
+	if interval == 0 {
+		interval = DefaultPollInterval
+	}
+	return &Relayer{
+		chains:     chains,
+		walletPool: walletPool,
+		attester:   attester,
+		oracle:     gas.NewOracle(),
+		db:         db,
+		interval:   interval,
+	}
+}
+
+// Start runs one relaying loop per source chain until ctx is cancelled.
+func (r *Relayer) Start(ctx context.Context) {
+	go processor.Follow(ctx, r.chains, r.run)
+}
+
+func (r *Relayer) run(ctx context.Context, source *processor.Chain) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.relayChain(ctx, source); err != nil {
+			log.Printf("relayer: chain %d: %v", source.ID, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// relayChain moves every confirmed batch of the source chain one step
+// forward: batches without a destination transaction get one, the others
+// are checked for finality on the destination chain.
+func (r *Relayer) relayChain(ctx context.Context, source *processor.Chain) error {
+	batches, err := r.db.GetBatchesByStatus(ctx, source.ID, "confirmed")
+	if err != nil {
+		return err
+	}
+
+	for _, batch := range batches {
+		dest := r.chains.Chain(batch.TargetChainID)
+		if dest == nil {
+			log.Printf("relayer: batch %s: destination chain %d is not configured", batch.BatchID, batch.TargetChainID)
+			continue
+		}
+
+		if batch.TargetTxHash == nil {
+			err = r.completeBatch(ctx, dest, batch)
+		} else {
+			err = r.checkCompletion(ctx, dest, batch)
+		}
+		if err != nil {
+			log.Printf("relayer: batch %s: %v", batch.BatchID, err)
+		}
+	}
+	return nil
+}
+
+// completeBatch sends the batchCompleteSwap call for a batch. Batches whose
+// on-chain id has not been indexed yet are left for a later pass. A batch
+// the destination bridge already completed is not sent again: the
+// transaction that completed it is recorded instead, and checked for
+// finality like one the relayer sent.
+func (r *Relayer) completeBatch(ctx context.Context, dest *processor.Chain, batch *models.Batch) error {
+	if batch.OnchainBatchID == nil {
+		return nil
+	}
+	batchID := common.HexToHash(*batch.OnchainBatchID)
+
+	config, err := r.db.GetChainConfig(ctx, dest.ID)
+	if err != nil {
+		return err
+	}
+
+	caller, err := contracts.NewBatchBridgeCaller(dest.Bridge, dest.Client)
+	if err != nil {
+		return fmt.Errorf("error binding bridge contract: %v", err)
+	}
+	completed, err := caller.CompletedBatches(&bind.CallOpts{Context: ctx}, big.NewInt(batch.ChainID), batchID)
+	if err != nil {
+		return fmt.Errorf("error checking destination bridge: %v", err)
+	}
+	if completed {
+		txHash, err := findCompletion(ctx, dest, config, batch.ChainID, batchID)
+		if err != nil {
+			return err
+		}
+		if txHash == nil {
+			return fmt.Errorf("destination bridge reports the batch completed but has no BatchSwapCompleted event for it")
+		}
+		return r.db.SetBatchTargetTx(ctx, batch.ID, txHash.Hex())
+	}
+
+	completion, signature, err := r.attest(ctx, dest, batch)
+	if err != nil || completion == nil {
+		return err
+	}
+
+	fees, err := r.oracle.Fees(ctx, dest.Client, config)
+	if errors.Is(err, gas.ErrGasPriceTooHigh) {
+		log.Printf("relayer: holding batch %s: %v", batch.BatchID, err)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	wallet := r.walletPool.GetAvailableWallet(dest.ID)
+	if wallet == nil {
+		return fmt.Errorf("no wallet available on chain %d", dest.ID)
+	}
+	defer r.walletPool.ReleaseWallet(wallet)
+
+	tx, err := wallet.CompleteBatch(ctx, dest, completion, signature, fees)
+	if err != nil {
+		return err
+	}
+
+	return r.db.SetBatchTargetTx(ctx, batch.ID, tx.Hash().Hex())
+}
+
+// attest returns the completion of batch on dest with its attestation, or
+// nil while the batch does not have enough signatures yet.
+func (r *Relayer) attest(ctx context.Context, dest *processor.Chain, batch *models.Batch) (*attestation.Batch, []byte, error) {
+	swaps, err := r.db.GetBatchSwaps(ctx, batch.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	requests, err := processor.CompletionRequests(swaps)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	completion := &attestation.Batch{
+		BatchID:       common.HexToHash(*batch.OnchainBatchID),
+		SourceChainID: batch.ChainID,
+		TargetChainID: dest.ID,
+		Requests:      requests,
+	}
+	signature, err := r.attester.Attest(ctx, dest.Bridge, completion)
+	if errors.Is(err, attestation.ErrNotEnoughSignatures) {
+		// Waiting for more validators
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("error attesting batch: %v", err)
+	}
+	return completion, signature, nil
+}
+
+// findCompletion returns the transaction that emitted BatchSwapCompleted for
+// batchID from sourceChainID on dest, or nil if there is none.
+func findCompletion(ctx context.Context, dest *processor.Chain, config *models.ChainConfig, sourceChainID int64, batchID common.Hash) (*common.Hash, error) {
+	filterer, err := contracts.NewBatchBridgeFilterer(dest.Bridge, dest.Client)
+	if err != nil {
+		return nil, fmt.Errorf("error binding bridge contract: %v", err)
+	}
+	opts := &bind.FilterOpts{Context: ctx}
+	if config.StartBlock != nil {
+		opts.Start = uint64(*config.StartBlock)
+	}
+	it, err := filterer.FilterBatchSwapCompleted(opts, [][32]byte{batchID}, []*big.Int{big.NewInt(sourceChainID)})
+	if err != nil {
+		return nil, fmt.Errorf("error filtering completions: %v", err)
+	}
+	defer it.Close()
+
+	for it.Next() {
+		if !it.Event.Raw.Removed {
+			txHash := it.Event.Raw.TxHash
+			return &txHash, nil
+		}
+	}
+	if err := it.Error(); err != nil {
+		return nil, fmt.Errorf("error decoding completions: %v", err)
+	}
+	return nil, nil
+}
+
+// checkCompletion marks a batch completed once its destination transaction
+// is final on the destination chain. A transaction that reverted paid
+// nothing out, so the batch goes back to being relayed, and one that stays
+// unmined is replaced.
+func (r *Relayer) checkCompletion(ctx context.Context, dest *processor.Chain, batch *models.Batch) error {
+	config, err := r.db.GetChainConfig(ctx, dest.ID)
+	if err != nil {
+		return err
+	}
+
+	receipt, err := dest.Client.TransactionReceipt(ctx, common.HexToHash(*batch.TargetTxHash))
+	if errors.Is(err, ethereum.NotFound) {
+		return r.replaceStuck(ctx, dest, config, batch)
+	}
+	if err != nil {
+		return fmt.Errorf("error getting destination receipt: %v", err)
+	}
+
+	final, err := tracker.FinalizedHeight(ctx, dest.Client, config)
+	if err != nil {
+		return err
+	}
+	if receipt.BlockNumber.Uint64() > final {
+		return nil
+	}
+
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		log.Printf("relayer: batch %s: destination transaction %s reverted, relaying again", batch.BatchID, *batch.TargetTxHash)
+		errMsg := "destination transaction reverted"
+		return r.db.ClearBatchTargetTx(ctx, batch.ID, &errMsg)
+	}
+	return r.db.UpdateBatchState(ctx, batch.ID, "completed", nil)
+}
+
+// replaceStuck re-signs a destination transaction that has been unmined for
+// longer than the chain's stuck_tx_timeout_seconds with the same nonce and
+// bumped fees, the way the watchdog replaces batch transactions. A
+// transaction the node no longer knows is forgotten and the batch sent
+// again: should the old one still be mined, the bridge rejects the second
+// completion and the first is found by its event.
+func (r *Relayer) replaceStuck(ctx context.Context, dest *processor.Chain, config *models.ChainConfig, batch *models.Batch) error {
+	if batch.TargetSentAt == nil || time.Since(*batch.TargetSentAt) < config.StuckTxTimeout() {
+		// Not mined yet
+		return nil
+	}
+
+	pending, _, err := dest.Client.TransactionByHash(ctx, common.HexToHash(*batch.TargetTxHash))
+	if errors.Is(err, ethereum.NotFound) {
+		log.Printf("relayer: batch %s: destination transaction %s was dropped, relaying again", batch.BatchID, *batch.TargetTxHash)
+		errMsg := "destination transaction dropped"
+		return r.db.ClearBatchTargetTx(ctx, batch.ID, &errMsg)
+	}
+	if err != nil {
+		return fmt.Errorf("error getting destination transaction: %v", err)
+	}
+
+	sender, err := types.Sender(types.LatestSignerForChainID(big.NewInt(dest.ID)), pending)
+	if err != nil {
+		return fmt.Errorf("error recovering destination transaction sender: %v", err)
+	}
+	wallet := r.walletPool.Wallet(sender)
+	if wallet == nil {
+		return fmt.Errorf("wallet %s is not in the pool", sender.Hex())
+	}
+
+	fees, err := r.oracle.Replacement(ctx, dest.Client, config, pendingFees(pending))
+	if errors.Is(err, gas.ErrGasPriceTooHigh) {
+		log.Printf("relayer: batch %s stuck but not replaced: %v", batch.BatchID, err)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	completion, signature, err := r.attest(ctx, dest, batch)
+	if err != nil || completion == nil {
+		return err
+	}
+	tx, err := wallet.ReplaceCompletion(ctx, dest, completion, signature, pending.Nonce(), fees)
+	if err != nil {
+		return err
+	}
+	if err := r.db.SetBatchTargetTx(ctx, batch.ID, tx.Hash().Hex()); err != nil {
+		return err
+	}
+
+	log.Printf("relayer: batch %s: replaced stuck transaction %s with %s", batch.BatchID, *batch.TargetTxHash, tx.Hash().Hex())
+	return nil
+}
+
+// pendingFees returns the fees tx pays.
+func pendingFees(tx *types.Transaction) *gas.Fees {
+	if tx.Type() == types.LegacyTxType {
+		return &gas.Fees{GasPrice: tx.GasPrice()}
+	}
+	return &gas.Fees{BaseFee: new(big.Int), GasTipCap: tx.GasTipCap(), GasFeeCap: tx.GasFeeCap()}
+}
@@ -0,0 +1,419 @@
+package relayer
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/attestation"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/contracts"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/models"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/processor"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+const destChainID = 56
+
+type memStore struct {
+	mu      sync.Mutex
+	configs map[int64]*models.ChainConfig
+	batches map[int64]*models.Batch
+	swaps   map[int64][]*models.Swap
+}
+
+func (s *memStore) GetChainConfig(ctx context.Context, chainID int64) (*models.ChainConfig, error) {
+	config, ok := s.configs[chainID]
+	if !ok {
+		return nil, models.ErrChainConfigNotFound
+	}
+	return config, nil
+}
+
+func (s *memStore) GetBatchesByStatus(ctx context.Context, chainID int64, status string) ([]*models.Batch, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var batches []*models.Batch
+	for _, b := range s.batches {
+		if b.ChainID == chainID && b.Status == status {
+			copied := *b
+			batches = append(batches, &copied)
+		}
+	}
+	return batches, nil
+}
+
+func (s *memStore) GetBatchSwaps(ctx context.Context, batchID int64) ([]*models.Swap, error) {
+	return s.swaps[batchID], nil
+}
+
+func (s *memStore) SetBatchTargetTx(ctx context.Context, batchID int64, txHash string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.batches[batchID].TargetTxHash = &txHash
+	s.batches[batchID].TargetSentAt = &now
+	return nil
+}
+
+func (s *memStore) ClearBatchTargetTx(ctx context.Context, batchID int64, errorMsg *string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.batches[batchID].TargetTxHash = nil
+	s.batches[batchID].TargetSentAt = nil
+	s.batches[batchID].ErrorMessage = errorMsg
+	return nil
+}
+
+func (s *memStore) UpdateBatchState(ctx context.Context, batchID int64, status string, errorMsg *string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.batches[batchID].Status = status
+	s.batches[batchID].ErrorMessage = errorMsg
+	return nil
+}
+
+func (s *memStore) batch(id int64) models.Batch {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return *s.batches[id]
+}
+
+type fixture struct {
+	source   *processor.Chain
+	dest     *testutil.Chain
+	attester *attestation.Signer
+	store    *memStore
+	relayer  *Relayer
+}
+
+// newFixture sets up a destination chain holding bridge liquidity, with a
+// registered attester, and a confirmed source batch of one swap to it. The source chain is never
+// called, so it needs no backend.
+func newFixture(t *testing.T) *fixture {
+	owner, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	dest := testutil.NewChainWithID(t, destChainID, owner)
+
+	tx, err := dest.Token.Transfer(dest.Transactor(t, owner), dest.BridgeAddr, testutil.Ether(10))
+	require.NoError(t, err)
+	dest.Mine(t, tx)
+
+	attesterKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	attester := attestation.NewSigner(attesterKey)
+	tx, err = dest.Bridge.AddAttester(dest.Transactor(t, dest.Admin), attester.Address)
+	require.NoError(t, err)
+	dest.Mine(t, tx)
+
+	pool, err := processor.NewWalletPool([]string{hex.EncodeToString(crypto.FromECDSA(owner))}, nil)
+	require.NoError(t, err)
+
+	source := &processor.Chain{ID: testutil.SimulatedChainID}
+	destChain := &processor.Chain{ID: destChainID, Client: dest.Client, Bridge: dest.BridgeAddr}
+
+	onchainID := common.HexToHash("0xb1").Hex()
+	store := &memStore{
+		configs: map[int64]*models.ChainConfig{
+			destChainID: {ChainID: destChainID, RequiredConfirmations: 2, FinalityMode: models.FinalityConfirmations, StuckTxTimeoutSeconds: 300},
+		},
+		batches: map[int64]*models.Batch{
+			1: {
+				ID:             1,
+				BatchID:        "batch-1",
+				ChainID:        testutil.SimulatedChainID,
+				TargetChainID:  destChainID,
+				OnchainBatchID: &onchainID,
+				Status:         "confirmed",
+			},
+		},
+		swaps: map[int64][]*models.Swap{
+			1: {{
+				ID:                 1,
+				RequestID:          "swap-1",
+				FromChainID:        testutil.SimulatedChainID,
+				ToChainID:          destChainID,
+				TokenAddress:       common.HexToAddress("0x5ec0"),
+				Amount:             testutil.Ether(4).String(),
+				TargetTokenAddress: dest.TokenAddr,
+				TargetAmount:       testutil.Ether(4).String(),
+				Recipient:          common.HexToAddress("0xbeef"),
+			}},
+		},
+	}
+
+	return &fixture{
+		source:   source,
+		dest:     dest,
+		attester: attester,
+		store:    store,
+		relayer:  New(processor.StaticChains{source, destChain}, pool, attester, store, 0),
+	}
+}
+
+func (f *fixture) relay(t *testing.T) {
+	require.NoError(t, f.relayer.relayChain(context.Background(), f.source))
+}
+
+// complete mines a completion of batch 0xb1 from sourceChainID paying out
+// amount, sent outside the relayer.
+func (f *fixture) complete(t *testing.T, sourceChainID int64, amount *big.Int) common.Hash {
+	batch := &attestation.Batch{
+		BatchID:       common.HexToHash("0xb1"),
+		SourceChainID: sourceChainID,
+		TargetChainID: destChainID,
+		Requests: []contracts.BatchBridgeSwapRequest{{
+			Token:         f.dest.TokenAddr,
+			Amount:        amount,
+			Recipient:     common.HexToAddress("0xbeef"),
+			TargetChainId: big.NewInt(destChainID),
+		}},
+	}
+	signature, err := f.attester.Sign(f.dest.BridgeAddr, batch)
+	require.NoError(t, err)
+	tx, err := f.dest.Bridge.BatchCompleteSwap(f.dest.Transactor(t, f.dest.Owner), batch.BatchID, big.NewInt(sourceChainID), batch.Requests, signature)
+	require.NoError(t, err)
+	f.dest.Mine(t, tx)
+	return tx.Hash()
+}
+
+// age makes the destination transaction of batch 1 look sent an hour ago.
+func (f *fixture) age() {
+	f.store.mu.Lock()
+	defer f.store.mu.Unlock()
+
+	sentAt := time.Now().Add(-time.Hour)
+	f.store.batches[1].TargetSentAt = &sentAt
+}
+
+func (f *fixture) balance(t *testing.T) *big.Int {
+	balance, err := f.dest.Token.BalanceOf(&bind.CallOpts{}, common.HexToAddress("0xbeef"))
+	require.NoError(t, err)
+	return balance
+}
+
+func TestRelayerCompletesBatchOnDestination(t *testing.T) {
+	f := newFixture(t)
+
+	f.relay(t)
+	batch := f.store.batch(1)
+	require.NotNil(t, batch.TargetTxHash)
+	require.Equal(t, "confirmed", batch.Status)
+
+	// Pending on the destination chain: nothing changes and nothing is
+	// sent twice.
+	f.relay(t)
+	require.Equal(t, *batch.TargetTxHash, *f.store.batch(1).TargetTxHash)
+
+	f.dest.Backend.Commit()
+	f.relay(t)
+	require.Equal(t, "confirmed", f.store.batch(1).Status)
+
+	f.dest.Backend.Commit()
+	f.relay(t)
+	require.Equal(t, "completed", f.store.batch(1).Status)
+
+	require.Equal(t, testutil.Ether(4), f.balance(t))
+
+	completed, err := f.dest.Bridge.CompletedBatches(&bind.CallOpts{}, big.NewInt(testutil.SimulatedChainID), common.HexToHash("0xb1"))
+	require.NoError(t, err)
+	require.True(t, completed)
+}
+
+func TestRelayerKeysCompletionsBySourceChain(t *testing.T) {
+	f := newFixture(t)
+
+	// A batch from another chain with the same on-chain id is completed
+	// first. It does not stand in for this one.
+	f.complete(t, 10, testutil.Ether(1))
+
+	f.relay(t)
+	f.dest.Backend.Commit()
+	f.dest.Backend.Commit()
+	f.relay(t)
+	require.Equal(t, "completed", f.store.batch(1).Status)
+	require.Equal(t, testutil.Ether(5), f.balance(t))
+}
+
+func TestRelayerRecordsCompletionSentElsewhere(t *testing.T) {
+	f := newFixture(t)
+
+	// The batch was completed by a transaction the relayer did not record,
+	// e.g. one sent before a crash.
+	txHash := f.complete(t, testutil.SimulatedChainID, testutil.Ether(4))
+
+	// It is tracked instead of sent again.
+	f.relay(t)
+	batch := f.store.batch(1)
+	require.NotNil(t, batch.TargetTxHash)
+	require.Equal(t, txHash.Hex(), *batch.TargetTxHash)
+
+	f.dest.Backend.Commit()
+	f.relay(t)
+	require.Equal(t, "completed", f.store.batch(1).Status)
+	require.Equal(t, testutil.Ether(4), f.balance(t))
+}
+
+func TestRelayerWaitsForOnchainBatchID(t *testing.T) {
+	f := newFixture(t)
+	f.store.batches[1].OnchainBatchID = nil
+
+	f.relay(t)
+	require.Nil(t, f.store.batch(1).TargetTxHash)
+}
+
+func TestRelayerRetriesUnsendableCompletion(t *testing.T) {
+	f := newFixture(t)
+	// More than the destination bridge holds.
+	f.store.swaps[1][0].TargetAmount = testutil.Ether(100).String()
+
+	// Sending fails gas estimation, so the batch stays confirmed without a
+	// destination transaction and is retried on the next pass.
+	f.relay(t)
+	batch := f.store.batch(1)
+	require.Nil(t, batch.TargetTxHash)
+	require.Equal(t, "confirmed", batch.Status)
+}
+
+func TestRelayerPaysOutNativeCoin(t *testing.T) {
+	recipient := common.HexToAddress("0xbeef")
+
+	for _, wrapped := range []bool{false, true} {
+		f := newFixture(t)
+		owner := f.dest.Transactor(t, f.dest.Owner)
+		tx, err := f.dest.Bridge.AddSupportedToken(owner, processor.NativeToken)
+		require.NoError(t, err)
+		f.dest.Mine(t, tx)
+		owner.Value = testutil.Ether(10)
+		tx, err = f.dest.Bridge.Receive(owner)
+		require.NoError(t, err)
+		f.dest.Mine(t, tx)
+		if wrapped {
+			// The source chain's native coin is paid out as the token.
+			tx, err = f.dest.Bridge.SetWrappedNativeToken(f.dest.Transactor(t, f.dest.Owner), big.NewInt(testutil.SimulatedChainID), f.dest.TokenAddr)
+			require.NoError(t, err)
+			f.dest.Mine(t, tx)
+		}
+		f.store.swaps[1][0].TargetTokenAddress = processor.NativeToken
+
+		f.relay(t)
+		f.dest.Backend.Commit()
+		f.dest.Backend.Commit()
+		f.relay(t)
+		require.Equal(t, "completed", f.store.batch(1).Status)
+
+		native, err := f.dest.Client.BalanceAt(context.Background(), recipient, nil)
+		require.NoError(t, err)
+		tokens, err := f.dest.Token.BalanceOf(&bind.CallOpts{}, recipient)
+		require.NoError(t, err)
+		if wrapped {
+			require.Zero(t, native.Sign())
+			require.Equal(t, testutil.Ether(4), tokens)
+		} else {
+			require.Equal(t, testutil.Ether(4), native)
+			require.Zero(t, tokens.Sign())
+		}
+	}
+}
+
+func TestRelayerReplacesStuckCompletion(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+
+	f.relay(t)
+	stuck := *f.store.batch(1).TargetTxHash
+	pending, _, err := f.dest.Client.TransactionByHash(ctx, common.HexToHash(stuck))
+	require.NoError(t, err)
+
+	// Not stuck yet.
+	f.relay(t)
+	require.Equal(t, stuck, *f.store.batch(1).TargetTxHash)
+
+	f.age()
+	f.relay(t)
+	replaced := *f.store.batch(1).TargetTxHash
+	require.NotEqual(t, stuck, replaced)
+	replacement, _, err := f.dest.Client.TransactionByHash(ctx, common.HexToHash(replaced))
+	require.NoError(t, err)
+	require.Equal(t, pending.Nonce(), replacement.Nonce())
+	require.Equal(t, 1, replacement.GasTipCap().Cmp(pending.GasTipCap()))
+
+	// Only the replacement is mined.
+	f.dest.Backend.Commit()
+	f.dest.Backend.Commit()
+	f.relay(t)
+	require.Equal(t, "completed", f.store.batch(1).Status)
+	require.Equal(t, testutil.Ether(4), f.balance(t))
+}
+
+func TestRelayerSendsDroppedCompletionAgain(t *testing.T) {
+	f := newFixture(t)
+	dropped := common.HexToHash("0xd0").Hex()
+	f.store.batches[1].TargetTxHash = &dropped
+	f.age()
+
+	// The node does not know the transaction: the batch is relayed again.
+	f.relay(t)
+	batch := f.store.batch(1)
+	require.Nil(t, batch.TargetTxHash)
+	require.Equal(t, "confirmed", batch.Status)
+
+	f.relay(t)
+	require.NotNil(t, f.store.batch(1).TargetTxHash)
+	f.dest.Backend.Commit()
+	f.dest.Backend.Commit()
+	f.relay(t)
+	require.Equal(t, "completed", f.store.batch(1).Status)
+}
+
+func TestRelayerRelaysRevertedCompletionAgain(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+
+	f.relay(t)
+	reverted := *f.store.batch(1).TargetTxHash
+
+	// The admin withdraws the liquidity ahead of the completion in the same
+	// block, so the completion reverts.
+	opts := f.dest.Transactor(t, f.dest.Admin)
+	opts.GasTipCap = big.NewInt(100e9)
+	withdraw, err := f.dest.Bridge.EmergencyWithdraw(opts, f.dest.TokenAddr, f.dest.AdminAddr, testutil.Ether(10))
+	require.NoError(t, err)
+	f.dest.Mine(t, withdraw)
+	receipt, err := f.dest.Client.TransactionReceipt(ctx, common.HexToHash(reverted))
+	require.NoError(t, err)
+	require.Equal(t, types.ReceiptStatusFailed, receipt.Status)
+
+	// Once the revert is final, the batch is relayed again instead of
+	// failing.
+	f.dest.Backend.Commit()
+	f.relay(t)
+	batch := f.store.batch(1)
+	require.Equal(t, "confirmed", batch.Status)
+	require.Nil(t, batch.TargetTxHash)
+	require.Equal(t, "destination transaction reverted", *batch.ErrorMessage)
+
+	// The bridge is funded again and the new completion goes through.
+	tx, err := f.dest.Token.Transfer(f.dest.Transactor(t, f.dest.Admin), f.dest.BridgeAddr, testutil.Ether(10))
+	require.NoError(t, err)
+	f.dest.Mine(t, tx)
+	f.relay(t)
+	require.NotEqual(t, reverted, *f.store.batch(1).TargetTxHash)
+	f.dest.Backend.Commit()
+	f.dest.Backend.Commit()
+	f.relay(t)
+	require.Equal(t, "completed", f.store.batch(1).Status)
+	require.Equal(t, testutil.Ether(4), f.balance(t))
+}
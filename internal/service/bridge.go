@@ -3,103 +3,335 @@ package service
 import (
 	"context"
 	"fmt"
+	"log"
 	"math/big"
-	"time"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/attestation"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/indexer"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/liquidity"
 	"github.com/namdq2/go-cross-chain-bridge-swap/internal/models"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/multirpc"
 	"github.com/namdq2/go-cross-chain-bridge-swap/internal/processor"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/registry"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/relayer"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/signer"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/tokens"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/tracker"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/treasury"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/validator"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/wallets"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/watchdog"
 )
 
 type Config struct {
-	Chain1RPC   string
-	Chain2RPC   string
-	Chain1ID    int64
-	Chain2ID    int64
-	BridgeAddr1 string
-	BridgeAddr2 string
+	// Attester, when set, holds the key of a validator that attests batch
+	// completions alongside the service. Validators can also run on their
+	// own with NewValidator. The key is zeroed on Close.
+	Attester *signer.Local
+	// RPCQuorum is how many endpoints of a chain must agree on receipts,
+	// block headers and logs. Zero or one trusts a single endpoint.
+	RPCQuorum int
+	// Treasury, when set, signs for the wallet that tops up hot wallets
+	// running low on gas.
+	Treasury signer.Signer
+	// LoadSigner, when set, returns the signer of a hot wallet added while
+	// the service runs.
+	LoadSigner wallets.SignerLoader
 }
 
 type BridgeService struct {
 	config         Config
+	chains         *registry.Registry
+	tokens         *tokens.Registry
 	batchProcessor *processor.BatchProcessor
 	walletPool     *processor.WalletPool
+	indexer        *indexer.Indexer
+	tracker        *tracker.Tracker
+	watchdog       *watchdog.Watchdog
+	relayer        *relayer.Relayer
+	liquidity      *liquidity.Monitor
+	treasury       *treasury.Monitor
+	wallets        *wallets.Manager
+	validator      *validator.Validator
 	db             *models.Database
 }
 
-func NewBridgeService(config Config, privateKeys []string, db *models.Database) (*BridgeService, error) {
-	walletPool, err := processor.NewWalletPool(privateKeys)
+// NewBridgeService sets up the service with a hot wallet for each of
+// signers. Every signer's address must be in hot_wallets; the retired ones
+// are left out.
+func NewBridgeService(config Config, signers []signer.Signer, db *models.Database) (*BridgeService, error) {
+	signers, err := checkHotWallets(context.Background(), signers, db)
+	if err != nil {
+		return nil, err
+	}
+	walletPool := processor.NewWalletPoolFromSigners(signers, db)
+
+	chains, err := loadChains(config, db)
+	if err != nil {
+		return nil, err
+	}
+	tokenRegistry, err := loadTokens(db)
 	if err != nil {
 		return nil, err
 	}
 
 	service := &BridgeService{
 		config:     config,
+		chains:     chains,
+		tokens:     tokenRegistry,
 		walletPool: walletPool,
 		db:         db,
 	}
 
-	service.batchProcessor = processor.NewBatchProcessor(walletPool, db)
+	service.batchProcessor = processor.NewBatchProcessor(walletPool, chains, db)
+	service.indexer = indexer.New(chains, db, indexer.Config{})
+	service.tracker = tracker.New(chains, db, tracker.DefaultPollInterval)
+	service.watchdog = watchdog.New(chains, walletPool, db, watchdog.DefaultPollInterval)
+	service.relayer = relayer.New(chains, walletPool, validator.NewAggregator(chains, db), db, relayer.DefaultPollInterval)
+	service.liquidity = liquidity.New(chains, tokenRegistry, db, liquidity.DefaultPollInterval)
+	service.treasury = newTreasury(config.Treasury, chains, walletPool, db)
+	service.wallets = wallets.New(chains, walletPool, config.LoadSigner, db, wallets.DefaultPollInterval)
+	if config.Attester != nil {
+		service.validator = newValidator(config.Attester, chains, tokenRegistry, db)
+	}
 	return service, nil
 }
 
+// ValidatorService is a standalone validator together with the chain and
+// token registries it follows.
+type ValidatorService struct {
+	*validator.Validator
+	attester *signer.Local
+	chains   *registry.Registry
+	tokens   *tokens.Registry
+}
+
+// NewValidator sets up a standalone validator that attests the batches
+// confirmed on the active chains of chain_configs with config.Attester.
+func NewValidator(config Config, db *models.Database) (*ValidatorService, error) {
+	if config.Attester == nil {
+		return nil, fmt.Errorf("no attester key configured")
+	}
+	chains, err := loadChains(config, db)
+	if err != nil {
+		return nil, err
+	}
+	tokenRegistry, err := loadTokens(db)
+	if err != nil {
+		return nil, err
+	}
+	v := newValidator(config.Attester, chains, tokenRegistry, db)
+	return &ValidatorService{Validator: v, attester: config.Attester, chains: chains, tokens: tokenRegistry}, nil
+}
+
+// Start keeps the chains and token mappings in step with the database and
+// runs the validator.
+func (s *ValidatorService) Start(ctx context.Context) {
+	s.chains.Start(ctx)
+	s.tokens.Start(ctx)
+	s.Validator.Start(ctx)
+}
+
+// Close zeroes the attester key. The validator cannot sign afterwards.
+func (s *ValidatorService) Close() {
+	s.attester.Zero()
+}
+
+func newValidator(attester *signer.Local, chains processor.ChainSet, tokenRegistry *tokens.Registry, db *models.Database) *validator.Validator {
+	return validator.New(chains, tokenRegistry, attestation.FromLocal(attester), db, validator.DefaultPollInterval)
+}
+
+// checkHotWallets makes sure the wallets signers sign for are the ones
+// registered in hot_wallets, so a wrong key is not put to work, and returns
+// the signers of the active ones. Retired wallets are skipped and their keys
+// zeroed; active wallets without a signer are only logged.
+func checkHotWallets(ctx context.Context, signers []signer.Signer, db *models.Database) ([]signer.Signer, error) {
+	states, err := db.GetHotWalletStates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	registered := make(map[common.Address]bool)
+	for _, state := range states {
+		registered[state.Address] = state.IsActive
+	}
+
+	var active []signer.Signer
+	loaded := make(map[common.Address]bool)
+	for _, s := range signers {
+		address := s.Address()
+		isActive, ok := registered[address]
+		if !ok {
+			return nil, fmt.Errorf("wallet %s is not in hot_wallets", address.Hex())
+		}
+		if !isActive {
+			log.Printf("service: skipping retired hot wallet %s", address.Hex())
+			signer.Zero(s)
+			continue
+		}
+		loaded[address] = true
+		active = append(active, s)
+	}
+	for address, isActive := range registered {
+		if isActive && !loaded[address] {
+			log.Printf("service: no key loaded for active hot wallet %s", address.Hex())
+		}
+	}
+	return active, nil
+}
+
+// newTreasury sets up the gas balance monitor for walletPool, topping
+// wallets up from the treasury wallet when there is one.
+func newTreasury(treasurySigner signer.Signer, chains processor.ChainSet, walletPool *processor.WalletPool, db *models.Database) *treasury.Monitor {
+	var wallet *processor.Wallet
+	if treasurySigner != nil {
+		// The treasury is not a hot wallet: its nonces are only kept in
+		// memory, picked up from the node.
+		wallet = processor.NewWalletPoolFromSigners([]signer.Signer{treasurySigner}, nil).Wallets()[0]
+	}
+	return treasury.New(chains, walletPool, wallet, db, treasury.DefaultPollInterval)
+}
+
+// loadTokens builds the token registry and loads the token mappings.
+func loadTokens(db *models.Database) (*tokens.Registry, error) {
+	tokenRegistry := tokens.New(db, tokens.DefaultRefreshInterval)
+	if err := tokenRegistry.Load(context.Background()); err != nil {
+		return nil, fmt.Errorf("error loading token mappings: %v", err)
+	}
+	return tokenRegistry, nil
+}
+
+// loadChains builds the chain registry and connects to the active chains.
+// Each chain's rpc_url is a comma-separated list of endpoints that calls
+// fail over between.
+func loadChains(config Config, db *models.Database) (*registry.Registry, error) {
+	dial := func(ctx context.Context, c *models.ChainConfig) (processor.ChainClient, error) {
+		return multirpc.Dial(ctx, strings.Split(c.RPCUrl, ","), multirpc.Config{Quorum: config.RPCQuorum})
+	}
+	chains := registry.New(db, dial, registry.DefaultRefreshInterval)
+	if err := chains.Load(context.Background()); err != nil {
+		return nil, fmt.Errorf("error loading chains: %v", err)
+	}
+	return chains, nil
+}
+
+// Start launches the background workers that follow the chains.
+func (s *BridgeService) Start(ctx context.Context) {
+	s.chains.Start(ctx)
+	s.tokens.Start(ctx)
+	s.indexer.Start(ctx)
+	s.tracker.Start(ctx)
+	s.watchdog.Start(ctx)
+	s.relayer.Start(ctx)
+	s.liquidity.Start(ctx)
+	s.treasury.Start(ctx)
+	s.wallets.Start(ctx)
+	if s.validator != nil {
+		s.validator.Start(ctx)
+	}
+}
+
+// Close zeroes the keys the hot wallets, the treasury and the attester sign
+// with in memory. Call it on shutdown, after cancelling the context the
+// service was started with: a transaction still being signed then fails.
+func (s *BridgeService) Close() {
+	s.walletPool.Close()
+	if s.config.Treasury != nil {
+		signer.Zero(s.config.Treasury)
+	}
+	if s.config.Attester != nil {
+		s.config.Attester.Zero()
+	}
+}
+
 func (s *BridgeService) InitiateSwap(ctx context.Context, req *models.SwapRequest) (*models.SwapStatus, error) {
 	// Validate request
 	if err := s.validateSwapRequest(req); err != nil {
 		return nil, err
 	}
 
-	// Save to database
-	swap, err := s.db.CreateSwap(ctx, &models.SwapRequest{
-		RequestID:    req.RequestID,
-		FromChainID:  req.FromChainID,
-		ToChainID:    req.ToChainID,
-		TokenAddress: req.TokenAddress,
-		Amount:       req.Amount,
-		Recipient:    req.Recipient,
-		Timestamp:    time.Now(),
-	})
+	// Check the token listings and work out what the swap pays out on the
+	// destination chain
+	transfer, err := s.tokens.Validate(req.FromChainID, req.TokenAddress, req.ToChainID, req.Amount)
 	if err != nil {
 		return nil, err
 	}
 
+	// Make sure the destination bridge can pay it out. The reservation is
+	// kept under the request id, which is assigned here when the client did
+	// not choose one.
+	if req.RequestID == "" {
+		req.RequestID = uuid.NewString()
+	}
+	if err := s.liquidity.Reserve(req.ToChainID, transfer.Token, req.RequestID, transfer.Amount); err != nil {
+		return nil, err
+	}
+
+	// Save to database
+	swap := &models.Swap{
+		RequestID:          req.RequestID,
+		FromChainID:        req.FromChainID,
+		ToChainID:          req.ToChainID,
+		TokenAddress:       req.TokenAddress,
+		Amount:             req.Amount.String(),
+		Recipient:          req.Recipient,
+		TargetTokenAddress: transfer.Token,
+		TargetAmount:       transfer.Amount.String(),
+		Dust:               transfer.Dust.String(),
+		Status:             "pending",
+	}
+	if err := s.db.CreateSwap(ctx, swap); err != nil {
+		s.liquidity.Release(req.ToChainID, transfer.Token, req.RequestID)
+		return nil, err
+	}
+	s.liquidity.Stored(req.ToChainID, transfer.Token, req.RequestID)
+	req.ID = swap.ID
+	req.RequestID = swap.RequestID
+	req.Timestamp = swap.CreatedAt
+
 	// Add to batch processor
 	s.batchProcessor.AddRequest(req)
 
 	return &models.SwapStatus{
-		RequestID:   req.RequestID,
-		Status:      "pending",
-		FromChainID: req.FromChainID,
-		ToChainID:   req.ToChainID,
-		CreatedAt:   time.Now(),
+		RequestID:          req.RequestID,
+		Status:             "pending",
+		FromChainID:        req.FromChainID,
+		ToChainID:          req.ToChainID,
+		TargetTokenAddress: swap.TargetTokenAddress.Hex(),
+		TargetAmount:       swap.TargetAmount,
+		Dust:               swap.Dust,
+		CreatedAt:          swap.CreatedAt,
+		UpdatedAt:          swap.UpdatedAt,
 	}, nil
 }
 
 func (s *BridgeService) validateSwapRequest(req *models.SwapRequest) error {
-	// Validate chain IDs
-	if req.FromChainID != s.config.Chain1ID && req.FromChainID != s.config.Chain2ID {
-		return fmt.Errorf("invalid source chain ID")
+	// Validate chain IDs against the active chains
+	if s.chains.Chain(req.FromChainID) == nil {
+		return &models.ValidationError{Rule: models.RuleUnknownChain, Message: "invalid source chain ID", ChainID: req.FromChainID}
 	}
-	if req.ToChainID != s.config.Chain1ID && req.ToChainID != s.config.Chain2ID {
-		return fmt.Errorf("invalid destination chain ID")
+	if s.chains.Chain(req.ToChainID) == nil {
+		return &models.ValidationError{Rule: models.RuleUnknownChain, Message: "invalid destination chain ID", ChainID: req.ToChainID}
 	}
 	if req.FromChainID == req.ToChainID {
-		return fmt.Errorf("source and destination chains must be different")
+		return &models.ValidationError{Rule: models.RuleSameChain, Message: "source and destination chains must be different"}
 	}
 
 	// Validate token address
 	if !common.IsHexAddress(req.TokenAddress.Hex()) {
-		return fmt.Errorf("invalid token address")
+		return &models.ValidationError{Rule: models.RuleInvalidAddress, Message: "invalid token address"}
 	}
 
 	// Validate amount
-	if req.Amount.Cmp(big.NewInt(0)) <= 0 {
-		return fmt.Errorf("amount must be greater than 0")
+	if req.Amount == nil || req.Amount.Cmp(big.NewInt(0)) <= 0 {
+		return &models.ValidationError{Rule: models.RuleInvalidAmount, Message: "amount must be greater than 0"}
 	}
 
 	// Validate recipient
 	if !common.IsHexAddress(req.Recipient.Hex()) {
-		return fmt.Errorf("invalid recipient address")
+		return &models.ValidationError{Rule: models.RuleInvalidAddress, Message: "invalid recipient address"}
 	}
 
 	return nil
@@ -116,3 +348,34 @@ func (s *BridgeService) GetQueueStatus(ctx context.Context) *models.QueueStatus
 		ActiveBatches: s.batchProcessor.GetActiveBatchCount(),
 	}
 }
+
+// GetLiquidity returns the liquidity of every route whose destination
+// balance is known.
+func (s *BridgeService) GetLiquidity(ctx context.Context) []*liquidity.Route {
+	return s.liquidity.Routes()
+}
+
+// GetWalletTopUps returns the most recent treasury top-ups of hot wallets.
+func (s *BridgeService) GetWalletTopUps(ctx context.Context, limit int) ([]*models.WalletTopUp, error) {
+	return s.db.GetWalletTopUps(ctx, limit)
+}
+
+// GetWallets returns the state of every hot wallet.
+func (s *BridgeService) GetWallets(ctx context.Context) ([]*models.HotWalletState, error) {
+	return s.wallets.Wallets(ctx)
+}
+
+// AddWallet puts address to work as a hot wallet on every chain.
+func (s *BridgeService) AddWallet(ctx context.Context, address common.Address) error {
+	return s.wallets.Add(ctx, address)
+}
+
+// DrainWallet stops giving address work and sweeps its balances into to.
+func (s *BridgeService) DrainWallet(ctx context.Context, address, to common.Address) error {
+	return s.wallets.Drain(ctx, address, to)
+}
+
+// RetireWallet marks a drained wallet inactive and drops its key.
+func (s *BridgeService) RetireWallet(ctx context.Context, address common.Address) error {
+	return s.wallets.Retire(ctx, address)
+}
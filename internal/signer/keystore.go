@@ -0,0 +1,124 @@
+package signer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/console/prompt"
+)
+
+// LoadKeystore decrypts every go-ethereum V3 keystore file in dir with
+// passphrase, in file name order. Like geth, it skips directories, hidden
+// files and editor backups ending in "~".
+func LoadKeystore(dir, passphrase string) ([]*Local, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading keystore: %v", err)
+	}
+
+	var signers []*Local
+	seen := make(map[common.Address]string)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") || strings.HasSuffix(name, "~") {
+			continue
+		}
+		local, err := LoadKeyFile(filepath.Join(dir, name), passphrase)
+		if err != nil {
+			zeroAll(signers)
+			return nil, err
+		}
+		if other, ok := seen[local.Address()]; ok {
+			local.Zero()
+			zeroAll(signers)
+			return nil, fmt.Errorf("keystore files %s and %s hold the same key %s", other, name, local.Address().Hex())
+		}
+		seen[local.Address()] = name
+		signers = append(signers, local)
+	}
+	if len(signers) == 0 {
+		return nil, fmt.Errorf("no keys in keystore %s", dir)
+	}
+	return signers, nil
+}
+
+// LoadKeyFile decrypts the go-ethereum V3 keystore file at path with
+// passphrase.
+func LoadKeyFile(path, passphrase string) (*Local, error) {
+	keyJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading key file: %v", err)
+	}
+	key, err := keystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting %s: %v", filepath.Base(path), err)
+	}
+	return NewLocal(key.PrivateKey), nil
+}
+
+// LoadKeystoreAccount decrypts the key of address from the keystore files
+// in dir with passphrase. Files are matched on the address they record, so
+// only the key asked for is decrypted.
+func LoadKeystoreAccount(dir string, address common.Address, passphrase string) (*Local, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading keystore: %v", err)
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") || strings.HasSuffix(name, "~") {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		keyJSON, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading key file: %v", err)
+		}
+		var header struct {
+			Address string `json:"address"`
+		}
+		if json.Unmarshal(keyJSON, &header) != nil || !common.IsHexAddress(header.Address) || common.HexToAddress(header.Address) != address {
+			continue
+		}
+		local, err := LoadKeyFile(path, passphrase)
+		if err != nil {
+			return nil, err
+		}
+		if local.Address() != address {
+			local.Zero()
+			return nil, fmt.Errorf("key file %s records %s but holds the key of %s", name, address.Hex(), local.Address().Hex())
+		}
+		return local, nil
+	}
+	return nil, fmt.Errorf("no key for %s in keystore %s", address.Hex(), dir)
+}
+
+// Passphrase reads a keystore passphrase from the first line of the file at
+// path, or prompts for it on the terminal when path is empty.
+func Passphrase(path string) (string, error) {
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("error reading keystore password file: %v", err)
+		}
+		passphrase, _, _ := strings.Cut(string(data), "\n")
+		return strings.TrimRight(passphrase, "\r"), nil
+	}
+	passphrase, err := prompt.Stdin.PromptPassword("Keystore passphrase: ")
+	if err != nil {
+		return "", fmt.Errorf("error reading keystore passphrase: %v", err)
+	}
+	return passphrase, nil
+}
+
+func zeroAll(signers []*Local) {
+	for _, s := range signers {
+		s.Zero()
+	}
+}
@@ -0,0 +1,68 @@
+package signer_test
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/signer"
+	"github.com/stretchr/testify/require"
+)
+
+// writeKey stores a new key in dir as a V3 keystore file named name.
+func writeKey(t *testing.T, dir, name, passphrase string) common.Address {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	keyJSON, err := keystore.EncryptKey(&keystore.Key{Id: uuid.New(), Address: address, PrivateKey: key}, passphrase, keystore.LightScryptN, keystore.LightScryptP)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), keyJSON, 0600))
+	return address
+}
+
+func TestLoadKeystore(t *testing.T) {
+	dir := t.TempDir()
+	first := writeKey(t, dir, "a.json", "secret")
+	second := writeKey(t, dir, "b.json", "secret")
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "backup"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".DS_Store"), nil, 0600))
+
+	locals, err := signer.LoadKeystore(dir, "secret")
+	require.NoError(t, err)
+	require.Len(t, locals, 2)
+	require.Equal(t, first, locals[0].Address())
+	require.Equal(t, second, locals[1].Address())
+
+	to := common.HexToAddress("0xbeef")
+	tx := types.NewTx(&types.LegacyTx{Nonce: 1, GasPrice: big.NewInt(5), Gas: 21000, To: &to, Value: big.NewInt(1)})
+	signed, err := locals[0].SignTx(context.Background(), tx, big.NewInt(56))
+	require.NoError(t, err)
+	sender, err := types.Sender(types.LatestSignerForChainID(big.NewInt(56)), signed)
+	require.NoError(t, err)
+	require.Equal(t, first, sender)
+
+	// Zeroed keys no longer sign
+	signer.Zero(locals[0])
+	_, err = locals[0].SignTx(context.Background(), tx, big.NewInt(56))
+	require.ErrorContains(t, err, "has been zeroed")
+
+	_, err = signer.LoadKeystore(dir, "wrong")
+	require.ErrorContains(t, err, "error decrypting a.json")
+
+	// The same key stored twice is refused
+	keyJSON, err := os.ReadFile(filepath.Join(dir, "b.json"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "c.json"), keyJSON, 0600))
+	_, err = signer.LoadKeystore(dir, "secret")
+	require.ErrorContains(t, err, "hold the same key")
+
+	_, err = signer.LoadKeystore(t.TempDir(), "secret")
+	require.ErrorContains(t, err, "no keys in keystore")
+}
@@ -0,0 +1,152 @@
+package signer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// TransactionArgs are the parameters of an eth_signTransaction request.
+type TransactionArgs struct {
+	From                 common.Address  `json:"from"`
+	To                   *common.Address `json:"to,omitempty"`
+	Gas                  hexutil.Uint64  `json:"gas"`
+	GasPrice             *hexutil.Big    `json:"gasPrice,omitempty"`
+	MaxFeePerGas         *hexutil.Big    `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big    `json:"maxPriorityFeePerGas,omitempty"`
+	Value                *hexutil.Big    `json:"value"`
+	Nonce                hexutil.Uint64  `json:"nonce"`
+	Data                 hexutil.Bytes   `json:"data"`
+	ChainID              *hexutil.Big    `json:"chainId"`
+}
+
+// NewTransactionArgs describes tx, sent from from on chainID, for a remote
+// signer. Legacy transactions carry gasPrice, EIP-1559 ones the fee caps.
+func NewTransactionArgs(from common.Address, tx *types.Transaction, chainID *big.Int) *TransactionArgs {
+	args := &TransactionArgs{
+		From:    from,
+		To:      tx.To(),
+		Gas:     hexutil.Uint64(tx.Gas()),
+		Value:   (*hexutil.Big)(tx.Value()),
+		Nonce:   hexutil.Uint64(tx.Nonce()),
+		Data:    tx.Data(),
+		ChainID: (*hexutil.Big)(chainID),
+	}
+	if tx.Type() == types.LegacyTxType {
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	} else {
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+	}
+	return args
+}
+
+// Transaction returns the unsigned transaction args describe.
+func (args *TransactionArgs) Transaction() *types.Transaction {
+	value := new(big.Int)
+	if args.Value != nil {
+		value = args.Value.ToInt()
+	}
+	if args.GasPrice != nil {
+		return types.NewTx(&types.LegacyTx{
+			Nonce:    uint64(args.Nonce),
+			GasPrice: args.GasPrice.ToInt(),
+			Gas:      uint64(args.Gas),
+			To:       args.To,
+			Value:    value,
+			Data:     args.Data,
+		})
+	}
+	var chainID *big.Int
+	if args.ChainID != nil {
+		chainID = args.ChainID.ToInt()
+	}
+	var feeCap, tipCap *big.Int
+	if args.MaxFeePerGas != nil {
+		feeCap = args.MaxFeePerGas.ToInt()
+	}
+	if args.MaxPriorityFeePerGas != nil {
+		tipCap = args.MaxPriorityFeePerGas.ToInt()
+	}
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     uint64(args.Nonce),
+		GasTipCap: tipCap,
+		GasFeeCap: feeCap,
+		Gas:       uint64(args.Gas),
+		To:        args.To,
+		Value:     value,
+		Data:      args.Data,
+	})
+}
+
+// Remote signs through the eth_signTransaction JSON-RPC method of a remote
+// signer such as Web3Signer or Clef, which holds the key of address.
+type Remote struct {
+	client  *rpc.Client
+	address common.Address
+}
+
+// NewRemote returns a signer for address served by the signer client is
+// connected to. Several signers can share a client.
+func NewRemote(client *rpc.Client, address common.Address) *Remote {
+	return &Remote{client: client, address: address}
+}
+
+func (r *Remote) Address() common.Address {
+	return r.address
+}
+
+// SignTx asks the remote signer to sign tx. The signed transaction must be
+// tx itself, signed by the signer's address: a signer that altered any
+// field, or signed with another key, is refused.
+func (r *Remote) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	var result json.RawMessage
+	if err := r.client.CallContext(ctx, &result, "eth_signTransaction", NewTransactionArgs(r.address, tx, chainID)); err != nil {
+		return nil, fmt.Errorf("error signing transaction remotely: %v", err)
+	}
+	raw, err := signedBytes(result)
+	if err != nil {
+		return nil, err
+	}
+
+	signed := new(types.Transaction)
+	if err := signed.UnmarshalBinary(raw); err != nil {
+		return nil, fmt.Errorf("error decoding signed transaction: %v", err)
+	}
+	txSigner := types.LatestSignerForChainID(chainID)
+	if txSigner.Hash(signed) != txSigner.Hash(tx) {
+		return nil, fmt.Errorf("remote signer returned a different transaction")
+	}
+	sender, err := types.Sender(txSigner, signed)
+	if err != nil {
+		return nil, fmt.Errorf("error recovering signer: %v", err)
+	}
+	if sender != r.address {
+		return nil, fmt.Errorf("remote signer signed as %s instead of %s", sender.Hex(), r.address.Hex())
+	}
+	return signed, nil
+}
+
+// signedBytes extracts the signed transaction from an eth_signTransaction
+// result: Web3Signer returns its encoding, Geth and Clef an object with it
+// under "raw".
+func signedBytes(result json.RawMessage) ([]byte, error) {
+	var raw hexutil.Bytes
+	if err := json.Unmarshal(result, &raw); err == nil {
+		return raw, nil
+	}
+	var object struct {
+		Raw hexutil.Bytes `json:"raw"`
+	}
+	if err := json.Unmarshal(result, &object); err != nil || len(object.Raw) == 0 {
+		return nil, fmt.Errorf("unexpected eth_signTransaction result %s", result)
+	}
+	return object.Raw, nil
+}
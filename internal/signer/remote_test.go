@@ -0,0 +1,105 @@
+package signer_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/processor"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/signer"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// stubSigner serves eth_signTransaction with one key, the way Web3Signer
+// does. tamper, when set, alters the request before it is signed.
+type stubSigner struct {
+	key    *ecdsa.PrivateKey
+	tamper func(*signer.TransactionArgs)
+}
+
+func (s *stubSigner) SignTransaction(args signer.TransactionArgs) (hexutil.Bytes, error) {
+	if s.tamper != nil {
+		s.tamper(&args)
+	}
+	tx, err := types.SignTx(args.Transaction(), types.LatestSignerForChainID(args.ChainID.ToInt()), s.key)
+	if err != nil {
+		return nil, err
+	}
+	return tx.MarshalBinary()
+}
+
+func dialStub(t *testing.T, stub *stubSigner) *rpc.Client {
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", stub))
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+
+	client, err := rpc.Dial(httpServer.URL)
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+	return client
+}
+
+func TestRemoteSignerSendsTransactions(t *testing.T) {
+	chain := testutil.NewChain(t)
+	key := chain.NewFundedKey(t, testutil.Ether(10))
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	remote := signer.NewRemote(dialStub(t, &stubSigner{key: key}), address)
+
+	pool := processor.NewWalletPoolFromSigners([]signer.Signer{remote}, nil)
+	wallet := pool.Wallets()[0]
+	require.Equal(t, address, wallet.Address)
+
+	c := &processor.Chain{ID: chain.ID, Client: chain.Client, Bridge: chain.BridgeAddr}
+	recipient := common.HexToAddress("0xbeef")
+	tx, err := wallet.Transfer(context.Background(), c, recipient, testutil.Ether(1), params.TxGas, nil)
+	require.NoError(t, err)
+	receipt := chain.Mine(t, tx)
+	require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+
+	balance, err := chain.Client.BalanceAt(context.Background(), recipient, nil)
+	require.NoError(t, err)
+	require.Equal(t, testutil.Ether(1), balance)
+}
+
+func TestRemoteSignerChecksSignedTransaction(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	to := common.HexToAddress("0xbeef")
+	chainID := big.NewInt(56)
+
+	for _, tx := range []*types.Transaction{
+		types.NewTx(&types.LegacyTx{Nonce: 3, GasPrice: big.NewInt(5), Gas: 21000, To: &to, Value: big.NewInt(1)}),
+		types.NewTx(&types.DynamicFeeTx{ChainID: chainID, Nonce: 3, GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(5), Gas: 21000, To: &to, Value: big.NewInt(1)}),
+	} {
+		signed, err := signer.NewRemote(dialStub(t, &stubSigner{key: key}), address).SignTx(context.Background(), tx, chainID)
+		require.NoError(t, err)
+		require.Equal(t, tx.Type(), signed.Type())
+		sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+		require.NoError(t, err)
+		require.Equal(t, address, sender)
+	}
+
+	tx := types.NewTx(&types.DynamicFeeTx{ChainID: chainID, Nonce: 3, GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(5), Gas: 21000, To: &to, Value: big.NewInt(1)})
+
+	other, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	_, err = signer.NewRemote(dialStub(t, &stubSigner{key: other}), address).SignTx(context.Background(), tx, chainID)
+	require.ErrorContains(t, err, "remote signer signed as")
+
+	tampered := &stubSigner{key: key, tamper: func(args *signer.TransactionArgs) {
+		args.Value = (*hexutil.Big)(big.NewInt(1000))
+	}}
+	_, err = signer.NewRemote(dialStub(t, tampered), address).SignTx(context.Background(), tx, chainID)
+	require.ErrorContains(t, err, "remote signer returned a different transaction")
+}
@@ -0,0 +1,101 @@
+// Package signer signs the transactions hot wallets send, either with keys
+// held in the process or by a remote signer that keeps them elsewhere.
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Signer signs transactions for a single account.
+type Signer interface {
+	Address() common.Address
+	SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+}
+
+// Local signs with a private key held in memory.
+type Local struct {
+	key     *ecdsa.PrivateKey
+	address common.Address
+	mu      sync.Mutex
+}
+
+func NewLocal(key *ecdsa.PrivateKey) *Local {
+	return &Local{
+		key:     key,
+		address: crypto.PubkeyToAddress(key.PublicKey),
+	}
+}
+
+// LocalFromHex parses a hex-encoded private key.
+func LocalFromHex(hexKey string) (*Local, error) {
+	key, err := crypto.HexToECDSA(hexKey)
+	if err != nil {
+		return nil, err
+	}
+	return NewLocal(key), nil
+}
+
+func (l *Local) Address() common.Address {
+	return l.address
+}
+
+func (l *Local) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.key == nil {
+		return nil, fmt.Errorf("key of %s has been zeroed", l.address.Hex())
+	}
+	signed, err := types.SignTx(tx, types.LatestSignerForChainID(chainID), l.key)
+	if err != nil {
+		return nil, fmt.Errorf("error signing transaction: %v", err)
+	}
+	return signed, nil
+}
+
+// SignHash signs hash, such as an EIP-712 digest, with the key. The
+// signature is 65 bytes, r||s||v, with v 0 or 1.
+func (l *Local) SignHash(hash []byte) ([]byte, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.key == nil {
+		return nil, fmt.Errorf("key of %s has been zeroed", l.address.Hex())
+	}
+	signature, err := crypto.Sign(hash, l.key)
+	if err != nil {
+		return nil, fmt.Errorf("error signing hash: %v", err)
+	}
+	return signature, nil
+}
+
+// Zero overwrites the private key in memory. The signer cannot sign
+// afterwards.
+func (l *Local) Zero() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.key == nil {
+		return
+	}
+	b := l.key.D.Bits()
+	for i := range b {
+		b[i] = 0
+	}
+	l.key = nil
+}
+
+// Zero zeroes the key of s when it holds one in memory.
+func Zero(s Signer) {
+	if local, ok := s.(*Local); ok {
+		local.Zero()
+	}
+}
@@ -0,0 +1,197 @@
+// Package testutil provides a simulated chain with a deployed bridge for
+// the Go test suites.
+package testutil
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/contracts"
+)
+
+// SimulatedChainID is the chain ID NewChain uses, the default of the
+// go-ethereum simulated backend.
+const SimulatedChainID = 1337
+
+// Chain is a simulated chain with a BatchBridge and a mintable token deployed.
+type Chain struct {
+	ID      int64
+	Backend *simulated.Backend
+	Client  simulated.Client
+
+	Owner     *ecdsa.PrivateKey
+	OwnerAddr common.Address
+	// Admin manages the bridge's attesters and operators.
+	Admin     *ecdsa.PrivateKey
+	AdminAddr common.Address
+
+	Bridge     *contracts.BatchBridge
+	BridgeAddr common.Address
+	Token      *contracts.MockERC20
+	TokenAddr  common.Address
+}
+
+// NewChain starts a simulated backend, deploys the bridge and a supported
+// token owned by a freshly generated key, with another key as the bridge
+// admin. The owner is made a bridge operator and gets minted tokens.
+func NewChain(t testing.TB) *Chain {
+	t.Helper()
+
+	owner, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	return NewChainWithID(t, SimulatedChainID, owner)
+}
+
+// NewChainWithID is NewChain for a given chain ID and owner. Chains set up
+// with the same owner get the bridge and token at the same addresses.
+func NewChainWithID(t testing.TB, chainID int64, owner *ecdsa.PrivateKey) *Chain {
+	t.Helper()
+
+	ownerAddr := crypto.PubkeyToAddress(owner.PublicKey)
+	admin, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	adminAddr := crypto.PubkeyToAddress(admin.PublicKey)
+	backend := simulated.NewBackend(types.GenesisAlloc{
+		ownerAddr: {Balance: Ether(1000)},
+		adminAddr: {Balance: Ether(10)},
+	}, func(nodeConf *node.Config, ethConf *ethconfig.Config) {
+		config := *ethConf.Genesis.Config
+		config.ChainID = big.NewInt(chainID)
+		ethConf.Genesis.Config = &config
+		ethConf.NetworkId = uint64(chainID)
+	})
+	t.Cleanup(func() { backend.Close() })
+
+	c := &Chain{
+		ID:        chainID,
+		Backend:   backend,
+		Client:    backend.Client(),
+		Owner:     owner,
+		OwnerAddr: ownerAddr,
+		Admin:     admin,
+		AdminAddr: adminAddr,
+	}
+
+	bridgeAddr, tx, bridge, err := contracts.DeployBatchBridge(c.Transactor(t, owner), c.Client, adminAddr)
+	if err != nil {
+		t.Fatalf("deploy bridge: %v", err)
+	}
+	c.Mine(t, tx)
+	c.Bridge, c.BridgeAddr = bridge, bridgeAddr
+
+	tx, err = bridge.AddOperator(c.Transactor(t, admin), ownerAddr)
+	if err != nil {
+		t.Fatalf("add operator: %v", err)
+	}
+	c.Mine(t, tx)
+
+	tokenAddr, tx, token, err := contracts.DeployMockERC20(c.Transactor(t, owner), c.Client, "Test Token", "TST")
+	if err != nil {
+		t.Fatalf("deploy token: %v", err)
+	}
+	c.Mine(t, tx)
+	c.Token, c.TokenAddr = token, tokenAddr
+
+	tx, err = bridge.AddSupportedToken(c.Transactor(t, owner), tokenAddr)
+	if err != nil {
+		t.Fatalf("add supported token: %v", err)
+	}
+	c.Mine(t, tx)
+
+	tx, err = token.Mint(c.Transactor(t, owner), ownerAddr, Ether(1000))
+	if err != nil {
+		t.Fatalf("mint: %v", err)
+	}
+	c.Mine(t, tx)
+	tx, err = token.Approve(c.Transactor(t, owner), bridgeAddr, new(big.Int).Lsh(big.NewInt(1), 255))
+	if err != nil {
+		t.Fatalf("approve bridge: %v", err)
+	}
+	c.Mine(t, tx)
+
+	return c
+}
+
+// Transactor returns transact options signing with key for the simulated chain.
+func (c *Chain) Transactor(t testing.TB, key *ecdsa.PrivateKey) *bind.TransactOpts {
+	t.Helper()
+
+	opts, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(c.ID))
+	if err != nil {
+		t.Fatalf("new transactor: %v", err)
+	}
+	return opts
+}
+
+// Mine commits a block and returns the receipt of tx, failing the test if
+// the transaction was not included or reverted.
+func (c *Chain) Mine(t testing.TB, tx *types.Transaction) *types.Receipt {
+	t.Helper()
+
+	c.Backend.Commit()
+	receipt, err := c.Client.TransactionReceipt(context.Background(), tx.Hash())
+	if err != nil {
+		t.Fatalf("receipt for %s: %v", tx.Hash().Hex(), err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		t.Fatalf("transaction %s reverted", tx.Hash().Hex())
+	}
+	return receipt
+}
+
+// SendValue signs and sends a plain value transfer from key without mining it.
+func (c *Chain) SendValue(t testing.TB, key *ecdsa.PrivateKey, to common.Address, value *big.Int) *types.Transaction {
+	t.Helper()
+
+	ctx := context.Background()
+	nonce, err := c.Client.PendingNonceAt(ctx, crypto.PubkeyToAddress(key.PublicKey))
+	if err != nil {
+		t.Fatalf("nonce: %v", err)
+	}
+	gasPrice, err := c.Client.SuggestGasPrice(ctx)
+	if err != nil {
+		t.Fatalf("gas price: %v", err)
+	}
+	tx, err := types.SignTx(
+		types.NewTransaction(nonce, to, value, 21000, gasPrice, nil),
+		types.LatestSignerForChainID(big.NewInt(c.ID)),
+		key,
+	)
+	if err != nil {
+		t.Fatalf("sign transfer: %v", err)
+	}
+	if err := c.Client.SendTransaction(ctx, tx); err != nil {
+		t.Fatalf("send transfer: %v", err)
+	}
+	return tx
+}
+
+// NewFundedKey generates a key and funds it with native coin from the owner.
+func (c *Chain) NewFundedKey(t testing.TB, value *big.Int) *ecdsa.PrivateKey {
+	t.Helper()
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	c.Mine(t, c.SendValue(t, c.Owner, crypto.PubkeyToAddress(key.PublicKey), value))
+	return key
+}
+
+// Ether converts a whole number of ether to wei.
+func Ether(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(1e18))
+}
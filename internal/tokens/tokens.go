@@ -0,0 +1,257 @@
+// Package tokens holds the tokens listed for swaps on each chain and maps
+// them to what they pay out on the destination chain, converting amounts
+// between token decimals.
+package tokens
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/models"
+)
+
+const DefaultRefreshInterval = time.Minute
+
+// ErrNoMapping is returned for a token that has no active mapping to the
+// destination chain.
+var ErrNoMapping = errors.New("token is not mapped to the destination chain")
+
+// Store is the part of the database the registry reads.
+type Store interface {
+	GetSupportedTokens(ctx context.Context) ([]*models.SupportedToken, error)
+	GetTokenMappings(ctx context.Context) ([]*models.TokenMapping, error)
+}
+
+// Transfer is what a swap pays out on its destination chain.
+type Transfer struct {
+	Token  common.Address
+	Amount *big.Int
+	// Dust is the part of the swap amount, in source token units, that the
+	// destination token's decimals cannot represent. It stays with the
+	// source bridge.
+	Dust *big.Int
+}
+
+// Convert scales amount from a token with fromDecimals to one with
+// toDecimals. Scaling down rounds towards zero; dust is the remainder, in
+// the source token's units.
+func Convert(amount *big.Int, fromDecimals, toDecimals int) (converted, dust *big.Int) {
+	switch {
+	case toDecimals > fromDecimals:
+		scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(toDecimals-fromDecimals)), nil)
+		return new(big.Int).Mul(amount, scale), new(big.Int)
+	case toDecimals < fromDecimals:
+		scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(fromDecimals-toDecimals)), nil)
+		return new(big.Int).QuoRem(amount, scale, new(big.Int))
+	default:
+		return new(big.Int).Set(amount), new(big.Int)
+	}
+}
+
+type tokenKey struct {
+	chainID int64
+	address common.Address
+}
+
+type route struct {
+	sourceChainID int64
+	token         common.Address
+	targetChainID int64
+}
+
+// Registry holds the supported tokens and token mappings, loaded from the
+// database and refreshed periodically.
+type Registry struct {
+	db       Store
+	interval time.Duration
+
+	tokens   map[tokenKey]*models.SupportedToken
+	mappings map[route]*models.TokenMapping
+	mutex    sync.RWMutex
+}
+
+func New(db Store, interval time.Duration) *Registry {
+	if interval == 0 {
+		interval = DefaultRefreshInterval
+	}
+	return &Registry{
+		db:       db,
+		interval: interval,
+		tokens:   make(map[tokenKey]*models.SupportedToken),
+		mappings: make(map[route]*models.TokenMapping),
+	}
+}
+
+// Load replaces the cached tokens and mappings with the ones in the
+// database.
+func (r *Registry) Load(ctx context.Context) error {
+	tokens, err := r.db.GetSupportedTokens(ctx)
+	if err != nil {
+		return err
+	}
+	mappings, err := r.db.GetTokenMappings(ctx)
+	if err != nil {
+		return err
+	}
+
+	byKey := make(map[tokenKey]*models.SupportedToken, len(tokens))
+	for _, t := range tokens {
+		byKey[tokenKey{t.ChainID, t.Address}] = t
+	}
+	byRoute := make(map[route]*models.TokenMapping, len(mappings))
+	for _, m := range mappings {
+		byRoute[route{m.SourceChainID, m.SourceToken, m.TargetChainID}] = m
+	}
+
+	r.mutex.Lock()
+	r.tokens = byKey
+	r.mappings = byRoute
+	r.mutex.Unlock()
+	return nil
+}
+
+// Start reloads the tokens and mappings every refresh interval until ctx is cancelled.
+func (r *Registry) Start(ctx context.Context) {
+	go r.run(ctx)
+}
+
+func (r *Registry) run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.Load(ctx); err != nil {
+				log.Printf("tokens: %v", err)
+			}
+		}
+	}
+}
+
+// Token returns the listing of token on chainID.
+func (r *Registry) Token(chainID int64, token common.Address) (*models.SupportedToken, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	t, ok := r.tokens[tokenKey{chainID, token}]
+	return t, ok
+}
+
+// Tokens returns the active tokens listed on chainID.
+func (r *Registry) Tokens(chainID int64) []*models.SupportedToken {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var tokens []*models.SupportedToken
+	for key, t := range r.tokens {
+		if key.chainID == chainID && t.IsActive {
+			tokens = append(tokens, t)
+		}
+	}
+	sort.Slice(tokens, func(i, j int) bool { return tokens[i].ID < tokens[j].ID })
+	return tokens
+}
+
+// Mappings returns every token mapping.
+func (r *Registry) Mappings() []*models.TokenMapping {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	mappings := make([]*models.TokenMapping, 0, len(r.mappings))
+	for _, m := range r.mappings {
+		mappings = append(mappings, m)
+	}
+	sort.Slice(mappings, func(i, j int) bool { return mappings[i].ID < mappings[j].ID })
+	return mappings
+}
+
+// Mapping returns the mapping of token from sourceChainID to targetChainID.
+func (r *Registry) Mapping(sourceChainID int64, token common.Address, targetChainID int64) (*models.TokenMapping, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	m, ok := r.mappings[route{sourceChainID, token, targetChainID}]
+	return m, ok
+}
+
+// Map works out what a swap of amount of token from sourceChainID pays out
+// on targetChainID.
+func (r *Registry) Map(sourceChainID int64, token common.Address, targetChainID int64, amount *big.Int) (*Transfer, error) {
+	m, ok := r.Mapping(sourceChainID, token, targetChainID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s from chain %d to chain %d", ErrNoMapping, token.Hex(), sourceChainID, targetChainID)
+	}
+
+	converted, dust := Convert(amount, m.SourceDecimals, m.TargetDecimals)
+	return &Transfer{Token: m.TargetToken, Amount: converted, Dust: dust}, nil
+}
+
+// Validate checks a swap of amount of token from sourceChainID to
+// targetChainID against the token listings and works out what it pays out.
+// Both the source token and the token paid out must be listed and active,
+// and the amounts on either side must be within that chain's limits. A
+// failed check returns a *models.ValidationError naming the rule.
+func (r *Registry) Validate(sourceChainID int64, token common.Address, targetChainID int64, amount *big.Int) (*Transfer, error) {
+	if err := r.checkAmount(sourceChainID, token, amount); err != nil {
+		return nil, err
+	}
+
+	transfer, err := r.Map(sourceChainID, token, targetChainID, amount)
+	if err != nil {
+		return nil, &models.ValidationError{Rule: models.RuleNoTokenMapping, Message: err.Error(), ChainID: targetChainID}
+	}
+	if transfer.Amount.Sign() == 0 {
+		return nil, &models.ValidationError{
+			Rule:    models.RuleAmountTooSmall,
+			Message: fmt.Sprintf("amount %s is too small for the destination token", amount),
+			ChainID: targetChainID,
+		}
+	}
+	if err := r.checkAmount(targetChainID, transfer.Token, transfer.Amount); err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+func (r *Registry) checkAmount(chainID int64, token common.Address, amount *big.Int) error {
+	t, ok := r.Token(chainID, token)
+	if !ok {
+		return &models.ValidationError{
+			Rule:    models.RuleUnsupportedToken,
+			Message: fmt.Sprintf("token %s is not supported on chain %d", token.Hex(), chainID),
+			ChainID: chainID,
+		}
+	}
+	if !t.IsActive {
+		return &models.ValidationError{
+			Rule:    models.RuleInactiveToken,
+			Message: fmt.Sprintf("token %s is not active on chain %d", t.Symbol, chainID),
+			ChainID: chainID,
+		}
+	}
+	if amount.Cmp(t.MinAmount) < 0 {
+		return &models.ValidationError{
+			Rule:    models.RuleBelowMinAmount,
+			Message: fmt.Sprintf("amount %s is below the minimum of %s for %s on chain %d", amount, t.MinAmount, t.Symbol, chainID),
+			ChainID: chainID,
+		}
+	}
+	if amount.Cmp(t.MaxAmount) > 0 {
+		return &models.ValidationError{
+			Rule:    models.RuleAboveMaxAmount,
+			Message: fmt.Sprintf("amount %s is above the maximum of %s for %s on chain %d", amount, t.MaxAmount, t.Symbol, chainID),
+			ChainID: chainID,
+		}
+	}
+	return nil
+}
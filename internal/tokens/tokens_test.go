@@ -0,0 +1,142 @@
+package tokens
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+type memStore struct {
+	tokens   []*models.SupportedToken
+	mappings []*models.TokenMapping
+}
+
+func (m *memStore) GetSupportedTokens(ctx context.Context) ([]*models.SupportedToken, error) {
+	return m.tokens, nil
+}
+
+func (m *memStore) GetTokenMappings(ctx context.Context) ([]*models.TokenMapping, error) {
+	return m.mappings, nil
+}
+
+func amount(s string) *big.Int {
+	n, _ := new(big.Int).SetString(s, 10)
+	return n
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name      string
+		amount    string
+		from, to  int
+		converted string
+		dust      string
+	}{
+		{"same decimals", "1234567", 6, 6, "1234567", "0"},
+		{"scale up", "1500000", 6, 18, "1500000000000000000", "0"},
+		{"scale down exact", "1500000000000000000", 18, 6, "1500000", "0"},
+		{"scale down with dust", "1500000123456789012", 18, 6, "1500000", "123456789012"},
+		{"all dust", "999999999999", 18, 6, "0", "999999999999"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			converted, dust := Convert(amount(tt.amount), tt.from, tt.to)
+			require.Equal(t, tt.converted, converted.String())
+			require.Equal(t, tt.dust, dust.String())
+		})
+	}
+}
+
+func TestRegistryMapsTokens(t *testing.T) {
+	usdcEth := common.HexToAddress("0xa0b8")
+	usdcBsc := common.HexToAddress("0x8ac7")
+	store := &memStore{mappings: []*models.TokenMapping{
+		{SourceChainID: 1, SourceToken: usdcEth, SourceDecimals: 6, TargetChainID: 56, TargetToken: usdcBsc, TargetDecimals: 18},
+		{SourceChainID: 56, SourceToken: usdcBsc, SourceDecimals: 18, TargetChainID: 1, TargetToken: usdcEth, TargetDecimals: 6},
+	}}
+	registry := New(store, 0)
+	require.NoError(t, registry.Load(context.Background()))
+
+	transfer, err := registry.Map(1, usdcEth, 56, amount("2500000"))
+	require.NoError(t, err)
+	require.Equal(t, usdcBsc, transfer.Token)
+	require.Equal(t, "2500000000000000000", transfer.Amount.String())
+	require.Zero(t, transfer.Dust.Sign())
+
+	transfer, err = registry.Map(56, usdcBsc, 1, amount("2500000000000000001"))
+	require.NoError(t, err)
+	require.Equal(t, usdcEth, transfer.Token)
+	require.Equal(t, "2500000", transfer.Amount.String())
+	require.Equal(t, "1", transfer.Dust.String())
+
+	_, err = registry.Map(1, usdcEth, 137, amount("1"))
+	require.ErrorIs(t, err, ErrNoMapping)
+
+	// Mappings removed from the database go away on the next load.
+	store.mappings = store.mappings[:1]
+	require.NoError(t, registry.Load(context.Background()))
+	_, err = registry.Map(56, usdcBsc, 1, amount("1"))
+	require.ErrorIs(t, err, ErrNoMapping)
+}
+
+func TestRegistryValidatesSwaps(t *testing.T) {
+	usdcEth := common.HexToAddress("0xa0b8")
+	usdcBsc := common.HexToAddress("0x8ac7")
+	daiEth := common.HexToAddress("0x6b17")
+	store := &memStore{
+		tokens: []*models.SupportedToken{
+			{ChainID: 1, Address: usdcEth, Symbol: "USDC", Decimals: 6, MinAmount: amount("1000000"), MaxAmount: amount("1000000000"), IsActive: true},
+			{ChainID: 56, Address: usdcBsc, Symbol: "USDC", Decimals: 18, MinAmount: amount("0"), MaxAmount: amount("500000000000000000000"), IsActive: true},
+			{ChainID: 1, Address: daiEth, Symbol: "DAI", Decimals: 18, MinAmount: amount("0"), MaxAmount: amount("1000000000000000000000"), IsActive: false},
+		},
+		mappings: []*models.TokenMapping{
+			{SourceChainID: 1, SourceToken: usdcEth, SourceDecimals: 6, TargetChainID: 56, TargetToken: usdcBsc, TargetDecimals: 18},
+		},
+	}
+	registry := New(store, 0)
+	require.NoError(t, registry.Load(context.Background()))
+
+	transfer, err := registry.Validate(1, usdcEth, 56, amount("2000000"))
+	require.NoError(t, err)
+	require.Equal(t, "2000000000000000000", transfer.Amount.String())
+
+	tests := []struct {
+		name    string
+		source  int64
+		token   common.Address
+		target  int64
+		amount  string
+		rule    string
+		chainID int64
+	}{
+		{"unlisted token", 1, common.HexToAddress("0xdead"), 56, "2000000", models.RuleUnsupportedToken, 1},
+		{"inactive token", 1, daiEth, 56, "1", models.RuleInactiveToken, 1},
+		{"below minimum", 1, usdcEth, 56, "999999", models.RuleBelowMinAmount, 1},
+		{"above maximum", 1, usdcEth, 56, "1000000001", models.RuleAboveMaxAmount, 1},
+		// 600 USDC is within the Ethereum limit but above BSC's.
+		{"above destination maximum", 1, usdcEth, 56, "600000000", models.RuleAboveMaxAmount, 56},
+		{"unmapped route", 1, usdcEth, 137, "2000000", models.RuleNoTokenMapping, 137},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := registry.Validate(tt.source, tt.token, tt.target, amount(tt.amount))
+			var invalid *models.ValidationError
+			require.ErrorAs(t, err, &invalid)
+			require.Equal(t, tt.rule, invalid.Rule)
+			require.Equal(t, tt.chainID, invalid.ChainID)
+		})
+	}
+
+	// A token delisted on the destination closes the route.
+	store.tokens[1].IsActive = false
+	require.NoError(t, registry.Load(context.Background()))
+	_, err = registry.Validate(1, usdcEth, 56, amount("2000000"))
+	var invalid *models.ValidationError
+	require.ErrorAs(t, err, &invalid)
+	require.Equal(t, models.RuleInactiveToken, invalid.Rule)
+	require.Equal(t, int64(56), invalid.ChainID)
+}
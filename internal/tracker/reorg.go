@@ -0,0 +1,98 @@
+package tracker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/models"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/processor"
+)
+
+// ReorgWindow is how many blocks below the head mined batches are checked
+// against the canonical chain.
+const ReorgWindow = 128
+
+// detectReorgs compares the block hash recorded for every recently mined
+// batch with the canonical chain. A batch whose transaction was re-mined in
+// the new branch has its receipt data moved to the new block and goes back
+// to processing to be confirmed again; a batch whose transaction is gone is
+// rolled back for resubmission, unless its completion was already sent to
+// the destination chain.
+func (t *Tracker) detectReorgs(ctx context.Context, chain *processor.Chain) error {
+	head, err := chain.Client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error getting head: %v", err)
+	}
+
+	from := head.Number.Int64() - ReorgWindow
+	if from < 0 {
+		from = 0
+	}
+	batches, err := t.db.GetMinedBatches(ctx, chain.ID, from)
+	if err != nil {
+		return err
+	}
+
+	for _, batch := range batches {
+		if err := t.checkCanonical(ctx, chain, batch); err != nil {
+			log.Printf("tracker: batch %s reorg check: %v", batch.BatchID, err)
+		}
+	}
+	return nil
+}
+
+func (t *Tracker) checkCanonical(ctx context.Context, chain *processor.Chain, batch *models.Batch) error {
+	var canonicalHash *string
+	header, err := chain.Client.HeaderByNumber(ctx, big.NewInt(*batch.BlockNumber))
+	switch {
+	case errors.Is(err, ethereum.NotFound):
+		// The canonical chain is now shorter than the recorded block.
+	case err != nil:
+		return fmt.Errorf("error getting block %d: %v", *batch.BlockNumber, err)
+	default:
+		hash := header.Hash().Hex()
+		if hash == *batch.BlockHash {
+			return nil
+		}
+		canonicalHash = &hash
+	}
+
+	receipt, err := chain.Client.TransactionReceipt(ctx, common.HexToHash(*batch.SourceTxHash))
+	if err != nil && !errors.Is(err, ethereum.NotFound) {
+		return fmt.Errorf("error getting receipt: %v", err)
+	}
+	if err == nil {
+		log.Printf("tracker: batch %s moved from block %s to %s by a reorg", batch.BatchID, *batch.BlockHash, receipt.BlockHash.Hex())
+		if err := t.db.UpdateBatchReceipt(ctx, batch.ID, t.batchReceipt(ctx, chain, receipt)); err != nil {
+			return err
+		}
+		if batch.Status != "processing" && batch.Status != "completed" {
+			return t.db.UpdateBatchState(ctx, batch.ID, "processing", nil)
+		}
+		return nil
+	}
+
+	if batch.TargetTxHash != nil || batch.Status == "completed" {
+		return t.alertReorgedCompletion(ctx, batch, canonicalHash)
+	}
+	log.Printf("tracker: batch %s was reorganized out of block %d, rolling back", batch.BatchID, *batch.BlockNumber)
+	err = t.db.RollbackReorgedBatch(ctx, batch, canonicalHash)
+	if errors.Is(err, models.ErrCompletionSent) {
+		// The relayer sent the completion since the batch was read
+		return t.alertReorgedCompletion(ctx, batch, canonicalHash)
+	}
+	return err
+}
+
+// alertReorgedCompletion reports a batch whose source transaction was
+// reorganized out after its completion was sent. Resubmitting it would pay
+// its recipients twice, so it is left for an operator to review.
+func (t *Tracker) alertReorgedCompletion(ctx context.Context, batch *models.Batch, canonicalHash *string) error {
+	log.Printf("tracker: ALERT: batch %s was reorganized out of block %d after its completion was sent; not rolling it back, review it manually", batch.BatchID, *batch.BlockNumber)
+	return t.db.FlagReorgedCompletion(ctx, batch, canonicalHash)
+}
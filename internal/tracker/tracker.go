@@ -0,0 +1,260 @@
+// Package tracker follows submitted batch transactions until they are
+// final on their source chain.
+package tracker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/gas"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/models"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/processor"
+)
+
+const DefaultPollInterval = 10 * time.Second
+
+// HeaderReader is satisfied by ethclient.Client and processor.ChainClient.
+type HeaderReader interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// Store is the part of models.Database the tracker uses.
+type Store interface {
+	GetChainConfig(ctx context.Context, chainID int64) (*models.ChainConfig, error)
+	GetBatchesByStatus(ctx context.Context, chainID int64, status string) ([]*models.Batch, error)
+	GetMinedBatches(ctx context.Context, chainID int64, fromBlock int64) ([]*models.Batch, error)
+	UpdateBatchReceipt(ctx context.Context, batchID int64, receipt *models.BatchReceipt) error
+	UpdateBatchState(ctx context.Context, batchID int64, status string, errorMsg *string) error
+	RollbackReorgedBatch(ctx context.Context, batch *models.Batch, canonicalHash *string) error
+	FlagReorgedCompletion(ctx context.Context, batch *models.Batch, canonicalHash *string) error
+	GetBatchTransactions(ctx context.Context, batchID int64) ([]*models.BatchTransaction, error)
+	SetBatchSourceTx(ctx context.Context, batchID int64, txHash string) error
+}
+
+type Tracker struct {
+	chains   processor.ChainSet
+	db       Store
+	interval time.Duration
+}
+
+func New(chains processor.ChainSet, db Store, interval time.Duration) *Tracker {
+	if interval == 0 {
+		interval = DefaultPollInterval
+	}
+	return &Tracker{
+		chains:   chains,
+		db:       db,
+		interval: interval,
+	}
+}
+
+// Start runs one tracking loop per chain until ctx is cancelled.
+func (t *Tracker) Start(ctx context.Context) {
+	go processor.Follow(ctx, t.chains, t.run)
+}
+
+func (t *Tracker) run(ctx context.Context, chain *processor.Chain) {
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := t.checkChain(ctx, chain); err != nil {
+			log.Printf("tracker: chain %d: %v", chain.ID, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (t *Tracker) checkChain(ctx context.Context, chain *processor.Chain) error {
+	config, err := t.db.GetChainConfig(ctx, chain.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := t.detectReorgs(ctx, chain); err != nil {
+		log.Printf("tracker: chain %d reorg check: %v", chain.ID, err)
+	}
+
+	batches, err := t.db.GetBatchesByStatus(ctx, chain.ID, "processing")
+	if err != nil {
+		return err
+	}
+	if len(batches) == 0 {
+		return nil
+	}
+
+	final, err := FinalizedHeight(ctx, chain.Client, config)
+	if err != nil {
+		return err
+	}
+
+	for _, batch := range batches {
+		if err := t.checkBatch(ctx, chain, batch, final); err != nil {
+			log.Printf("tracker: batch %s: %v", batch.BatchID, err)
+		}
+	}
+	return nil
+}
+
+func (t *Tracker) checkBatch(ctx context.Context, chain *processor.Chain, batch *models.Batch, final uint64) error {
+	if batch.SourceTxHash == nil {
+		return nil
+	}
+
+	receipt, err := chain.Client.TransactionReceipt(ctx, common.HexToHash(*batch.SourceTxHash))
+	if errors.Is(err, ethereum.NotFound) {
+		receipt, err = t.minedReplacement(ctx, chain, batch)
+	}
+	if err != nil {
+		return err
+	}
+	if receipt == nil {
+		// Not mined yet
+		return nil
+	}
+
+	blockHash := receipt.BlockHash.Hex()
+	if batch.BlockHash == nil || *batch.BlockHash != blockHash {
+		if err := t.db.UpdateBatchReceipt(ctx, batch.ID, t.batchReceipt(ctx, chain, receipt)); err != nil {
+			return err
+		}
+	}
+
+	if receipt.BlockNumber.Uint64() > final {
+		return nil
+	}
+
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		errMsg := "batch transaction reverted"
+		reason, err := processor.ReplayRevert(ctx, chain, receipt)
+		if err != nil {
+			log.Printf("tracker: batch %s: no revert reason: %v", batch.BatchID, err)
+		} else if reason != "" {
+			errMsg = "execution reverted: " + reason
+		}
+		return t.db.UpdateBatchState(ctx, batch.ID, "reverted", &errMsg)
+	}
+	return t.db.UpdateBatchState(ctx, batch.ID, "confirmed", nil)
+}
+
+// batchReceipt decodes what the tracker records from a batch transaction's
+// receipt. When the cost cannot be worked out, the estimate made when the
+// batch was sent is kept.
+func (t *Tracker) batchReceipt(ctx context.Context, chain *processor.Chain, receipt *types.Receipt) *models.BatchReceipt {
+	r := &models.BatchReceipt{
+		BlockNumber: receipt.BlockNumber.Int64(),
+		BlockHash:   receipt.BlockHash.Hex(),
+		GasUsed:     int64(receipt.GasUsed),
+	}
+	if receipt.EffectiveGasPrice == nil {
+		return r
+	}
+
+	gasPrice := receipt.EffectiveGasPrice.String()
+	r.GasPrice = &gasPrice
+	cost, err := t.receiptCost(ctx, chain, receipt)
+	if err != nil {
+		log.Printf("tracker: chain %d: no cost for transaction %s: %v", chain.ID, receipt.TxHash.Hex(), err)
+		return r
+	}
+	r.Cost = processor.BatchCost(cost)
+	return r
+}
+
+// receiptCost splits what a mined transaction paid. On rollups the L1 data
+// fee is read as of the block the transaction was mined in.
+func (t *Tracker) receiptCost(ctx context.Context, chain *processor.Chain, receipt *types.Receipt) (*gas.Cost, error) {
+	config, err := t.db.GetChainConfig(ctx, chain.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var tx *types.Transaction
+	if gas.IsRollup(config.ChainType) {
+		if tx, _, err = chain.Client.TransactionByHash(ctx, receipt.TxHash); err != nil {
+			return nil, fmt.Errorf("error getting transaction: %v", err)
+		}
+	}
+	return gas.EstimateCost(ctx, chain.Client, config.ChainType, tx, receipt.GasUsed, receipt.EffectiveGasPrice, receipt.BlockNumber)
+}
+
+// minedReplacement looks for a mined transaction among all the ones sent for
+// a batch whose current source transaction is not mined: when a stuck
+// transaction was replaced, either the original or one of its replacements
+// can end up in a block. The one found becomes the batch's source
+// transaction.
+func (t *Tracker) minedReplacement(ctx context.Context, chain *processor.Chain, batch *models.Batch) (*types.Receipt, error) {
+	batchTxs, err := t.db.GetBatchTransactions(ctx, batch.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, batchTx := range batchTxs {
+		if batchTx.TxHash == *batch.SourceTxHash {
+			continue
+		}
+		receipt, err := chain.Client.TransactionReceipt(ctx, common.HexToHash(batchTx.TxHash))
+		if errors.Is(err, ethereum.NotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error getting receipt: %v", err)
+		}
+
+		if err := t.db.SetBatchSourceTx(ctx, batch.ID, batchTx.TxHash); err != nil {
+			return nil, err
+		}
+		log.Printf("tracker: batch %s mined with transaction %s", batch.BatchID, batchTx.TxHash)
+		return receipt, nil
+	}
+	return nil, nil
+}
+
+// FinalizedHeight returns the highest block the chain config treats as final:
+// the block with the required number of confirmations on top of it, or the
+// node's safe/finalized block when the chain uses one of those modes.
+func FinalizedHeight(ctx context.Context, client HeaderReader, config *models.ChainConfig) (uint64, error) {
+	switch config.FinalityMode {
+	case models.FinalitySafe:
+		return taggedHeight(ctx, client, rpc.SafeBlockNumber)
+	case models.FinalityFinalized:
+		return taggedHeight(ctx, client, rpc.FinalizedBlockNumber)
+	case models.FinalityConfirmations, "":
+		head, err := client.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return 0, fmt.Errorf("error getting head: %v", err)
+		}
+		// A transaction in the head block has one confirmation.
+		confirmations := uint64(config.RequiredConfirmations)
+		if confirmations == 0 {
+			confirmations = 1
+		}
+		if head.Number.Uint64()+1 < confirmations {
+			return 0, nil
+		}
+		return head.Number.Uint64() + 1 - confirmations, nil
+	default:
+		return 0, fmt.Errorf("unknown finality mode %q", config.FinalityMode)
+	}
+}
+
+func taggedHeight(ctx context.Context, client HeaderReader, tag rpc.BlockNumber) (uint64, error) {
+	header, err := client.HeaderByNumber(ctx, big.NewInt(int64(tag)))
+	if err != nil {
+		return 0, fmt.Errorf("error getting %s block: %v", tag, err)
+	}
+	return header.Number.Uint64(), nil
+}
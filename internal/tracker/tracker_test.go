@@ -0,0 +1,417 @@
+package tracker
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/models"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/processor"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+type memStore struct {
+	mu        sync.Mutex
+	config    *models.ChainConfig
+	batches   map[int64]*models.Batch
+	batchTxs  map[int64][]*models.BatchTransaction
+	rollbacks []*models.Batch
+	flagged   []*models.Batch
+}
+
+func newMemStore(confirmations int) *memStore {
+	return &memStore{
+		config: &models.ChainConfig{
+			ChainID:               testutil.SimulatedChainID,
+			RequiredConfirmations: confirmations,
+			FinalityMode:          models.FinalityConfirmations,
+		},
+		batches:  make(map[int64]*models.Batch),
+		batchTxs: make(map[int64][]*models.BatchTransaction),
+	}
+}
+
+func (s *memStore) GetChainConfig(ctx context.Context, chainID int64) (*models.ChainConfig, error) {
+	return s.config, nil
+}
+
+func (s *memStore) GetBatchesByStatus(ctx context.Context, chainID int64, status string) ([]*models.Batch, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var batches []*models.Batch
+	for _, b := range s.batches {
+		if b.ChainID == chainID && b.Status == status {
+			copied := *b
+			batches = append(batches, &copied)
+		}
+	}
+	return batches, nil
+}
+
+func (s *memStore) GetMinedBatches(ctx context.Context, chainID int64, fromBlock int64) ([]*models.Batch, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var batches []*models.Batch
+	for _, b := range s.batches {
+		if b.ChainID == chainID && (b.Status == "processing" || b.Status == "confirmed" || b.Status == "completed") &&
+			b.BlockHash != nil && *b.BlockNumber >= fromBlock {
+			copied := *b
+			batches = append(batches, &copied)
+		}
+	}
+	return batches, nil
+}
+
+func (s *memStore) UpdateBatchReceipt(ctx context.Context, batchID int64, receipt *models.BatchReceipt) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b := s.batches[batchID]
+	b.BlockNumber, b.BlockHash, b.GasUsed = &receipt.BlockNumber, &receipt.BlockHash, &receipt.GasUsed
+	if receipt.GasPrice != nil {
+		b.GasPrice = receipt.GasPrice
+	}
+	if receipt.Cost != nil {
+		b.ExecutionFee, b.L1DataFee = &receipt.Cost.ExecutionFee, &receipt.Cost.L1DataFee
+	}
+	return nil
+}
+
+func (s *memStore) UpdateBatchState(ctx context.Context, batchID int64, status string, errorMsg *string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.batches[batchID].Status = status
+	s.batches[batchID].ErrorMessage = errorMsg
+	return nil
+}
+
+func (s *memStore) RollbackReorgedBatch(ctx context.Context, batch *models.Batch, canonicalHash *string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b := s.batches[batch.ID]
+	if b.TargetTxHash != nil || b.Status == "completed" {
+		return models.ErrCompletionSent
+	}
+	s.rollbacks = append(s.rollbacks, batch)
+	b.Status = "reorged"
+	b.BlockNumber, b.BlockHash, b.GasUsed = nil, nil, nil
+	return nil
+}
+
+func (s *memStore) FlagReorgedCompletion(ctx context.Context, batch *models.Batch, canonicalHash *string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b := s.batches[batch.ID]
+	s.flagged = append(s.flagged, batch)
+	b.BlockNumber, b.BlockHash = nil, nil
+	return nil
+}
+
+func (s *memStore) GetBatchTransactions(ctx context.Context, batchID int64) ([]*models.BatchTransaction, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.batchTxs[batchID], nil
+}
+
+func (s *memStore) SetBatchSourceTx(ctx context.Context, batchID int64, txHash string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, batchTx := range s.batchTxs[batchID] {
+		if batchTx.TxHash == txHash {
+			s.batches[batchID].SourceTxHash = &batchTx.TxHash
+			s.batches[batchID].GasPrice = &batchTx.GasPrice
+			return nil
+		}
+	}
+	return models.ErrBatchNotFound
+}
+
+func (s *memStore) batch(id int64) models.Batch {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return *s.batches[id]
+}
+
+type fixture struct {
+	chain   *testutil.Chain
+	other   *ecdsa.PrivateKey
+	store   *memStore
+	tracker *Tracker
+	tx      *types.Transaction
+}
+
+// newFixture submits a one-swap batch from the bridge owner and records it
+// as processing, without mining it. It also funds a second account.
+func newFixture(t *testing.T, confirmations int) *fixture {
+	chain := testutil.NewChain(t)
+	other := chain.NewFundedKey(t, testutil.Ether(1))
+
+	pool, err := processor.NewWalletPool([]string{hex.EncodeToString(crypto.FromECDSA(chain.Owner))}, nil)
+	require.NoError(t, err)
+	pc := &processor.Chain{ID: testutil.SimulatedChainID, Client: chain.Client, Bridge: chain.BridgeAddr}
+
+	wallet := walletFor(t, pool, chain.OwnerAddr)
+	tx, err := wallet.ProcessBatch(context.Background(), pc, []*models.SwapRequest{{
+		FromChainID:  testutil.SimulatedChainID,
+		ToChainID:    56,
+		TokenAddress: chain.TokenAddr,
+		Amount:       testutil.Ether(1),
+		Recipient:    common.HexToAddress("0x01"),
+	}}, nil)
+	require.NoError(t, err)
+
+	txHash := tx.Hash().Hex()
+	store := newMemStore(confirmations)
+	store.batches[1] = &models.Batch{
+		ID:           1,
+		BatchID:      "batch-1",
+		ChainID:      testutil.SimulatedChainID,
+		Status:       "processing",
+		SourceTxHash: &txHash,
+	}
+
+	return &fixture{
+		chain:   chain,
+		other:   other,
+		store:   store,
+		tracker: New(processor.StaticChains{pc}, store, 0),
+		tx:      tx,
+	}
+}
+
+func walletFor(t *testing.T, pool *processor.WalletPool, addr common.Address) *processor.Wallet {
+	for _, w := range pool.Wallets() {
+		if w.Address == addr {
+			return w
+		}
+	}
+	t.Fatalf("wallet %s not in pool", addr.Hex())
+	return nil
+}
+
+func (f *fixture) check(t *testing.T) {
+	require.NoError(t, f.tracker.checkChain(context.Background(), f.tracker.chains.Chains()[0]))
+}
+
+// send submits tx after a fork, retrying while the transaction pool is
+// still catching up with the new head. Transactions from the abandoned
+// branch may already have been re-injected and mined.
+func (f *fixture) send(t *testing.T, tx *types.Transaction) {
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		err := f.chain.Client.SendTransaction(context.Background(), tx)
+		if err == nil || strings.Contains(err.Error(), "already known") {
+			return
+		}
+		if _, rerr := f.chain.Client.TransactionReceipt(context.Background(), tx.Hash()); rerr == nil {
+			// Re-injected by the pool and already mined on the new branch.
+			return
+		}
+		if !strings.Contains(err.Error(), "nonce too low") || time.Now().After(deadline) {
+			t.Fatalf("send %s: %v", tx.Hash().Hex(), err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestTrackerConfirmsAfterRequiredDepth(t *testing.T) {
+	f := newFixture(t, 3)
+
+	f.check(t)
+	require.Equal(t, "processing", f.store.batch(1).Status)
+	require.Nil(t, f.store.batch(1).BlockNumber)
+
+	receipt := f.chain.Mine(t, f.tx)
+	f.check(t)
+	batch := f.store.batch(1)
+	require.Equal(t, "processing", batch.Status)
+	require.Equal(t, receipt.BlockNumber.Int64(), *batch.BlockNumber)
+	require.Equal(t, receipt.BlockHash.Hex(), *batch.BlockHash)
+	require.Equal(t, int64(receipt.GasUsed), *batch.GasUsed)
+	require.Equal(t, receipt.EffectiveGasPrice.String(), *batch.GasPrice)
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), receipt.EffectiveGasPrice)
+	require.Equal(t, fee.String(), *batch.ExecutionFee)
+	require.Equal(t, "0", *batch.L1DataFee)
+
+	f.chain.Backend.Commit()
+	f.check(t)
+	require.Equal(t, "processing", f.store.batch(1).Status)
+
+	f.chain.Backend.Commit()
+	f.check(t)
+	require.Equal(t, "confirmed", f.store.batch(1).Status)
+}
+
+func TestTrackerRecordsWhicheverReplacementMines(t *testing.T) {
+	f := newFixture(t, 1)
+
+	// The batch was replaced, but the replacement never made it into a
+	// block while the original transaction did.
+	replacementHash := common.HexToHash("0xfeed").Hex()
+	f.store.batchTxs[1] = []*models.BatchTransaction{
+		{BatchID: 1, TxHash: f.tx.Hash().Hex(), Nonce: f.tx.Nonce(), GasPrice: "1"},
+		{BatchID: 1, TxHash: replacementHash, Nonce: f.tx.Nonce(), GasPrice: "2"},
+	}
+	f.store.batches[1].SourceTxHash = &replacementHash
+
+	f.check(t)
+	require.Equal(t, replacementHash, *f.store.batch(1).SourceTxHash)
+
+	receipt := f.chain.Mine(t, f.tx)
+	f.check(t)
+	batch := f.store.batch(1)
+	require.Equal(t, f.tx.Hash().Hex(), *batch.SourceTxHash)
+	require.Equal(t, receipt.EffectiveGasPrice.String(), *batch.GasPrice)
+	require.Equal(t, receipt.BlockHash.Hex(), *batch.BlockHash)
+	require.Equal(t, "confirmed", batch.Status)
+}
+
+func TestTrackerRecordsRevertReason(t *testing.T) {
+	f := newFixture(t, 1)
+	f.chain.Mine(t, f.tx)
+
+	// Sent with a fixed gas limit, so that gas estimation does not catch
+	// the unsupported token before the transaction is mined. Batch ids
+	// derive from the block time, sender and batch size, so this batch
+	// differs in size from the first one.
+	opts := f.chain.Transactor(t, f.chain.Owner)
+	opts.GasLimit = 500000
+	swap := &models.SwapRequest{
+		ToChainID:    56,
+		TokenAddress: common.HexToAddress("0xdead"),
+		Amount:       testutil.Ether(1),
+		Recipient:    common.HexToAddress("0x01"),
+	}
+	tx, err := f.chain.Bridge.BatchInitiateSwap(opts, processor.BridgeRequests([]*models.SwapRequest{swap, swap}))
+	require.NoError(t, err)
+	f.chain.Backend.Commit()
+
+	txHash := tx.Hash().Hex()
+	f.store.batches[2] = &models.Batch{
+		ID:           2,
+		BatchID:      "batch-2",
+		ChainID:      testutil.SimulatedChainID,
+		Status:       "processing",
+		SourceTxHash: &txHash,
+	}
+
+	f.check(t)
+	batch := f.store.batch(2)
+	require.Equal(t, "reverted", batch.Status)
+	require.Equal(t, "execution reverted: Unsupported token", *batch.ErrorMessage)
+	require.NotNil(t, batch.BlockNumber)
+	require.NotNil(t, batch.GasUsed)
+	require.Equal(t, "confirmed", f.store.batch(1).Status)
+}
+
+// reorgOut forks from the parent of the batch block and replaces the batch
+// transaction with a plain transfer using the same nonce.
+func (f *fixture) reorgOut(t *testing.T, receipt *types.Receipt) {
+	ctx := context.Background()
+	parent, err := f.chain.Client.HeaderByNumber(ctx, new(big.Int).Sub(receipt.BlockNumber, big.NewInt(1)))
+	require.NoError(t, err)
+	require.NoError(t, f.chain.Backend.Fork(parent.Hash()))
+
+	replacement, err := types.SignTx(
+		types.NewTx(&types.DynamicFeeTx{
+			ChainID:   big.NewInt(testutil.SimulatedChainID),
+			Nonce:     f.tx.Nonce(),
+			GasTipCap: new(big.Int).Mul(f.tx.GasTipCap(), big.NewInt(2)),
+			GasFeeCap: new(big.Int).Mul(f.tx.GasFeeCap(), big.NewInt(2)),
+			Gas:       21000,
+			To:        &f.chain.OwnerAddr,
+			Value:     big.NewInt(1),
+		}),
+		types.LatestSignerForChainID(big.NewInt(testutil.SimulatedChainID)),
+		f.chain.Owner,
+	)
+	require.NoError(t, err)
+	f.send(t, replacement)
+	f.chain.Mine(t, replacement)
+	f.chain.Backend.Commit()
+}
+
+func TestTrackerRollsBackReorgedBatch(t *testing.T) {
+	f := newFixture(t, 1)
+
+	receipt := f.chain.Mine(t, f.tx)
+	f.check(t)
+	require.Equal(t, "confirmed", f.store.batch(1).Status)
+
+	f.reorgOut(t, receipt)
+	f.check(t)
+	batch := f.store.batch(1)
+	require.Equal(t, "reorged", batch.Status)
+	require.Len(t, f.store.rollbacks, 1)
+	require.Equal(t, receipt.BlockHash.Hex(), *f.store.rollbacks[0].BlockHash)
+}
+
+func TestTrackerFollowsBatchIntoNewBranch(t *testing.T) {
+	f := newFixture(t, 1)
+	ctx := context.Background()
+
+	receipt := f.chain.Mine(t, f.tx)
+	f.check(t)
+	require.Equal(t, "confirmed", f.store.batch(1).Status)
+
+	// Fork from the parent and mine the same transaction on a longer branch
+	// that starts with an unrelated transfer.
+	parent, err := f.chain.Client.HeaderByNumber(ctx, new(big.Int).Sub(receipt.BlockNumber, big.NewInt(1)))
+	require.NoError(t, err)
+	require.NoError(t, f.chain.Backend.Fork(parent.Hash()))
+	f.chain.Mine(t, f.chain.SendValue(t, f.other, common.HexToAddress("0xbeef"), big.NewInt(1)))
+	f.send(t, f.tx)
+	moved := f.chain.Mine(t, f.tx)
+	require.NotEqual(t, receipt.BlockHash, moved.BlockHash)
+
+	f.check(t)
+	batch := f.store.batch(1)
+	require.Empty(t, f.store.rollbacks)
+	require.Equal(t, moved.BlockHash.Hex(), *batch.BlockHash)
+	require.Equal(t, moved.BlockNumber.Int64(), *batch.BlockNumber)
+	require.Equal(t, "confirmed", batch.Status)
+}
+
+func TestTrackerDoesNotRollBackCompletedBatch(t *testing.T) {
+	for _, status := range []string{"confirmed", "completed"} {
+		f := newFixture(t, 1)
+
+		receipt := f.chain.Mine(t, f.tx)
+		f.check(t)
+		require.Equal(t, "confirmed", f.store.batch(1).Status)
+
+		// The relayer has sent the completion, or it is already final.
+		targetTx := common.HexToHash("0xc0").Hex()
+		f.store.batches[1].TargetTxHash = &targetTx
+		f.store.batches[1].Status = status
+
+		f.reorgOut(t, receipt)
+		f.check(t)
+		batch := f.store.batch(1)
+		require.Equal(t, status, batch.Status)
+		require.Empty(t, f.store.rollbacks)
+		require.Len(t, f.store.flagged, 1)
+		require.Equal(t, receipt.BlockHash.Hex(), *f.store.flagged[0].BlockHash)
+
+		// It is reported once.
+		f.check(t)
+		require.Len(t, f.store.flagged, 1)
+	}
+}
@@ -0,0 +1,209 @@
+// Package treasury keeps hot wallets funded for gas, taking wallets that
+// run low out of rotation and topping them up from a treasury wallet.
+package treasury
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/gas"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/models"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/processor"
+)
+
+const DefaultPollInterval = time.Minute
+
+// Store is the part of models.Database the monitor uses.
+type Store interface {
+	GetChainConfig(ctx context.Context, chainID int64) (*models.ChainConfig, error)
+	UpdateWalletBalance(ctx context.Context, address string, chainID int64, balance *big.Int) error
+	CreateWalletTopUp(ctx context.Context, topUp *models.WalletTopUp) error
+	GetPendingWalletTopUps(ctx context.Context, chainID int64) ([]*models.WalletTopUp, error)
+	UpdateWalletTopUpStatus(ctx context.Context, id int64, status string, errorMessage *string) error
+}
+
+// Monitor reads the native balance of every hot wallet on each chain and
+// records it in hot_wallets. A wallet below the chain's min_wallet_balance
+// is not handed out for batches or completions there. When the chain has a
+// target_wallet_balance and a treasury wallet is configured, the treasury
+// sends the wallet the difference, one top-up at a time; each is recorded
+// in wallet_topups and settled once mined.
+type Monitor struct {
+	chains     processor.ChainSet
+	walletPool *processor.WalletPool
+	treasury   *processor.Wallet
+	oracle     *gas.Oracle
+	db         Store
+	interval   time.Duration
+}
+
+// New returns a monitor for the wallets of walletPool. treasury may be nil,
+// in which case low wallets are only taken out of rotation.
+func New(chains processor.ChainSet, walletPool *processor.WalletPool, treasury *processor.Wallet, db Store, interval time.Duration) *Monitor {
+	if interval == 0 {
+		interval = DefaultPollInterval
+	}
+	return &Monitor{
+		chains:     chains,
+		walletPool: walletPool,
+		treasury:   treasury,
+		oracle:     gas.NewOracle(),
+		db:         db,
+		interval:   interval,
+	}
+}
+
+// Start runs one monitoring loop per chain until ctx is cancelled.
+func (m *Monitor) Start(ctx context.Context) {
+	go processor.Follow(ctx, m.chains, m.run)
+}
+
+func (m *Monitor) run(ctx context.Context, chain *processor.Chain) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := m.CheckChain(ctx, chain); err != nil {
+			log.Printf("treasury: chain %d: %v", chain.ID, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckChain settles the pending top-ups on chain, then checks the balance
+// of every wallet there.
+func (m *Monitor) CheckChain(ctx context.Context, chain *processor.Chain) error {
+	config, err := m.db.GetChainConfig(ctx, chain.ID)
+	if err != nil {
+		return err
+	}
+	minBalance, err := parseBalance(config.MinWalletBalance)
+	if err != nil {
+		return fmt.Errorf("invalid min_wallet_balance: %v", err)
+	}
+	targetBalance, err := parseBalance(config.TargetWalletBalance)
+	if err != nil {
+		return fmt.Errorf("invalid target_wallet_balance: %v", err)
+	}
+
+	pending, err := m.settle(ctx, chain, config)
+	if err != nil {
+		return err
+	}
+
+	for _, wallet := range m.walletPool.Wallets() {
+		balance, err := chain.Client.BalanceAt(ctx, wallet.Address, nil)
+		if err != nil {
+			log.Printf("treasury: chain %d: error getting balance of wallet %s: %v", chain.ID, wallet.Address.Hex(), err)
+			continue
+		}
+		if err := m.db.UpdateWalletBalance(ctx, wallet.Address.Hex(), chain.ID, balance); err != nil {
+			log.Printf("treasury: chain %d: %v", chain.ID, err)
+		}
+
+		low := minBalance != nil && balance.Cmp(minBalance) < 0
+		if low != wallet.LowBalance(chain.ID) {
+			log.Printf("treasury: chain %d: wallet %s balance %s, low: %v", chain.ID, wallet.Address.Hex(), balance, low)
+		}
+		wallet.SetLowBalance(chain.ID, low)
+
+		// A draining wallet is being emptied, not refilled
+		if low && !pending[wallet.Address] && !wallet.Draining() && targetBalance != nil && m.treasury != nil {
+			amount := new(big.Int).Sub(targetBalance, balance)
+			if err := m.topUp(ctx, chain, config, wallet.Address, amount); err != nil {
+				log.Printf("treasury: chain %d: error topping up wallet %s: %v", chain.ID, wallet.Address.Hex(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// settle records the outcome of the pending top-ups on chain and returns
+// the wallets that still have one in flight. A top-up not mined within the
+// chain's stuck_tx_timeout_seconds is given up on.
+func (m *Monitor) settle(ctx context.Context, chain *processor.Chain, config *models.ChainConfig) (map[common.Address]bool, error) {
+	topUps, err := m.db.GetPendingWalletTopUps(ctx, chain.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := make(map[common.Address]bool)
+	for _, topUp := range topUps {
+		receipt, err := chain.Client.TransactionReceipt(ctx, common.HexToHash(topUp.TxHash))
+		if errors.Is(err, ethereum.NotFound) {
+			if time.Since(topUp.CreatedAt) < config.StuckTxTimeout() {
+				pending[topUp.WalletAddress] = true
+				continue
+			}
+			msg := fmt.Sprintf("not mined within %s", config.StuckTxTimeout())
+			if err := m.db.UpdateWalletTopUpStatus(ctx, topUp.ID, models.TopUpFailed, &msg); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error getting receipt of top-up %s: %v", topUp.TxHash, err)
+		}
+
+		if receipt.Status == types.ReceiptStatusFailed {
+			msg := "transaction reverted"
+			err = m.db.UpdateWalletTopUpStatus(ctx, topUp.ID, models.TopUpFailed, &msg)
+		} else {
+			err = m.db.UpdateWalletTopUpStatus(ctx, topUp.ID, models.TopUpConfirmed, nil)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return pending, nil
+}
+
+// topUp sends amount of native coin from the treasury to wallet and
+// records the transfer.
+func (m *Monitor) topUp(ctx context.Context, chain *processor.Chain, config *models.ChainConfig, wallet common.Address, amount *big.Int) error {
+	fees, err := m.oracle.Fees(ctx, chain.Client, config)
+	if err != nil {
+		return err
+	}
+	gasLimit, err := m.treasury.TransferGas(ctx, chain, wallet)
+	if err != nil {
+		return err
+	}
+	tx, err := m.treasury.Transfer(ctx, chain, wallet, amount, gasLimit, fees)
+	if err != nil {
+		return err
+	}
+	log.Printf("treasury: chain %d: topping up wallet %s with %s in %s", chain.ID, wallet.Hex(), amount, tx.Hash().Hex())
+
+	return m.db.CreateWalletTopUp(ctx, &models.WalletTopUp{
+		ChainID:       chain.ID,
+		WalletAddress: wallet,
+		Treasury:      m.treasury.Address,
+		Amount:        amount.String(),
+		TxHash:        tx.Hash().Hex(),
+		Status:        models.TopUpPending,
+	})
+}
+
+func parseBalance(value *string) (*big.Int, error) {
+	if value == nil {
+		return nil, nil
+	}
+	balance, ok := new(big.Int).SetString(*value, 10)
+	if !ok {
+		return nil, fmt.Errorf("%q is not a number", *value)
+	}
+	return balance, nil
+}
@@ -0,0 +1,128 @@
+package treasury
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/models"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/processor"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+type memStore struct {
+	mu       sync.Mutex
+	config   *models.ChainConfig
+	balances map[string]*big.Int
+	topUps   []*models.WalletTopUp
+}
+
+func (s *memStore) GetChainConfig(ctx context.Context, chainID int64) (*models.ChainConfig, error) {
+	return s.config, nil
+}
+
+func (s *memStore) UpdateWalletBalance(ctx context.Context, address string, chainID int64, balance *big.Int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.balances[address] = balance
+	return nil
+}
+
+func (s *memStore) CreateWalletTopUp(ctx context.Context, topUp *models.WalletTopUp) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	copied := *topUp
+	copied.ID = int64(len(s.topUps) + 1)
+	copied.CreatedAt = time.Now()
+	s.topUps = append(s.topUps, &copied)
+	return nil
+}
+
+func (s *memStore) GetPendingWalletTopUps(ctx context.Context, chainID int64) ([]*models.WalletTopUp, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var pending []*models.WalletTopUp
+	for _, topUp := range s.topUps {
+		if topUp.ChainID == chainID && topUp.Status == models.TopUpPending {
+			copied := *topUp
+			pending = append(pending, &copied)
+		}
+	}
+	return pending, nil
+}
+
+func (s *memStore) UpdateWalletTopUpStatus(ctx context.Context, id int64, status string, errorMessage *string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.topUps[id-1].Status = status
+	s.topUps[id-1].ErrorMessage = errorMessage
+	return nil
+}
+
+func keyHex(t *testing.T, chain *testutil.Chain, balance *big.Int) string {
+	return hex.EncodeToString(crypto.FromECDSA(chain.NewFundedKey(t, balance)))
+}
+
+func TestMonitorTopsUpLowWallets(t *testing.T) {
+	ctx := context.Background()
+	chain := testutil.NewChain(t)
+	minBalance, targetBalance := testutil.Ether(1).String(), testutil.Ether(3).String()
+	store := &memStore{
+		config: &models.ChainConfig{
+			ChainID:               chain.ID,
+			ChainType:             "ethereum",
+			StuckTxTimeoutSeconds: 300,
+			MinWalletBalance:      &minBalance,
+			TargetWalletBalance:   &targetBalance,
+		},
+		balances: make(map[string]*big.Int),
+	}
+
+	lowKey := keyHex(t, chain, big.NewInt(1e17))
+	pool, err := processor.NewWalletPool([]string{keyHex(t, chain, testutil.Ether(5)), lowKey}, nil)
+	require.NoError(t, err)
+	healthy, low := pool.Wallets()[0], pool.Wallets()[1]
+	treasuryPool, err := processor.NewWalletPool([]string{hex.EncodeToString(crypto.FromECDSA(chain.Owner))}, nil)
+	require.NoError(t, err)
+	treasury := treasuryPool.Wallets()[0]
+
+	c := &processor.Chain{ID: chain.ID, Client: chain.Client, Bridge: chain.BridgeAddr}
+	monitor := New(processor.StaticChains{c}, pool, treasury, store, 0)
+
+	require.NoError(t, monitor.CheckChain(ctx, c))
+	require.Equal(t, big.NewInt(1e17), store.balances[low.Address.Hex()])
+	require.True(t, low.LowBalance(chain.ID))
+	require.False(t, healthy.LowBalance(chain.ID))
+
+	// Only the healthy wallet is handed out on the chain.
+	require.Same(t, healthy, pool.GetAvailableWallet(chain.ID))
+	require.Nil(t, pool.GetAvailableWallet(chain.ID))
+	pool.ReleaseWallet(healthy)
+
+	require.Len(t, store.topUps, 1)
+	topUp := store.topUps[0]
+	require.Equal(t, low.Address, topUp.WalletAddress)
+	require.Equal(t, treasury.Address, topUp.Treasury)
+	require.Equal(t, "2900000000000000000", topUp.Amount)
+	require.Equal(t, models.TopUpPending, topUp.Status)
+
+	// No second top-up while the first is in flight.
+	require.NoError(t, monitor.CheckChain(ctx, c))
+	require.Len(t, store.topUps, 1)
+
+	chain.Backend.Commit()
+	require.NoError(t, monitor.CheckChain(ctx, c))
+	require.Equal(t, models.TopUpConfirmed, store.topUps[0].Status)
+	require.Equal(t, testutil.Ether(3), store.balances[low.Address.Hex()])
+	require.False(t, low.LowBalance(chain.ID))
+	require.Len(t, store.topUps, 1)
+}
@@ -0,0 +1,82 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/attestation"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/contracts"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/models"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/processor"
+)
+
+// AggregatorStore is the part of models.Database the aggregator uses.
+type AggregatorStore interface {
+	GetAttestations(ctx context.Context, digest string) ([]*models.Attestation, error)
+}
+
+// Aggregator implements relayer.Attester by gathering the signatures the
+// validators published. The validator set and threshold are read from the
+// destination bridge, so signatures from removed attesters are never used.
+type Aggregator struct {
+	chains processor.ChainSet
+	db     AggregatorStore
+}
+
+func NewAggregator(chains processor.ChainSet, db AggregatorStore) *Aggregator {
+	return &Aggregator{
+		chains: chains,
+		db:     db,
+	}
+}
+
+// Attest returns the combined signatures of the bridge's threshold of
+// attesters, or an error wrapping attestation.ErrNotEnoughSignatures while
+// too few of them have signed.
+func (a *Aggregator) Attest(ctx context.Context, bridge common.Address, batch *attestation.Batch) ([]byte, error) {
+	dest := a.chains.Chain(batch.TargetChainID)
+	if dest == nil {
+		return nil, fmt.Errorf("destination chain %d is not configured", batch.TargetChainID)
+	}
+
+	caller, err := contracts.NewBatchBridgeCaller(bridge, dest.Client)
+	if err != nil {
+		return nil, fmt.Errorf("error binding bridge contract: %v", err)
+	}
+	opts := &bind.CallOpts{Context: ctx}
+
+	threshold, err := caller.AttesterThreshold(opts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting attester threshold: %v", err)
+	}
+
+	digest, err := attestation.Digest(bridge, batch)
+	if err != nil {
+		return nil, err
+	}
+	stored, err := a.db.GetAttestations(ctx, digest.Hex())
+	if err != nil {
+		return nil, err
+	}
+
+	signatures := make(map[common.Address][]byte)
+	for _, att := range stored {
+		signer, err := attestation.Recover(bridge, batch, att.Signature)
+		if err != nil || signer != att.Attester {
+			log.Printf("aggregator: ignoring invalid attestation %d from %s", att.ID, att.Attester.Hex())
+			continue
+		}
+		isAttester, err := caller.Attesters(opts, signer)
+		if err != nil {
+			return nil, fmt.Errorf("error checking attester %s: %v", signer.Hex(), err)
+		}
+		if isAttester {
+			signatures[signer] = att.Signature
+		}
+	}
+
+	return attestation.Combine(signatures, int(threshold.Int64()))
+}
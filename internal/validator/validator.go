@@ -0,0 +1,263 @@
+// Package validator runs the attesters that independently check confirmed
+// source batches and publish their signatures, and gathers those signatures
+// for the relayer.
+package validator
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/attestation"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/contracts"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/models"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/processor"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/tokens"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/tracker"
+)
+
+const DefaultPollInterval = 10 * time.Second
+
+// Store is the part of models.Database a validator uses.
+type Store interface {
+	GetChainConfig(ctx context.Context, chainID int64) (*models.ChainConfig, error)
+	GetBatchesByStatus(ctx context.Context, chainID int64, status string) ([]*models.Batch, error)
+	GetBatchSwaps(ctx context.Context, batchID int64) ([]*models.Swap, error)
+	SaveAttestation(ctx context.Context, attestation *models.Attestation) error
+}
+
+// Validator signs the completion of confirmed source batches. It does not
+// trust the swaps recorded in the database: what it signs is read back from
+// the BatchSwapInitiated event of the source transaction, once that
+// transaction is final by the validator's own check. Each request pays out
+// the target token and amount recorded with its swap when the swap was
+// created, as the relayer does, so that a mapping changed since does not
+// stall the batch. The payout is only signed when the swap matches the
+// event and the event's amount converts to it between the decimals the two
+// tokens are listed with.
+type Validator struct {
+	chains   processor.ChainSet
+	tokens   *tokens.Registry
+	signer   *attestation.Signer
+	db       Store
+	interval time.Duration
+
+	// signed holds the on-chain batch ids already attested.
+	signed map[string]bool
+	mutex  sync.Mutex
+}
+
+func New(chains processor.ChainSet, tokens *tokens.Registry, signer *attestation.Signer, db Store, interval time.Duration) *Validator {
+	if interval == 0 {
+		interval = DefaultPollInterval
+	}
+	return &Validator{
+		chains:   chains,
+		tokens:   tokens,
+		signer:   signer,
+		db:       db,
+		interval: interval,
+		signed:   make(map[string]bool),
+	}
+}
+
+// Address returns the attester address the validator signs with.
+func (v *Validator) Address() common.Address {
+	return v.signer.Address
+}
+
+// Start runs one validating loop per source chain until ctx is cancelled.
+func (v *Validator) Start(ctx context.Context) {
+	go processor.Follow(ctx, v.chains, v.run)
+}
+
+func (v *Validator) run(ctx context.Context, source *processor.Chain) {
+	ticker := time.NewTicker(v.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := v.validateChain(ctx, source); err != nil {
+			log.Printf("validator %s: chain %d: %v", v.signer.Address.Hex(), source.ID, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (v *Validator) validateChain(ctx context.Context, source *processor.Chain) error {
+	batches, err := v.db.GetBatchesByStatus(ctx, source.ID, "confirmed")
+	if err != nil {
+		return err
+	}
+
+	for _, batch := range batches {
+		if batch.OnchainBatchID == nil || batch.SourceTxHash == nil || batch.TargetTxHash != nil {
+			continue
+		}
+		if v.hasSigned(*batch.OnchainBatchID) {
+			continue
+		}
+		if err := v.attest(ctx, source, batch); err != nil {
+			log.Printf("validator %s: batch %s: %v", v.signer.Address.Hex(), batch.BatchID, err)
+		}
+	}
+	return nil
+}
+
+func (v *Validator) attest(ctx context.Context, source *processor.Chain, batch *models.Batch) error {
+	dest := v.chains.Chain(batch.TargetChainID)
+	if dest == nil {
+		return fmt.Errorf("destination chain %d is not configured", batch.TargetChainID)
+	}
+
+	receipt, err := source.Client.TransactionReceipt(ctx, common.HexToHash(*batch.SourceTxHash))
+	if err != nil {
+		return fmt.Errorf("error getting source receipt: %v", err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return fmt.Errorf("source transaction %s failed", *batch.SourceTxHash)
+	}
+
+	config, err := v.db.GetChainConfig(ctx, source.ID)
+	if err != nil {
+		return err
+	}
+	final, err := tracker.FinalizedHeight(ctx, source.Client, config)
+	if err != nil {
+		return err
+	}
+	if receipt.BlockNumber.Uint64() > final {
+		// The tracker and this validator disagree on finality; retry later.
+		return nil
+	}
+
+	initiated, err := initiatedEvent(source, receipt)
+	if err != nil {
+		return err
+	}
+	if common.Hash(initiated.BatchId).Hex() != *batch.OnchainBatchID {
+		return fmt.Errorf("source transaction carries batch %s, not %s", common.Hash(initiated.BatchId).Hex(), *batch.OnchainBatchID)
+	}
+	swaps, err := v.db.GetBatchSwaps(ctx, batch.ID)
+	if err != nil {
+		return err
+	}
+	if len(swaps) != len(initiated.Requests) {
+		return fmt.Errorf("source transaction carries %d requests, the batch has %d swaps", len(initiated.Requests), len(swaps))
+	}
+	requests := make([]contracts.BatchBridgeSwapRequest, len(initiated.Requests))
+	for i, req := range initiated.Requests {
+		if req.TargetChainId.Cmp(big.NewInt(dest.ID)) != 0 {
+			return fmt.Errorf("request %d targets chain %s, not %d", i, req.TargetChainId, dest.ID)
+		}
+		payout, err := v.payout(source.ID, dest.ID, req, swaps[i])
+		if err != nil {
+			return fmt.Errorf("request %d: %v", i, err)
+		}
+		requests[i] = payout
+	}
+
+	completion := &attestation.Batch{
+		BatchID:       initiated.BatchId,
+		SourceChainID: source.ID,
+		TargetChainID: dest.ID,
+		Requests:      requests,
+	}
+	digest, err := attestation.Digest(dest.Bridge, completion)
+	if err != nil {
+		return err
+	}
+	signature, err := v.signer.Sign(dest.Bridge, completion)
+	if err != nil {
+		return err
+	}
+
+	err = v.db.SaveAttestation(ctx, &models.Attestation{
+		BatchID:   batch.ID,
+		Digest:    digest.Hex(),
+		Attester:  v.signer.Address,
+		Signature: signature,
+	})
+	if err != nil {
+		return err
+	}
+
+	v.mutex.Lock()
+	v.signed[*batch.OnchainBatchID] = true
+	v.mutex.Unlock()
+	return nil
+}
+
+// payout returns what req, a request of the source event, pays out on the
+// destination chain: the target token and amount recorded with swap, the
+// swap stored at its position in the batch.
+func (v *Validator) payout(sourceChainID, destChainID int64, req contracts.BatchBridgeSwapRequest, swap *models.Swap) (contracts.BatchBridgeSwapRequest, error) {
+	var payout contracts.BatchBridgeSwapRequest
+	amount, ok := new(big.Int).SetString(swap.Amount, 10)
+	if !ok {
+		return payout, fmt.Errorf("invalid amount %q for swap %s", swap.Amount, swap.RequestID)
+	}
+	if swap.FromChainID != sourceChainID || swap.ToChainID != destChainID || swap.TokenAddress != req.Token || amount.Cmp(req.Amount) != 0 || swap.Recipient != req.Recipient {
+		return payout, fmt.Errorf("swap %s does not match the source event", swap.RequestID)
+	}
+	targetAmount, ok := new(big.Int).SetString(swap.TargetAmount, 10)
+	if !ok {
+		return payout, fmt.Errorf("invalid target amount %q for swap %s", swap.TargetAmount, swap.RequestID)
+	}
+
+	sourceToken, ok := v.tokens.Token(sourceChainID, req.Token)
+	if !ok {
+		return payout, fmt.Errorf("token %s is not listed on chain %d", req.Token.Hex(), sourceChainID)
+	}
+	targetToken, ok := v.tokens.Token(destChainID, swap.TargetTokenAddress)
+	if !ok {
+		return payout, fmt.Errorf("token %s is not listed on chain %d", swap.TargetTokenAddress.Hex(), destChainID)
+	}
+	converted, _ := tokens.Convert(req.Amount, sourceToken.Decimals, targetToken.Decimals)
+	if converted.Cmp(targetAmount) != 0 {
+		return payout, fmt.Errorf("swap %s pays out %s, not the %s its amount converts to", swap.RequestID, targetAmount, converted)
+	}
+
+	return contracts.BatchBridgeSwapRequest{
+		Token:         swap.TargetTokenAddress,
+		Amount:        targetAmount,
+		Recipient:     req.Recipient,
+		TargetChainId: req.TargetChainId,
+	}, nil
+}
+
+func (v *Validator) hasSigned(onchainBatchID string) bool {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	return v.signed[onchainBatchID]
+}
+
+// initiatedEvent decodes the BatchSwapInitiated event the source bridge
+// emitted in receipt.
+func initiatedEvent(source *processor.Chain, receipt *types.Receipt) (*contracts.BatchBridgeBatchSwapInitiated, error) {
+	filterer, err := contracts.NewBatchBridgeFilterer(source.Bridge, source.Client)
+	if err != nil {
+		return nil, fmt.Errorf("error binding bridge contract: %v", err)
+	}
+
+	for _, l := range receipt.Logs {
+		if l.Address != source.Bridge {
+			continue
+		}
+		event, err := filterer.ParseBatchSwapInitiated(*l)
+		if err == nil {
+			return event, nil
+		}
+	}
+	return nil, fmt.Errorf("no %s event in source transaction", models.EventBatchSwapInitiated)
+}
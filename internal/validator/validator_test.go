@@ -0,0 +1,419 @@
+package validator
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/attestation"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/contracts"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/models"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/processor"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/testutil"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/tokens"
+	"github.com/stretchr/testify/require"
+)
+
+const destChainID = 56
+
+type memStore struct {
+	mu           sync.Mutex
+	configs      map[int64]*models.ChainConfig
+	batches      map[int64]*models.Batch
+	attestations []*models.Attestation
+	tokens       []*models.SupportedToken
+	mappings     []*models.TokenMapping
+	swaps        map[int64][]*models.Swap
+}
+
+func (s *memStore) GetSupportedTokens(ctx context.Context) ([]*models.SupportedToken, error) {
+	return s.tokens, nil
+}
+
+func (s *memStore) GetTokenMappings(ctx context.Context) ([]*models.TokenMapping, error) {
+	return s.mappings, nil
+}
+
+func (s *memStore) GetChainConfig(ctx context.Context, chainID int64) (*models.ChainConfig, error) {
+	config, ok := s.configs[chainID]
+	if !ok {
+		return nil, models.ErrChainConfigNotFound
+	}
+	return config, nil
+}
+
+func (s *memStore) GetBatchesByStatus(ctx context.Context, chainID int64, status string) ([]*models.Batch, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var batches []*models.Batch
+	for _, b := range s.batches {
+		if b.ChainID == chainID && b.Status == status {
+			copied := *b
+			batches = append(batches, &copied)
+		}
+	}
+	return batches, nil
+}
+
+func (s *memStore) GetBatchSwaps(ctx context.Context, batchID int64) ([]*models.Swap, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.swaps[batchID], nil
+}
+
+func (s *memStore) SaveAttestation(ctx context.Context, attestation *models.Attestation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, a := range s.attestations {
+		if a.Digest == attestation.Digest && a.Attester == attestation.Attester {
+			return nil
+		}
+	}
+	copied := *attestation
+	copied.ID = int64(len(s.attestations) + 1)
+	s.attestations = append(s.attestations, &copied)
+	return nil
+}
+
+func (s *memStore) GetAttestations(ctx context.Context, digest string) ([]*models.Attestation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var attestations []*models.Attestation
+	for _, a := range s.attestations {
+		if a.Digest == digest {
+			attestations = append(attestations, a)
+		}
+	}
+	return attestations, nil
+}
+
+type fixture struct {
+	source     *testutil.Chain
+	dest       *testutil.Chain
+	chains     processor.StaticChains
+	wallet     *processor.Wallet
+	store      *memStore
+	tokens     *tokens.Registry
+	validators []*Validator
+	aggregator *Aggregator
+	completion *attestation.Batch
+}
+
+// newFixture mines a one-swap batch on a source chain and sets up a
+// destination bridge with three registered validators and a threshold of
+// two. The batch is recorded as confirmed with one required confirmation.
+// The source token is mapped to a six-decimal token on the destination, so
+// the three tokens swapped pay out 3e6 units.
+func newFixture(t *testing.T) *fixture {
+	owner, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	source := testutil.NewChainWithID(t, testutil.SimulatedChainID, owner)
+	dest := testutil.NewChainWithID(t, destChainID, owner)
+
+	tx, err := dest.Token.Transfer(dest.Transactor(t, owner), dest.BridgeAddr, testutil.Ether(10))
+	require.NoError(t, err)
+	dest.Mine(t, tx)
+
+	chains := processor.StaticChains{
+		{ID: source.ID, Client: source.Client, Bridge: source.BridgeAddr},
+		{ID: dest.ID, Client: dest.Client, Bridge: dest.BridgeAddr},
+	}
+	store := &memStore{
+		configs: map[int64]*models.ChainConfig{
+			source.ID: {ChainID: source.ID, RequiredConfirmations: 1, FinalityMode: models.FinalityConfirmations},
+			dest.ID:   {ChainID: dest.ID, RequiredConfirmations: 1, FinalityMode: models.FinalityConfirmations},
+		},
+		batches: make(map[int64]*models.Batch),
+		tokens: []*models.SupportedToken{
+			{ChainID: source.ID, Address: source.TokenAddr, Symbol: "TKN", Decimals: 18, IsActive: true},
+			{ChainID: dest.ID, Address: dest.TokenAddr, Symbol: "TKN", Decimals: 6, IsActive: true},
+		},
+		swaps: make(map[int64][]*models.Swap),
+		mappings: []*models.TokenMapping{{
+			SourceChainID:  source.ID,
+			SourceToken:    source.TokenAddr,
+			SourceDecimals: 18,
+			TargetChainID:  dest.ID,
+			TargetToken:    dest.TokenAddr,
+			TargetDecimals: 6,
+		}},
+	}
+	tokenRegistry := tokens.New(store, 0)
+	require.NoError(t, tokenRegistry.Load(context.Background()))
+
+	f := &fixture{
+		source:     source,
+		dest:       dest,
+		chains:     chains,
+		store:      store,
+		tokens:     tokenRegistry,
+		aggregator: NewAggregator(chains, store),
+	}
+	for i := 0; i < 3; i++ {
+		v := f.newValidator(t)
+		tx, err := dest.Bridge.AddAttester(dest.Transactor(t, dest.Admin), v.Address())
+		require.NoError(t, err)
+		dest.Mine(t, tx)
+		f.validators = append(f.validators, v)
+	}
+	tx, err = dest.Bridge.SetAttesterThreshold(dest.Transactor(t, dest.Admin), big.NewInt(2))
+	require.NoError(t, err)
+	dest.Mine(t, tx)
+
+	pool, err := processor.NewWalletPool([]string{hex.EncodeToString(crypto.FromECDSA(owner))}, nil)
+	require.NoError(t, err)
+	f.wallet = pool.Wallets()[0]
+
+	requests := []*models.SwapRequest{{
+		FromChainID:  source.ID,
+		ToChainID:    destChainID,
+		TokenAddress: source.TokenAddr,
+		Amount:       testutil.Ether(3),
+		Recipient:    common.HexToAddress("0xbeef"),
+	}}
+	tx, err = f.wallet.ProcessBatch(context.Background(), chains[0], requests, nil)
+	require.NoError(t, err)
+	receipt := source.Mine(t, tx)
+	event, err := source.Bridge.ParseBatchSwapInitiated(*receipt.Logs[len(receipt.Logs)-1])
+	require.NoError(t, err)
+
+	onchainID := common.Hash(event.BatchId).Hex()
+	txHash := tx.Hash().Hex()
+	store.batches[1] = &models.Batch{
+		ID:             1,
+		BatchID:        "batch-1",
+		ChainID:        source.ID,
+		TargetChainID:  destChainID,
+		OnchainBatchID: &onchainID,
+		SourceTxHash:   &txHash,
+		Status:         "confirmed",
+	}
+	store.swaps[1] = []*models.Swap{{
+		ID:                 1,
+		RequestID:          "swap-1",
+		FromChainID:        source.ID,
+		ToChainID:          destChainID,
+		TokenAddress:       source.TokenAddr,
+		Amount:             testutil.Ether(3).String(),
+		Recipient:          common.HexToAddress("0xbeef"),
+		TargetTokenAddress: dest.TokenAddr,
+		TargetAmount:       "3000000",
+	}}
+	f.completion = &attestation.Batch{
+		BatchID:       event.BatchId,
+		SourceChainID: source.ID,
+		TargetChainID: destChainID,
+		Requests: []contracts.BatchBridgeSwapRequest{{
+			Token:         dest.TokenAddr,
+			Amount:        big.NewInt(3e6),
+			Recipient:     common.HexToAddress("0xbeef"),
+			TargetChainId: big.NewInt(destChainID),
+		}},
+	}
+	return f
+}
+
+func (f *fixture) newValidator(t *testing.T) *Validator {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return New(f.chains, f.tokens, attestation.NewSigner(key), f.store, 0)
+}
+
+func (f *fixture) validate(t *testing.T, v *Validator) {
+	require.NoError(t, v.validateChain(context.Background(), f.chains[0]))
+}
+
+func (f *fixture) attest(t *testing.T) ([]byte, error) {
+	return f.aggregator.Attest(context.Background(), f.dest.BridgeAddr, f.completion)
+}
+
+func (f *fixture) adminOpts(t *testing.T) *bind.TransactOpts {
+	return f.dest.Transactor(t, f.dest.Admin)
+}
+
+func TestValidatorsReachThreshold(t *testing.T) {
+	f := newFixture(t)
+
+	f.validate(t, f.validators[0])
+	_, err := f.attest(t)
+	require.ErrorIs(t, err, attestation.ErrNotEnoughSignatures)
+
+	// Validating again does not add a second signature from the same key.
+	f.validate(t, f.validators[0])
+	require.Len(t, f.store.attestations, 1)
+
+	f.validate(t, f.validators[1])
+	signatures, err := f.attest(t)
+	require.NoError(t, err)
+	require.Len(t, signatures, 2*crypto.SignatureLength)
+
+	tx, err := f.wallet.CompleteBatch(context.Background(), f.chains[1], f.completion, signatures, nil)
+	require.NoError(t, err)
+	f.dest.Mine(t, tx)
+
+	balance, err := f.dest.Token.BalanceOf(&bind.CallOpts{}, common.HexToAddress("0xbeef"))
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(3e6), balance)
+}
+
+func TestValidatorWaitsForSourceFinality(t *testing.T) {
+	f := newFixture(t)
+	f.store.configs[f.source.ID].RequiredConfirmations = 3
+
+	f.validate(t, f.validators[0])
+	require.Empty(t, f.store.attestations)
+
+	f.source.Backend.Commit()
+	f.source.Backend.Commit()
+	f.validate(t, f.validators[0])
+	require.Len(t, f.store.attestations, 1)
+}
+
+func TestAggregatorUsesCurrentValidatorSet(t *testing.T) {
+	f := newFixture(t)
+
+	// A validator the bridge does not know about does not count.
+	f.validate(t, f.newValidator(t))
+	f.validate(t, f.validators[0])
+	_, err := f.attest(t)
+	require.ErrorIs(t, err, attestation.ErrNotEnoughSignatures)
+
+	f.validate(t, f.validators[1])
+	_, err = f.attest(t)
+	require.NoError(t, err)
+
+	// Once removed on-chain, a validator's published signature is ignored.
+	tx, err := f.dest.Bridge.RemoveAttester(f.adminOpts(t), f.validators[1].Address())
+	require.NoError(t, err)
+	f.dest.Mine(t, tx)
+	_, err = f.attest(t)
+	require.ErrorIs(t, err, attestation.ErrNotEnoughSignatures)
+
+	f.validate(t, f.validators[2])
+	_, err = f.attest(t)
+	require.NoError(t, err)
+}
+
+func TestAggregatorIgnoresTamperedBatch(t *testing.T) {
+	f := newFixture(t)
+	for _, v := range f.validators {
+		f.validate(t, v)
+	}
+
+	// Validators sign the requests emitted on the source chain, so a batch
+	// rebuilt from altered records finds no signatures.
+	f.completion.Requests[0].Amount = testutil.Ether(9)
+	_, err := f.attest(t)
+	require.ErrorIs(t, err, attestation.ErrNotEnoughSignatures)
+}
+
+func TestValidatorSignsRecordedPayoutAfterMappingChanges(t *testing.T) {
+	f := newFixture(t)
+
+	// The source token is remapped to another destination token while the
+	// batch is in flight
+	other := common.HexToAddress("0x07e4")
+	f.store.tokens = append(f.store.tokens, &models.SupportedToken{ChainID: destChainID, Address: other, Symbol: "OTH", Decimals: 18, IsActive: true})
+	f.store.mappings[0].TargetToken = other
+	f.store.mappings[0].TargetDecimals = 18
+	require.NoError(t, f.tokens.Load(context.Background()))
+
+	f.validate(t, f.validators[0])
+	f.validate(t, f.validators[1])
+	_, err := f.attest(t)
+	require.NoError(t, err)
+}
+
+func TestValidatorRejectsRecordedPayoutNotMatchingEvent(t *testing.T) {
+	tests := []struct {
+		name   string
+		tamper func(*models.Swap)
+	}{
+		{"inflated target amount", func(s *models.Swap) { s.TargetAmount = "9000000" }},
+		{"unlisted target token", func(s *models.Swap) { s.TargetTokenAddress = common.HexToAddress("0x0bad") }},
+		{"other recipient", func(s *models.Swap) { s.Recipient = common.HexToAddress("0x0bad") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			tt.tamper(f.store.swaps[1][0])
+
+			for _, v := range f.validators {
+				f.validate(t, v)
+			}
+			require.Empty(t, f.store.attestations)
+		})
+	}
+}
+
+func TestBridgeRejectsInvalidSignatureSets(t *testing.T) {
+	f := newFixture(t)
+
+	signatures := make(map[common.Address][]byte)
+	for _, v := range f.validators[:2] {
+		sig, err := v.signer.Sign(f.dest.BridgeAddr, f.completion)
+		require.NoError(t, err)
+		signatures[v.Address()] = sig
+	}
+	combined, err := attestation.Combine(signatures, 2)
+	require.NoError(t, err)
+	first, second := combined[:crypto.SignatureLength], combined[crypto.SignatureLength:]
+
+	tests := []struct {
+		name      string
+		signature []byte
+	}{
+		{"below threshold", first},
+		{"duplicate signer", append(append([]byte{}, first...), first...)},
+		{"descending signers", append(append([]byte{}, second...), first...)},
+		{"truncated", combined[:len(combined)-1]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := f.wallet.CompleteBatch(context.Background(), f.chains[1], f.completion, tt.signature, nil)
+			require.ErrorContains(t, err, "Invalid signature")
+		})
+	}
+}
+
+func TestValidatorSetManagement(t *testing.T) {
+	f := newFixture(t)
+	bridge := f.dest.Bridge
+
+	count, err := bridge.AttesterCount(&bind.CallOpts{})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), count.Int64())
+
+	_, err = bridge.AddAttester(f.adminOpts(t), f.validators[0].Address())
+	require.ErrorContains(t, err, "Already an attester")
+
+	_, err = bridge.SetAttesterThreshold(f.adminOpts(t), big.NewInt(4))
+	require.ErrorContains(t, err, "Invalid threshold")
+	_, err = bridge.SetAttesterThreshold(f.adminOpts(t), big.NewInt(0))
+	require.ErrorContains(t, err, "Invalid threshold")
+
+	tx, err := bridge.RemoveAttester(f.adminOpts(t), f.validators[2].Address())
+	require.NoError(t, err)
+	f.dest.Mine(t, tx)
+
+	// Two attesters left with a threshold of two: removing one more would
+	// make completions impossible.
+	_, err = bridge.RemoveAttester(f.adminOpts(t), f.validators[1].Address())
+	require.ErrorContains(t, err, "Threshold exceeds attesters")
+
+	// Neither an outsider nor the owner, a hot wallet, may change the set.
+	outsider := f.dest.NewFundedKey(t, testutil.Ether(1))
+	_, err = bridge.AddAttester(f.dest.Transactor(t, outsider), crypto.PubkeyToAddress(outsider.PublicKey))
+	require.ErrorContains(t, err, "Caller is not the admin")
+	_, err = bridge.AddAttester(f.dest.Transactor(t, f.dest.Owner), crypto.PubkeyToAddress(outsider.PublicKey))
+	require.ErrorContains(t, err, "Caller is not the admin")
+}
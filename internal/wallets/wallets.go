@@ -0,0 +1,451 @@
+// Package wallets changes the set of hot wallets while the service runs:
+// it adds wallets, drains a wallet's balances into another one and retires
+// drained wallets, keeping the wallet pool in step with hot_wallets.
+package wallets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/gas"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/models"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/processor"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/signer"
+)
+
+const DefaultPollInterval = 30 * time.Second
+
+// ErrInvalidOperation is returned, wrapped, for operations the wallet's
+// state does not allow.
+var ErrInvalidOperation = errors.New("invalid wallet operation")
+
+// Store is the part of models.Database the manager uses.
+type Store interface {
+	GetChainConfig(ctx context.Context, chainID int64) (*models.ChainConfig, error)
+	GetSupportedTokens(ctx context.Context) ([]*models.SupportedToken, error)
+	GetUnminedBatchNonces(ctx context.Context, address string, chainID int64) ([]uint64, error)
+	GetHotWalletStates(ctx context.Context) ([]*models.HotWalletState, error)
+	AddHotWallet(ctx context.Context, address string, chainIDs []int64) error
+	DrainHotWallet(ctx context.Context, address, to string, chainIDs []int64) error
+	MarkHotWalletDrained(ctx context.Context, address string, chainID int64) error
+	RetireHotWallet(ctx context.Context, address string) error
+}
+
+// SignerLoader returns the signer for a wallet that was not loaded at
+// startup, such as one added at runtime.
+type SignerLoader func(address common.Address) (signer.Signer, error)
+
+// Manager applies the wallet operations and reloads hot_wallets regularly,
+// so that operations made through another instance reach this one's pool.
+//
+// A wallet being drained gets no new work. On each chain, once it has no
+// reserved work and no transaction in flight, every supported token it
+// holds and then its native coin, less the fee of the transfer, are sent to
+// the wallet it drains into. When nothing is left there, the chain is
+// marked drained. A wallet drained on every chain can be retired: it is
+// marked inactive, taken out of the pool and its key is zeroed.
+type Manager struct {
+	chains     processor.ChainSet
+	walletPool *processor.WalletPool
+	loadSigner SignerLoader
+	oracle     *gas.Oracle
+	db         Store
+	interval   time.Duration
+
+	mu      sync.Mutex
+	states  map[common.Address]*models.HotWalletState
+	missing map[common.Address]bool
+}
+
+// New returns a manager for the wallets of walletPool. loadSigner may be
+// nil, in which case wallets cannot be added.
+func New(chains processor.ChainSet, walletPool *processor.WalletPool, loadSigner SignerLoader, db Store, interval time.Duration) *Manager {
+	if interval == 0 {
+		interval = DefaultPollInterval
+	}
+	return &Manager{
+		chains:     chains,
+		walletPool: walletPool,
+		loadSigner: loadSigner,
+		oracle:     gas.NewOracle(),
+		db:         db,
+		interval:   interval,
+		states:     make(map[common.Address]*models.HotWalletState),
+		missing:    make(map[common.Address]bool),
+	}
+}
+
+// Start keeps the pool in step with hot_wallets and drains wallets on each
+// chain until ctx is cancelled.
+func (m *Manager) Start(ctx context.Context) {
+	go m.syncLoop(ctx)
+	go processor.Follow(ctx, m.chains, m.run)
+}
+
+func (m *Manager) syncLoop(ctx context.Context) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := m.Sync(ctx); err != nil {
+			log.Printf("wallets: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *Manager) run(ctx context.Context, chain *processor.Chain) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := m.DrainChain(ctx, chain); err != nil {
+			log.Printf("wallets: chain %d: %v", chain.ID, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Wallets returns the state of every wallet in hot_wallets.
+func (m *Manager) Wallets(ctx context.Context) ([]*models.HotWalletState, error) {
+	return m.db.GetHotWalletStates(ctx)
+}
+
+// Sync reloads hot_wallets and applies it to the pool. Active wallets the
+// pool lacks are loaded, draining ones stop getting work and retired ones
+// are removed once they are idle.
+func (m *Manager) Sync(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.sync(ctx)
+}
+
+func (m *Manager) sync(ctx context.Context) error {
+	states, err := m.db.GetHotWalletStates(ctx)
+	if err != nil {
+		return err
+	}
+
+	m.states = make(map[common.Address]*models.HotWalletState, len(states))
+	for _, state := range states {
+		m.states[state.Address] = state
+		m.apply(state)
+	}
+	return nil
+}
+
+func (m *Manager) apply(state *models.HotWalletState) {
+	wallet := m.walletPool.Wallet(state.Address)
+	if !state.IsActive {
+		if wallet == nil {
+			return
+		}
+		wallet.SetDraining(true)
+		removed, err := m.walletPool.Remove(state.Address)
+		if err != nil {
+			log.Printf("wallets: retiring wallet %s: %v", state.Address.Hex(), err)
+			return
+		}
+		signer.Zero(removed.Signer)
+		log.Printf("wallets: wallet %s retired", state.Address.Hex())
+		return
+	}
+
+	if wallet == nil {
+		if m.loadSigner == nil {
+			return
+		}
+		s, err := m.loadSigner(state.Address)
+		if err != nil {
+			// Logged once: the wallet may be served by another instance
+			if !m.missing[state.Address] {
+				log.Printf("wallets: error loading wallet %s: %v", state.Address.Hex(), err)
+				m.missing[state.Address] = true
+			}
+			return
+		}
+		delete(m.missing, state.Address)
+		wallet = m.walletPool.Add(s)
+		log.Printf("wallets: wallet %s added", state.Address.Hex())
+	}
+	wallet.SetDraining(state.DrainTo != nil)
+}
+
+// Add makes address an active hot wallet on every chain and puts it in the
+// pool. A draining or retired wallet is put back to work.
+func (m *Manager) Add(ctx context.Context, address common.Address) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var s signer.Signer
+	if m.walletPool.Wallet(address) == nil {
+		if m.loadSigner == nil {
+			return fmt.Errorf("%w: wallets can only be added with a keystore or remote signer", ErrInvalidOperation)
+		}
+		var err error
+		if s, err = m.loadSigner(address); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidOperation, err)
+		}
+	}
+
+	if err := m.db.AddHotWallet(ctx, address.Hex(), m.chainIDs()); err != nil {
+		return err
+	}
+	if s != nil {
+		m.walletPool.Add(s)
+	}
+	log.Printf("wallets: wallet %s added", address.Hex())
+
+	delete(m.missing, address)
+	return m.sync(ctx)
+}
+
+// Drain stops giving address work and sweeps its balances on every chain
+// into to, which must be an active wallet that is not draining itself.
+func (m *Manager) Drain(ctx context.Context, address, to common.Address) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.sync(ctx); err != nil {
+		return err
+	}
+	state := m.states[address]
+	if state == nil || !state.IsActive {
+		return fmt.Errorf("%w: %s is not an active hot wallet", ErrInvalidOperation, address.Hex())
+	}
+	if m.walletPool.Wallet(address) == nil {
+		return fmt.Errorf("%w: the key of %s is not loaded", ErrInvalidOperation, address.Hex())
+	}
+	if to == address {
+		return fmt.Errorf("%w: a wallet cannot be drained into itself", ErrInvalidOperation)
+	}
+	target := m.states[to]
+	if target == nil || !target.IsActive || target.DrainTo != nil {
+		return fmt.Errorf("%w: %s is not an active hot wallet that is not draining", ErrInvalidOperation, to.Hex())
+	}
+
+	if err := m.db.DrainHotWallet(ctx, address.Hex(), to.Hex(), m.chainIDs()); err != nil {
+		return err
+	}
+	log.Printf("wallets: draining wallet %s into %s", address.Hex(), to.Hex())
+	return m.sync(ctx)
+}
+
+// Retire marks address inactive and removes it from the pool. The wallet
+// must have been drained on every chain first.
+func (m *Manager) Retire(ctx context.Context, address common.Address) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.sync(ctx); err != nil {
+		return err
+	}
+	state := m.states[address]
+	if state == nil || !state.IsActive {
+		return fmt.Errorf("%w: %s is not an active hot wallet", ErrInvalidOperation, address.Hex())
+	}
+	if state.DrainTo == nil {
+		return fmt.Errorf("%w: %s must be drained before it is retired", ErrInvalidOperation, address.Hex())
+	}
+	for _, chainID := range m.chainIDs() {
+		if !state.Drained(chainID) {
+			return fmt.Errorf("%w: %s is still draining on chain %d", ErrInvalidOperation, address.Hex(), chainID)
+		}
+	}
+
+	if err := m.db.RetireHotWallet(ctx, address.Hex()); err != nil {
+		return err
+	}
+	return m.sync(ctx)
+}
+
+func (m *Manager) chainIDs() []int64 {
+	chains := m.chains.Chains()
+	ids := make([]int64, len(chains))
+	for i, chain := range chains {
+		ids[i] = chain.ID
+	}
+	return ids
+}
+
+// drainee is a wallet to sweep on a chain and where its balances go.
+type drainee struct {
+	wallet *processor.Wallet
+	to     common.Address
+}
+
+// DrainChain moves the draining wallets on chain one step further.
+func (m *Manager) DrainChain(ctx context.Context, chain *processor.Chain) error {
+	m.mu.Lock()
+	var drainees []drainee
+	for _, state := range m.states {
+		if !state.IsActive || state.DrainTo == nil || state.Drained(chain.ID) {
+			continue
+		}
+		if wallet := m.walletPool.Wallet(state.Address); wallet != nil {
+			drainees = append(drainees, drainee{wallet: wallet, to: *state.DrainTo})
+		}
+	}
+	m.mu.Unlock()
+	if len(drainees) == 0 {
+		return nil
+	}
+
+	config, err := m.db.GetChainConfig(ctx, chain.ID)
+	if err != nil {
+		return err
+	}
+	for _, d := range drainees {
+		drained, err := m.drain(ctx, chain, config, d.wallet, d.to)
+		if err != nil {
+			log.Printf("wallets: chain %d: error draining wallet %s: %v", chain.ID, d.wallet.Address.Hex(), err)
+			continue
+		}
+		if drained {
+			m.markDrained(d.wallet.Address, chain.ID)
+		}
+	}
+	return nil
+}
+
+// drain sweeps what wallet holds on chain into to, once its transactions
+// there are settled. Tokens go first, while the wallet still has gas for
+// them, and the native coin once they are mined. It reports true when
+// nothing is left to sweep and the chain has been marked drained.
+func (m *Manager) drain(ctx context.Context, chain *processor.Chain, config *models.ChainConfig, wallet *processor.Wallet, to common.Address) (bool, error) {
+	if wallet.Processing() {
+		return false, nil
+	}
+	nonces, err := m.db.GetUnminedBatchNonces(ctx, wallet.Address.Hex(), chain.ID)
+	if err != nil {
+		return false, err
+	}
+	if len(nonces) > 0 {
+		return false, nil
+	}
+	pending, err := chain.Client.PendingNonceAt(ctx, wallet.Address)
+	if err != nil {
+		return false, fmt.Errorf("error getting pending nonce: %v", err)
+	}
+	mined, err := chain.Client.NonceAt(ctx, wallet.Address, nil)
+	if err != nil {
+		return false, fmt.Errorf("error getting nonce: %v", err)
+	}
+	if pending > mined {
+		return false, nil
+	}
+
+	fees, err := m.oracle.Fees(ctx, chain.Client, config)
+	if err != nil {
+		return false, err
+	}
+
+	tokens, err := m.db.GetSupportedTokens(ctx)
+	if err != nil {
+		return false, err
+	}
+	swept := false
+	for _, token := range tokens {
+		if token.ChainID != chain.ID || processor.IsNative(token.Address) {
+			continue
+		}
+		funds, err := wallet.TokenFunds(ctx, chain, token.Address)
+		if err != nil {
+			return false, err
+		}
+		if funds.Balance.Sign() == 0 {
+			continue
+		}
+		tx, err := wallet.TransferToken(ctx, chain, token.Address, to, funds.Balance, fees)
+		if err != nil {
+			return false, err
+		}
+		log.Printf("wallets: chain %d: sweeping %s of token %s from %s to %s in %s", chain.ID, funds.Balance, token.Address.Hex(), wallet.Address.Hex(), to.Hex(), tx.Hash().Hex())
+		swept = true
+	}
+	if swept {
+		return false, nil
+	}
+
+	balance, err := chain.Client.BalanceAt(ctx, wallet.Address, nil)
+	if err != nil {
+		return false, fmt.Errorf("error getting native balance: %v", err)
+	}
+	gasLimit, err := wallet.TransferGas(ctx, chain, to)
+	if err != nil {
+		return false, err
+	}
+	cost, err := transferCost(ctx, chain, config, fees, to, balance, gasLimit)
+	if err != nil {
+		return false, err
+	}
+	if balance.Cmp(cost) > 0 {
+		amount := new(big.Int).Sub(balance, cost)
+		tx, err := wallet.Transfer(ctx, chain, to, amount, gasLimit, fees)
+		if err != nil {
+			return false, err
+		}
+		log.Printf("wallets: chain %d: sweeping %s native from %s to %s in %s", chain.ID, amount, wallet.Address.Hex(), to.Hex(), tx.Hash().Hex())
+		return false, nil
+	}
+
+	if err := m.db.MarkHotWalletDrained(ctx, wallet.Address.Hex(), chain.ID); err != nil {
+		return false, err
+	}
+	log.Printf("wallets: chain %d: wallet %s drained", chain.ID, wallet.Address.Hex())
+	return true, nil
+}
+
+func (m *Manager) markDrained(address common.Address, chainID int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if state := m.states[address]; state != nil && !state.Drained(chainID) {
+		state.DrainedChains = append(state.DrainedChains, chainID)
+	}
+}
+
+// transferCost is the most a native transfer of value to to with gasLimit
+// can cost at fees. On rollups it includes the estimated L1 data fee twice,
+// as that fee moves with L1 until the transfer is included.
+func transferCost(ctx context.Context, chain *processor.Chain, config *models.ChainConfig, fees *gas.Fees, to common.Address, value *big.Int, gasLimit uint64) (*big.Int, error) {
+	var tx *types.Transaction
+	var price *big.Int
+	if fees.Legacy() {
+		price = fees.GasPrice
+		tx = types.NewTx(&types.LegacyTx{GasPrice: price, Gas: gasLimit, To: &to, Value: value})
+	} else {
+		price = fees.GasFeeCap
+		tx = types.NewTx(&types.DynamicFeeTx{
+			ChainID:   big.NewInt(chain.ID),
+			GasTipCap: fees.GasTipCap,
+			GasFeeCap: fees.GasFeeCap,
+			Gas:       gasLimit,
+			To:        &to,
+			Value:     value,
+		})
+	}
+	cost, err := gas.EstimateCost(ctx, chain.Client, config.ChainType, tx, gasLimit, price, nil)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).Add(cost.Total(), cost.L1Data), nil
+}
@@ -0,0 +1,221 @@
+package wallets
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/models"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/processor"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/signer"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+type row struct {
+	address common.Address
+	chainID int64
+	active  bool
+	drainTo *common.Address
+	drained bool
+}
+
+type memStore struct {
+	mu     sync.Mutex
+	config *models.ChainConfig
+	tokens []*models.SupportedToken
+	rows   []*row
+}
+
+func (s *memStore) GetChainConfig(ctx context.Context, chainID int64) (*models.ChainConfig, error) {
+	return s.config, nil
+}
+
+func (s *memStore) GetSupportedTokens(ctx context.Context) ([]*models.SupportedToken, error) {
+	return s.tokens, nil
+}
+
+func (s *memStore) GetUnminedBatchNonces(ctx context.Context, address string, chainID int64) ([]uint64, error) {
+	return nil, nil
+}
+
+func (s *memStore) GetHotWalletStates(ctx context.Context) ([]*models.HotWalletState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	states := make(map[common.Address]*models.HotWalletState)
+	for _, r := range s.rows {
+		state, ok := states[r.address]
+		if !ok {
+			state = &models.HotWalletState{Address: r.address}
+			states[r.address] = state
+		}
+		if r.active {
+			state.IsActive = true
+			if r.drainTo != nil {
+				state.DrainTo = r.drainTo
+			}
+			if r.drained {
+				state.DrainedChains = append(state.DrainedChains, r.chainID)
+			}
+		}
+	}
+
+	var list []*models.HotWalletState
+	for _, state := range states {
+		list = append(list, state)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Address.Hex() < list[j].Address.Hex() })
+	return list, nil
+}
+
+func (s *memStore) ensureRows(address string, chainIDs []int64) {
+	for _, chainID := range chainIDs {
+		found := false
+		for _, r := range s.rows {
+			if r.address == common.HexToAddress(address) && r.chainID == chainID {
+				found = true
+			}
+		}
+		if !found {
+			s.rows = append(s.rows, &row{address: common.HexToAddress(address), chainID: chainID, active: true})
+		}
+	}
+}
+
+func (s *memStore) AddHotWallet(ctx context.Context, address string, chainIDs []int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ensureRows(address, chainIDs)
+	for _, r := range s.rows {
+		if r.address == common.HexToAddress(address) {
+			r.active, r.drainTo, r.drained = true, nil, false
+		}
+	}
+	return nil
+}
+
+func (s *memStore) DrainHotWallet(ctx context.Context, address, to string, chainIDs []int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ensureRows(address, chainIDs)
+	target := common.HexToAddress(to)
+	for _, r := range s.rows {
+		if r.address == common.HexToAddress(address) && r.active {
+			r.drainTo, r.drained = &target, false
+		}
+	}
+	return nil
+}
+
+func (s *memStore) MarkHotWalletDrained(ctx context.Context, address string, chainID int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, r := range s.rows {
+		if r.address == common.HexToAddress(address) && r.chainID == chainID {
+			r.drained = true
+		}
+	}
+	return nil
+}
+
+func (s *memStore) RetireHotWallet(ctx context.Context, address string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, r := range s.rows {
+		if r.address == common.HexToAddress(address) {
+			r.active = false
+		}
+	}
+	return nil
+}
+
+func TestManagerDrainsAndRetiresWallets(t *testing.T) {
+	ctx := context.Background()
+	chain := testutil.NewChain(t)
+	c := &processor.Chain{ID: chain.ID, Client: chain.Client, Bridge: chain.BridgeAddr}
+
+	oldKey := chain.NewFundedKey(t, testutil.Ether(5))
+	oldAddr := crypto.PubkeyToAddress(oldKey.PublicKey)
+	tx, err := chain.Token.Mint(chain.Transactor(t, chain.Owner), oldAddr, big.NewInt(100))
+	require.NoError(t, err)
+	chain.Mine(t, tx)
+
+	newKey := chain.NewFundedKey(t, testutil.Ether(1))
+	newAddr := crypto.PubkeyToAddress(newKey.PublicKey)
+	loadSigner := func(address common.Address) (signer.Signer, error) {
+		if address != newAddr {
+			return nil, fmt.Errorf("no key for %s", address.Hex())
+		}
+		return signer.NewLocal(newKey), nil
+	}
+
+	store := &memStore{
+		config: &models.ChainConfig{ChainID: chain.ID, ChainType: "ethereum", StuckTxTimeoutSeconds: 300},
+		tokens: []*models.SupportedToken{{ChainID: chain.ID, Address: chain.TokenAddr, IsActive: true}},
+		rows:   []*row{{address: oldAddr, chainID: chain.ID, active: true}},
+	}
+	pool, err := processor.NewWalletPool([]string{hex.EncodeToString(crypto.FromECDSA(oldKey))}, nil)
+	require.NoError(t, err)
+	old := pool.Wallets()[0]
+
+	m := New(processor.StaticChains{c}, pool, loadSigner, store, 0)
+	require.NoError(t, m.Sync(ctx))
+
+	require.ErrorIs(t, m.Add(ctx, common.HexToAddress("0xbeef")), ErrInvalidOperation)
+	require.NoError(t, m.Add(ctx, newAddr))
+	require.Len(t, pool.Wallets(), 2)
+
+	require.ErrorIs(t, m.Retire(ctx, oldAddr), ErrInvalidOperation)
+	require.ErrorIs(t, m.Drain(ctx, oldAddr, oldAddr), ErrInvalidOperation)
+	require.ErrorIs(t, m.Drain(ctx, oldAddr, common.HexToAddress("0xbeef")), ErrInvalidOperation)
+	require.NoError(t, m.Drain(ctx, oldAddr, newAddr))
+	require.True(t, old.Draining())
+
+	// Only the new wallet gets work
+	wallet := pool.GetAvailableWallet(chain.ID)
+	require.Equal(t, newAddr, wallet.Address)
+	require.Nil(t, pool.GetAvailableWallet(chain.ID))
+	pool.ReleaseWallet(wallet)
+
+	// Tokens are swept first, then the native coin once they are mined
+	require.NoError(t, m.DrainChain(ctx, c))
+	require.NoError(t, m.DrainChain(ctx, c))
+	chain.Backend.Commit()
+	tokenBalance, err := chain.Token.BalanceOf(&bind.CallOpts{}, newAddr)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(100), tokenBalance)
+
+	require.NoError(t, m.DrainChain(ctx, c))
+	require.ErrorIs(t, m.Retire(ctx, oldAddr), ErrInvalidOperation)
+	chain.Backend.Commit()
+
+	require.NoError(t, m.DrainChain(ctx, c))
+	balance, err := chain.Client.BalanceAt(ctx, newAddr, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, balance.Cmp(testutil.Ether(5)))
+	states, err := m.Wallets(ctx)
+	require.NoError(t, err)
+	for _, state := range states {
+		require.Equal(t, state.Address == oldAddr, state.Drained(chain.ID))
+	}
+
+	require.NoError(t, m.Retire(ctx, oldAddr))
+	require.Len(t, pool.Wallets(), 1)
+	require.Nil(t, pool.Wallet(oldAddr))
+	to := common.HexToAddress("0xbeef")
+	_, err = old.Signer.SignTx(ctx, types.NewTx(&types.LegacyTx{To: &to}), big.NewInt(chain.ID))
+	require.ErrorContains(t, err, "zeroed")
+}
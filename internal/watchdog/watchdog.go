@@ -0,0 +1,160 @@
+// Package watchdog replaces batch transactions that stay unmined for too
+// long with ones paying higher fees.
+package watchdog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/gas"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/models"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/processor"
+)
+
+const DefaultPollInterval = 30 * time.Second
+
+// Store is the part of models.Database the watchdog uses.
+type Store interface {
+	GetChainConfig(ctx context.Context, chainID int64) (*models.ChainConfig, error)
+	GetBatchesByStatus(ctx context.Context, chainID int64, status string) ([]*models.Batch, error)
+	GetBatchSwaps(ctx context.Context, batchID int64) ([]*models.Swap, error)
+	GetBatchTransactions(ctx context.Context, batchID int64) ([]*models.BatchTransaction, error)
+	ReplaceBatchTx(ctx context.Context, batchTx *models.BatchTransaction) error
+}
+
+// Watchdog looks for processing batches whose latest transaction has been
+// pending longer than the chain's stuck_tx_timeout_seconds and re-signs it
+// with the same nonce and bumped fees. Every replacement is recorded in
+// batch_transactions; the tracker records whichever of them is mined.
+type Watchdog struct {
+	chains     processor.ChainSet
+	walletPool *processor.WalletPool
+	oracle     *gas.Oracle
+	db         Store
+	interval   time.Duration
+}
+
+func New(chains processor.ChainSet, walletPool *processor.WalletPool, db Store, interval time.Duration) *Watchdog {
+	if interval == 0 {
+		interval = DefaultPollInterval
+	}
+	return &Watchdog{
+		chains:     chains,
+		walletPool: walletPool,
+		oracle:     gas.NewOracle(),
+		db:         db,
+		interval:   interval,
+	}
+}
+
+// Start runs one watching loop per chain until ctx is cancelled.
+func (w *Watchdog) Start(ctx context.Context) {
+	go processor.Follow(ctx, w.chains, w.run)
+}
+
+func (w *Watchdog) run(ctx context.Context, chain *processor.Chain) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.checkChain(ctx, chain); err != nil {
+			log.Printf("watchdog: chain %d: %v", chain.ID, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *Watchdog) checkChain(ctx context.Context, chain *processor.Chain) error {
+	config, err := w.db.GetChainConfig(ctx, chain.ID)
+	if err != nil {
+		return err
+	}
+
+	batches, err := w.db.GetBatchesByStatus(ctx, chain.ID, "processing")
+	if err != nil {
+		return err
+	}
+
+	for _, batch := range batches {
+		if batch.SourceTxHash == nil || batch.BlockHash != nil {
+			continue
+		}
+		if err := w.checkBatch(ctx, chain, config, batch); err != nil {
+			log.Printf("watchdog: batch %s: %v", batch.BatchID, err)
+		}
+	}
+	return nil
+}
+
+func (w *Watchdog) checkBatch(ctx context.Context, chain *processor.Chain, config *models.ChainConfig, batch *models.Batch) error {
+	batchTxs, err := w.db.GetBatchTransactions(ctx, batch.ID)
+	if err != nil {
+		return err
+	}
+	if len(batchTxs) == 0 {
+		return nil
+	}
+	latest := batchTxs[len(batchTxs)-1]
+	if time.Since(latest.CreatedAt) < config.StuckTxTimeout() {
+		return nil
+	}
+
+	// The tracker records a transaction that made it into a block.
+	for _, batchTx := range batchTxs {
+		_, err := chain.Client.TransactionReceipt(ctx, common.HexToHash(batchTx.TxHash))
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, ethereum.NotFound) {
+			return fmt.Errorf("error getting receipt: %v", err)
+		}
+	}
+
+	wallet := w.walletPool.Wallet(common.HexToAddress(latest.WalletAddress))
+	if wallet == nil {
+		return fmt.Errorf("wallet %s is not in the pool", latest.WalletAddress)
+	}
+
+	pending, err := processor.PendingFees(latest)
+	if err != nil {
+		return err
+	}
+	fees, err := w.oracle.Replacement(ctx, chain.Client, config, pending)
+	if errors.Is(err, gas.ErrGasPriceTooHigh) {
+		log.Printf("watchdog: batch %s stuck but not replaced: %v", batch.BatchID, err)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	swaps, err := w.db.GetBatchSwaps(ctx, batch.ID)
+	if err != nil {
+		return err
+	}
+	requests, err := processor.SwapRequests(swaps)
+	if err != nil {
+		return err
+	}
+
+	tx, err := wallet.ReplaceBatch(ctx, chain, requests, latest.Nonce, fees)
+	if err != nil {
+		return err
+	}
+	if err := w.db.ReplaceBatchTx(ctx, processor.BatchTransaction(batch.ID, wallet, tx, fees)); err != nil {
+		return err
+	}
+
+	log.Printf("watchdog: batch %s: replaced stuck transaction %s with %s", batch.BatchID, latest.TxHash, tx.Hash().Hex())
+	return nil
+}
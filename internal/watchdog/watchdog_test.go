@@ -0,0 +1,222 @@
+package watchdog
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/gas"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/models"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/processor"
+	"github.com/namdq2/go-cross-chain-bridge-swap/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+type memStore struct {
+	mu       sync.Mutex
+	config   *models.ChainConfig
+	batches  map[int64]*models.Batch
+	swaps    map[int64][]*models.Swap
+	batchTxs map[int64][]*models.BatchTransaction
+}
+
+func (s *memStore) GetChainConfig(ctx context.Context, chainID int64) (*models.ChainConfig, error) {
+	return s.config, nil
+}
+
+func (s *memStore) GetBatchesByStatus(ctx context.Context, chainID int64, status string) ([]*models.Batch, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var batches []*models.Batch
+	for _, b := range s.batches {
+		if b.ChainID == chainID && b.Status == status {
+			copied := *b
+			batches = append(batches, &copied)
+		}
+	}
+	return batches, nil
+}
+
+func (s *memStore) GetBatchSwaps(ctx context.Context, batchID int64) ([]*models.Swap, error) {
+	return s.swaps[batchID], nil
+}
+
+func (s *memStore) GetBatchTransactions(ctx context.Context, batchID int64) ([]*models.BatchTransaction, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*models.BatchTransaction(nil), s.batchTxs[batchID]...), nil
+}
+
+func (s *memStore) ReplaceBatchTx(ctx context.Context, batchTx *models.BatchTransaction) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	batchTx.CreatedAt = time.Now()
+	s.batchTxs[batchTx.BatchID] = append(s.batchTxs[batchTx.BatchID], batchTx)
+	s.batches[batchTx.BatchID].SourceTxHash = &batchTx.TxHash
+	s.batches[batchTx.BatchID].GasPrice = &batchTx.GasPrice
+	return nil
+}
+
+type fixture struct {
+	chain    *testutil.Chain
+	store    *memStore
+	watchdog *Watchdog
+	tx       *types.Transaction
+}
+
+// newFixture submits a one-swap batch priced by the fee oracle for a chain
+// of chainType and records it as processing since sentAt, without mining
+// it.
+func newFixture(t *testing.T, chainType string, sentAt time.Time) *fixture {
+	chain := testutil.NewChain(t)
+
+	pool, err := processor.NewWalletPool([]string{hex.EncodeToString(crypto.FromECDSA(chain.Owner))}, nil)
+	require.NoError(t, err)
+	wallet := pool.Wallet(chain.OwnerAddr)
+	pc := &processor.Chain{ID: chain.ID, Client: chain.Client, Bridge: chain.BridgeAddr}
+
+	config := &models.ChainConfig{
+		ChainID:               chain.ID,
+		ChainType:             chainType,
+		StuckTxTimeoutSeconds: 60,
+	}
+	fees, err := gas.NewOracle().Fees(context.Background(), chain.Client, config)
+	require.NoError(t, err)
+
+	swap := &models.Swap{
+		ID:           1,
+		RequestID:    "swap-1",
+		FromChainID:  chain.ID,
+		ToChainID:    56,
+		TokenAddress: chain.TokenAddr,
+		Amount:       testutil.Ether(1).String(),
+		Recipient:    common.HexToAddress("0xbeef"),
+	}
+	requests, err := processor.SwapRequests([]*models.Swap{swap})
+	require.NoError(t, err)
+	tx, err := wallet.ProcessBatch(context.Background(), pc, requests, fees)
+	require.NoError(t, err)
+
+	batchTx := processor.BatchTransaction(1, wallet, tx, fees)
+	batchTx.CreatedAt = sentAt
+	store := &memStore{
+		config: config,
+		batches: map[int64]*models.Batch{
+			1: {
+				ID:            1,
+				BatchID:       "batch-1",
+				WalletAddress: wallet.Address.Hex(),
+				ChainID:       chain.ID,
+				TargetChainID: 56,
+				SourceTxHash:  &batchTx.TxHash,
+				GasPrice:      &batchTx.GasPrice,
+				Status:        "processing",
+			},
+		},
+		swaps:    map[int64][]*models.Swap{1: {swap}},
+		batchTxs: map[int64][]*models.BatchTransaction{1: {batchTx}},
+	}
+
+	return &fixture{
+		chain:    chain,
+		store:    store,
+		watchdog: New(processor.StaticChains{pc}, pool, store, 0),
+		tx:       tx,
+	}
+}
+
+func (f *fixture) check(t *testing.T) {
+	require.NoError(t, f.watchdog.checkChain(context.Background(), f.watchdog.chains.Chains()[0]))
+}
+
+func (f *fixture) batchTxs() []*models.BatchTransaction {
+	f.store.mu.Lock()
+	defer f.store.mu.Unlock()
+
+	return f.store.batchTxs[1]
+}
+
+// requireBumped checks that replacement raises every fee field of original
+// by at least gas.ReplacementBump percent.
+func requireBumped(t *testing.T, original, replacement *types.Transaction) {
+	for _, fee := range []struct{ old, new *big.Int }{
+		{original.GasTipCap(), replacement.GasTipCap()},
+		{original.GasFeeCap(), replacement.GasFeeCap()},
+	} {
+		want := new(big.Int).Mul(fee.old, big.NewInt(100+gas.ReplacementBump))
+		require.GreaterOrEqual(t, new(big.Int).Mul(fee.new, big.NewInt(100)).Cmp(want), 0)
+	}
+}
+
+func TestWatchdogReplacesStuckTransaction(t *testing.T) {
+	for _, tt := range []struct {
+		chainType string
+		txType    uint8
+	}{
+		{"ethereum", types.DynamicFeeTxType},
+		{"bsc", types.LegacyTxType},
+	} {
+		t.Run(tt.chainType, func(t *testing.T) {
+			f := newFixture(t, tt.chainType, time.Now().Add(-time.Minute))
+			ctx := context.Background()
+
+			f.check(t)
+			batchTxs := f.batchTxs()
+			require.Len(t, batchTxs, 2)
+			replacementHash := common.HexToHash(batchTxs[1].TxHash)
+			require.Equal(t, batchTxs[1].TxHash, *f.store.batches[1].SourceTxHash)
+			require.Equal(t, f.tx.Nonce(), batchTxs[1].Nonce)
+
+			replacement, pending, err := f.chain.Client.TransactionByHash(ctx, replacementHash)
+			require.NoError(t, err)
+			require.True(t, pending)
+			require.Equal(t, tt.txType, replacement.Type())
+			require.Equal(t, f.tx.Nonce(), replacement.Nonce())
+			requireBumped(t, f.tx, replacement)
+
+			// The replacement was just sent, so it is not stuck yet.
+			f.check(t)
+			require.Len(t, f.batchTxs(), 2)
+
+			f.chain.Mine(t, replacement)
+			_, err = f.chain.Client.TransactionReceipt(ctx, f.tx.Hash())
+			require.ErrorIs(t, err, ethereum.NotFound)
+		})
+	}
+}
+
+func TestWatchdogWaitsForTimeout(t *testing.T) {
+	f := newFixture(t, "ethereum", time.Now())
+
+	f.check(t)
+	require.Len(t, f.batchTxs(), 1)
+}
+
+func TestWatchdogLeavesMinedTransaction(t *testing.T) {
+	f := newFixture(t, "ethereum", time.Now().Add(-time.Minute))
+	f.chain.Mine(t, f.tx)
+
+	f.check(t)
+	require.Len(t, f.batchTxs(), 1)
+}
+
+func TestWatchdogRespectsMaxGasPrice(t *testing.T) {
+	f := newFixture(t, "ethereum", time.Now().Add(-time.Minute))
+	// Room for the pending fees, not for bumping them.
+	maxGasPrice := f.tx.GasFeeCap().String()
+	f.store.config.MaxGasPrice = &maxGasPrice
+
+	f.check(t)
+	require.Len(t, f.batchTxs(), 1)
+	require.Equal(t, f.tx.Hash().Hex(), *f.store.batches[1].SourceTxHash)
+}